@@ -1,19 +1,43 @@
 package main
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"math"
 	"math/big"
+	"math/rand/v2"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	floatBit "github.com/shantanu-gontia/float-conv/pkg"
 	BF16 "github.com/shantanu-gontia/float-conv/pkg/bfloat16bits"
+	F128 "github.com/shantanu-gontia/float-conv/pkg/float128bits"
 	F16 "github.com/shantanu-gontia/float-conv/pkg/float16bits"
 	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+	F64 "github.com/shantanu-gontia/float-conv/pkg/float64bits"
+	F80 "github.com/shantanu-gontia/float-conv/pkg/float80bits"
+	E2M1 "github.com/shantanu-gontia/float-conv/pkg/fp4e2m1bits"
+	E2M3 "github.com/shantanu-gontia/float-conv/pkg/fp6e2m3bits"
+	E3M2 "github.com/shantanu-gontia/float-conv/pkg/fp6e3m2bits"
+	E4M3 "github.com/shantanu-gontia/float-conv/pkg/fp8e4m3bits"
+	E4M3FNUZ "github.com/shantanu-gontia/float-conv/pkg/fp8e4m3fnuzbits"
+	E5M2 "github.com/shantanu-gontia/float-conv/pkg/fp8e5m2bits"
+	E5M2FNUZ "github.com/shantanu-gontia/float-conv/pkg/fp8e5m2fnuzbits"
+	MX "github.com/shantanu-gontia/float-conv/pkg/mx"
+	NVFP4 "github.com/shantanu-gontia/float-conv/pkg/nvfp4"
+	TF32 "github.com/shantanu-gontia/float-conv/pkg/tf32bits"
 )
 
+// Minimum precision used to parse the input when converting to float64,
+// x87, float128 or a custom format
+const wideInputPrecision uint = 256
+
 type ProgramInputs struct {
 	input  big.Float
 	format string
@@ -24,20 +48,83 @@ type ProgramInputs struct {
 
 func main() {
 	// Declare cmdline flags
-	valStrPtr := flag.String("num", "nil", "Input floating point number. Required.")
+	valStrPtr := flag.String("num", "nil", "Input floating point number. Required. "+
+		"For the MX formats this is a comma-separated list of up to 32 numbers, and for nvfp4 a comma-separated list "+
+		"of any length")
+	bitsStrPtr := flag.String("bits", "",
+		"Raw bits to decode in the format instead of converting a number, in hexadecimal (0x...), binary (0b...), "+
+			"octal (0o...) or decimal. Only supported for the scalar formats")
 	formatStrPtr := flag.String("format", "float32",
-		"Target floating point format (Supported values are float32)")
+		"Target floating point format (Supported values are float128, x87, float64, float32, bfloat16, tf32, "+
+			"float16, e4m3, e5m2, e4m3fnuz, e5m2fnuz, e2m3, e3m2, e2m1, mxfp8e4m3, mxfp8e5m2, mxfp6e2m3, "+
+			"mxfp6e3m2, mxfp4, mxint8, nvfp4, or custom:e=<exponent bits>,m=<mantissa bits>[,bias=<bias>]"+
+			"[,inf=<true|false>][,nan=<ieee|allones|negzero|none>][,negzero=<true|false>]). all, or a "+
+			"comma-separated list of scalar formats, compares the conversions to each of them in a single table")
 	rouningModeStrPtr := flag.String("round-mode", "rne", "Rounding Mode to use (Supported values are rne, "+
-		"rno, rtz, rtposinf, rtneginf, rthalfzero, rthalfposinf, rthalfneginf)")
-	overflowModeStrPtr := flag.String("overflow-mode", "satmax",
-		"Overflow behavior (Supported values are satmax, satinf, nan)")
-	underflowModeStrPtr := flag.String("underflow-mode", "satmin",
-		"Overflow behavior (Supported values are satmin, flushzero)")
+		"rno, rtz, rtposinf, rtneginf, rtaway, rthalfzero, rthalfposinf, rthalfneginf, rthalfaway, sr). all "+
+		"compares the results of every rounding mode in a single table")
+	overflowModeStrPtr := flag.String("overflow-mode", "",
+		"Overflow behavior (Supported values are ieee, satmax, satinf, nan, and all with --round-mode=all). The default is ieee for the IEEE-754 "+
+			"formats (float128, x87, float64, float32 and float16), and satmax for the others")
+	underflowModeStrPtr := flag.String("underflow-mode", "",
+		"Underflow behavior (Supported values are subnormal, satmin, flushzero, and all with --round-mode=all). The default is subnormal for the "+
+			"IEEE-754 formats (float128, x87, float64, float32 and float16), and satmin for the others")
+	nanPolicyStrPtr := flag.String("nan-policy", "quiet",
+		"How NaN inputs like nan, -snan or nan(0x2a) are converted (Supported values are canonicalize, preserve, "+
+			"quiet)")
+	tininessStrPtr := flag.String("tininess", "before",
+		"Whether numbers are tiny before or after they are rounded to the precision of the format, which decides "+
+			"when the underflow mode applies and when underflow is raised (Supported values are before, after)")
 	precisionPtr := flag.Uint("precision", 53, "Precision to use for the input floating point")
+	tensorScaleStrPtr := flag.String("tensor-scale", "1",
+		"Per-tensor scale for nvfp4. Either a number, or auto to derive it from the input")
+	seedPtr := flag.Uint64("seed", 0, "Seed for the random bits used by stochastic rounding (sr)")
+	samplesPtr := flag.Uint("samples", 1,
+		"Number of times to convert the input. With more than 1, the distribution of the results is printed")
+	opStrPtr := flag.String("op", "", "Arithmetic operation to perform on the operands a, b and c instead of "+
+		"converting the input (Supported values are add, sub, mul, div, sqrt, fma). Only supported for float32, "+
+		"bfloat16 and float16")
+	aStrPtr := flag.String("a", "", "First operand of the arithmetic operation")
+	bStrPtr := flag.String("b", "", "Second operand of the arithmetic operation")
+	cStrPtr := flag.String("c", "", "Third operand of the arithmetic operation (fma only)")
+	outputStrPtr := flag.String("output", "text",
+		"Output format of conversions (Supported values are text, json, csv). json and csv write a record for "+
+			"every conversion, element of the MX formats and nvfp4, or arithmetic operation, with the same fields. "+
+			"Not supported for --bits and --samples")
 
 	// Parse the flags
 	flag.Parse()
 
+	// Parse the output format
+	output, err := parseOutput(outputStrPtr)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if output != "text" && (*bitsStrPtr != "" || *samplesPtr > 1) {
+		fmt.Println("--output=" + output + " is not supported for decoding --bits, or with --samples")
+		os.Exit(1)
+	}
+
+	// Every rounding mode is compared in a single table
+	if strings.ToLower(*rouningModeStrPtr) == "all" {
+		if _, isList, _ := parseFormatList(*formatStrPtr); isList {
+			fmt.Println("Only a single format is supported with all rounding modes")
+			os.Exit(1)
+		}
+		if *opStrPtr != "" || *bitsStrPtr != "" || *samplesPtr > 1 {
+			fmt.Println("All rounding modes are only supported for converting --num")
+			os.Exit(1)
+		}
+		err := handleModeMatrix(*valStrPtr, *formatStrPtr, *precisionPtr, *overflowModeStrPtr, *underflowModeStrPtr,
+			tininessStrPtr, rand.New(rand.NewPCG(*seedPtr, 0)), output)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Parse the rounding mode
 	roundingMode, err := parseRoundingMode(rouningModeStrPtr)
 	if err != nil {
@@ -46,161 +133,1487 @@ func main() {
 	}
 
 	// Parse the overflow mode
-	overflowMode, err := parseOverflowMode(overflowModeStrPtr)
+	overflowMode, err := parseOverflowMode(overflowModeStrPtr, formatStrPtr)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
 	// Pares the underflow mode
-	underflowMode, err := parseUnderflowMode(underflowModeStrPtr)
+	underflowMode, err := parseUnderflowMode(underflowModeStrPtr, formatStrPtr)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	// Parse when tininess is detected
+	tininess, err := parseTininess(tininessStrPtr)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	// Parse the NaN policy
+	nanPolicy, err := parseNaNPolicy(nanPolicyStrPtr)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	// The same seed always gives the same random bits, so stochastic rounding
+	// is reproducible
+	randomBits := rand.New(rand.NewPCG(*seedPtr, 0))
+
+	if *samplesPtr == 0 {
+		fmt.Println("The number of samples must be at least 1")
+		os.Exit(1)
+	}
+
+	// Arithmetic operations take their operands from different flags
+	if *opStrPtr != "" {
+		if *samplesPtr > 1 {
+			fmt.Println("Sampling is not supported for arithmetic operations")
+			os.Exit(1)
+		}
+		err := handleOp(*opStrPtr, *formatStrPtr, []string{*aStrPtr, *bStrPtr, *cStrPtr}, *precisionPtr,
+			roundingMode, overflowMode, underflowMode, tininess, randomBits, output)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Raw bits are decoded as they are, without any rounding
+	if *bitsStrPtr != "" {
+		if *valStrPtr != "nil" {
+			fmt.Println("Only one of --num and --bits can be given")
+			os.Exit(1)
+		}
+		if *samplesPtr > 1 {
+			fmt.Println("Sampling is not supported for decoding bits")
+			os.Exit(1)
+		}
+		if err := handleBits(*bitsStrPtr, *formatStrPtr); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// MX formats quantize a whole block of values, so they take a different
+	// path
+	_, isMX := parseMXFormat(formatStrPtr)
+	if *samplesPtr > 1 && (isMX || strings.ToLower(*formatStrPtr) == "nvfp4") {
+		fmt.Println("Sampling is only supported for scalar formats")
+		os.Exit(1)
+	}
+	if mxFormat, ok := parseMXFormat(formatStrPtr); ok {
+		valueStrs := splitValueList(*valStrPtr)
+		values, exacts, err := parseValueList(valueStrs, *precisionPtr, roundingMode)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if err := handleMX(valueStrs, values, exacts, mxFormat, roundingMode, randomBits, output); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// So does NVFP4
+	if strings.ToLower(*formatStrPtr) == "nvfp4" {
+		valueStrs := splitValueList(*valStrPtr)
+		values, _, err := parseValueList(valueStrs, *precisionPtr, roundingMode)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		asFloat32s := make([]float32, len(values))
+		for i := range values {
+			asFloat32s[i], _ = values[i].Float32()
+		}
+		tensorScale, err := parseTensorScale(tensorScaleStrPtr, asFloat32s)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if err := handleNVFP4(valueStrs, asFloat32s, tensorScale, roundingMode, randomBits, output); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Several formats are compared in a single table
+	formats, isList, err := parseFormatList(*formatStrPtr)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if isList {
+		if *samplesPtr > 1 {
+			fmt.Println("Sampling is not supported when comparing formats")
+			os.Exit(1)
+		}
+		err := handleComparison(*valStrPtr, formats, *precisionPtr, roundingMode, overflowModeStrPtr,
+			underflowModeStrPtr, tininessStrPtr, randomBits, output)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	precision := max(*precisionPtr, inputPrecision(*formatStrPtr))
+
+	// Custom formats are described by the format string itself
+	customSpec, isCustom := strings.CutPrefix(strings.ToLower(*formatStrPtr), "custom:")
+	var customFormat floatBit.Format
+	if isCustom {
+		customFormat, err = parseCustomFormat(customSpec)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	// NaNs aren't rounded, they are converted with the NaN policy instead
+	if nan, ok := floatBit.ParseNaN(*valStrPtr); ok {
+		if *samplesPtr > 1 {
+			fmt.Println("Sampling is not supported for NaNs")
+			os.Exit(1)
+		}
+		if err := handleNaN(*valStrPtr, nan, *formatStrPtr, nanPolicy, output); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Input Value
-	val, _, err := big.ParseFloat(*valStrPtr, 0, *precisionPtr, roundingMode.ToBigRoundingMode())
+	val, err := floatBit.ParseFloat(*valStrPtr, precision, roundingMode)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	exact, err := parseExactInput(*valStrPtr, precision)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	// Call the appropriate handlers
-	switch strings.ToLower(*formatStrPtr) {
-	case "float32":
-		fallthrough
-	case "fp32":
-		handleFloat32(val, roundingMode, overflowMode, underflowMode)
-	case "bfloat16":
-		fallthrough
-	case "bf16":
-		handleBFloat16(val, roundingMode, overflowMode, underflowMode)
+	if *samplesPtr > 1 {
+		name := "Custom " + customFormat.String()
+		if !isCustom {
+			name = *formatStrPtr
+			var ok bool
+			customFormat, ok = parseScalarFormat(formatStrPtr)
+			if !ok {
+				fmt.Println("Sampling is not supported for " + *formatStrPtr)
+				os.Exit(1)
+			}
+		}
+		handleSamples(val, name, customFormat, roundingMode, overflowMode, underflowMode, tininess, randomBits, *samplesPtr)
+		return
 	}
 
+	// Convert the input, and print the details
+	sf, err := lookupScalarFormat(*formatStrPtr)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	c := sf.convert(val, roundingMode, overflowMode, underflowMode, tininess, randomBits).againstExact(exact, val.Prec())
+	if output != "text" {
+		record := newConversionRecord(sf.names[0], *valStrPtr, exact.Text('x', -1), c)
+		record.setModes(roundingMode, overflowMode, underflowMode, tininess)
+		if err := writeRecords([]conversionRecord{record}, output); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	fmt.Println(sf.title)
+	printConversion(c, "Conversion Error")
 }
 
-// Call the appropriate functions and methods required to put together the information to print for FP32
-func handleFloat32(bf *big.Float, rm floatBit.RoundingMode, om floatBit.OverflowMode, um floatBit.UnderflowMode) {
-	// First we print the type
-	fmt.Println("Float32")
+// A scalar format the CLI converts to
+type scalarFormat struct {
+	// The values of --format that select the format. The first one is used in the comparison table
+	names []string
+	// Printed above the details of a conversion
+	title string
+	// Converts a number to the format
+	convert func(*big.Float, floatBit.RoundingMode, floatBit.OverflowMode, floatBit.UnderflowMode, floatBit.Tininess,
+		floatBit.RandomBits) conversion
+	// Converts a NaN to the format, with the NaN policy
+	convertNaN func(floatBit.NaN, floatBit.NaNPolicy) conversion
+}
 
-	// Get the Float32 Value
-	floatVal, accuracy, status := F32.FromBigFloat(*bf, rm, om, um)
+// The scalar formats, in the order they are compared in
+var scalarFormats = []scalarFormat{
+	{[]string{"float128", "fp128"}, "Float128", convertFloat128, formatConvertNaN(floatBit.FormatFloat128)},
+	{[]string{"x87", "float80", "fp80"}, "x87 Extended Precision (80-bit)", convertFloat80,
+		convertNaNFloat80},
+	packageFormat([]string{"float64", "fp64"}, "Float64", floatBit.FormatFloat64, F64.FromBigFloat),
+	packageFormat([]string{"float32", "fp32"}, "Float32", floatBit.FormatFloat32, F32.FromBigFloat),
+	{[]string{"tf32", "tensorfloat32"}, "TF32", convertTF32, formatConvertNaN(floatBit.FormatTF32)},
+	packageFormat([]string{"bfloat16", "bf16"}, "BFloat16", floatBit.FormatBFloat16, BF16.FromBigFloat),
+	packageFormat([]string{"float16", "fp16"}, "Float16", floatBit.FormatFloat16, F16.FromBigFloat),
+	packageFormat([]string{"e5m2", "fp8e5m2"}, "FP8 E5M2", floatBit.FormatE5M2, E5M2.FromBigFloat),
+	packageFormat([]string{"e4m3", "fp8e4m3"}, "FP8 E4M3", floatBit.FormatE4M3, E4M3.FromBigFloat),
+	packageFormat([]string{"e5m2fnuz", "fp8e5m2fnuz"}, "FP8 E5M2FNUZ", floatBit.FormatE5M2FNUZ,
+		E5M2FNUZ.FromBigFloat),
+	packageFormat([]string{"e4m3fnuz", "fp8e4m3fnuz"}, "FP8 E4M3FNUZ", floatBit.FormatE4M3FNUZ,
+		E4M3FNUZ.FromBigFloat),
+	packageFormat([]string{"e3m2", "fp6e3m2"}, "FP6 E3M2", floatBit.FormatE3M2, E3M2.FromBigFloat),
+	packageFormat([]string{"e2m3", "fp6e2m3"}, "FP6 E2M3", floatBit.FormatE2M3, E2M3.FromBigFloat),
+	packageFormat([]string{"e2m1", "fp4e2m1"}, "FP4 E2M1", floatBit.FormatE2M1, E2M1.FromBigFloat),
+}
 
-	// Print the bits in a table
-	fmt.Print(floatVal.ToFloatFormat().AsTable())
+// Describes a format whose package represents the bits as an unsigned integer
+func packageFormat[T ~uint8 | ~uint16 | ~uint32 | ~uint64](names []string, title string, f floatBit.Format,
+	fromBigFloat func(big.Float, floatBit.RoundingMode, floatBit.OverflowMode, floatBit.UnderflowMode, floatBit.Tininess,
+		...floatBit.RandomBits) (T, big.Accuracy, floatBit.Status)) scalarFormat {
+	convert := func(bf *big.Float, rm floatBit.RoundingMode, om floatBit.OverflowMode, um floatBit.UnderflowMode,
+		t floatBit.Tininess, rb floatBit.RandomBits) conversion {
+		floatVal, accuracy, status := fromBigFloat(*bf, rm, om, um, t, rb)
+		return newConversion(f, new(big.Int).SetUint64(uint64(floatVal)), bf, accuracy, status,
+			f.Exceptions(bf, rm, t, accuracy, status))
+	}
+	return scalarFormat{names, title, convert, formatConvertNaN(f)}
+}
 
-	// Print the decimal value
-	asBigFloat := floatVal.ToBigFloat()
-	fmt.Printf("Decimal: %s\n", asBigFloat.Text('e', -1))
+// Describes a custom format, which is converted to with the generic encoder. The name is the value of --format
+func customScalarFormat(name string, f floatBit.Format) scalarFormat {
+	convert := func(bf *big.Float, rm floatBit.RoundingMode, om floatBit.OverflowMode, um floatBit.UnderflowMode,
+		t floatBit.Tininess, rb floatBit.RandomBits) conversion {
+		bits, accuracy, status := floatBit.Encode(*bf, f, rm, om, um, t, rb)
+		return newConversion(f, bits, bf, accuracy, status, f.Exceptions(bf, rm, t, accuracy, status))
+	}
+	return scalarFormat{[]string{name}, "Custom " + f.String(), convert, formatConvertNaN(f)}
+}
+
+// Returns the minimum precision to parse the input with for the format. Parsing the input with the default precision
+// would already round it to float64, so float64, x87, float128 and custom formats need more precision to apply their
+// own rounding
+func inputPrecision(format string) uint {
+	lowerFormat := strings.ToLower(strings.TrimSpace(format))
+	switch lowerFormat {
+	case "float64", "fp64", "x87", "float80", "fp80", "float128", "fp128":
+		return wideInputPrecision
+	}
+	if spec, isCustom := strings.CutPrefix(lowerFormat, "custom:"); isCustom {
+		// Invalid custom formats are reported when they are parsed
+		if f, err := parseCustomFormat(spec); err == nil {
+			return wideInputPrecision + uint(f.MantissaBits)
+		}
+	}
+	return 0
+}
 
-	// Print the hexfloat value
-	fmt.Printf("Hexfloat: %x\n", floatVal.ToFloat32())
+// Returns the scalar format selected by the value of --format, including custom formats
+func lookupScalarFormat(format string) (scalarFormat, error) {
+	lowerFormat := strings.ToLower(strings.TrimSpace(format))
+	if spec, isCustom := strings.CutPrefix(lowerFormat, "custom:"); isCustom {
+		f, err := parseCustomFormat(spec)
+		if err != nil {
+			return scalarFormat{}, err
+		}
+		return customScalarFormat(lowerFormat, f), nil
+	}
+	for _, sf := range scalarFormats {
+		if slices.Contains(sf.names, lowerFormat) {
+			return sf, nil
+		}
+	}
+	return scalarFormat{}, errors.New("Unsupported format " + format)
+}
+
+// Converts NaNs to a format with its descriptor
+func formatConvertNaN(f floatBit.Format) func(floatBit.NaN, floatBit.NaNPolicy) conversion {
+	return func(nan floatBit.NaN, p floatBit.NaNPolicy) conversion {
+		bits, status, exceptions := f.EncodeNaN(nan, p)
+		return newConversion(f, bits, nil, big.Exact, status, exceptions)
+	}
+}
+
+// Float128 has its own conversion, and its bits are split into two halves
+func convertFloat128(bf *big.Float, rm floatBit.RoundingMode, om floatBit.OverflowMode, um floatBit.UnderflowMode,
+	t floatBit.Tininess, rb floatBit.RandomBits) conversion {
+	floatVal, accuracy, status := F128.FromBigFloat(*bf, rm, om, um, t, rb)
+	bits := new(big.Int).SetUint64(floatVal.Hi)
+	bits.Lsh(bits, 64)
+	bits.Or(bits, new(big.Int).SetUint64(floatVal.Lo))
+	return newConversion(floatBit.FormatFloat128, bits, bf, accuracy, status,
+		floatBit.FormatFloat128.Exceptions(bf, rm, t, accuracy, status))
+}
+
+// TF32 is stored in the upper 19 bits of a 32-bit container, so the encoding is shifted down
+func convertTF32(bf *big.Float, rm floatBit.RoundingMode, om floatBit.OverflowMode, um floatBit.UnderflowMode,
+	t floatBit.Tininess, rb floatBit.RandomBits) conversion {
+	floatVal, accuracy, status := TF32.FromBigFloat(*bf, rm, om, um, t, rb)
+	return newConversion(floatBit.FormatTF32, new(big.Int).SetUint64(uint64(floatVal>>13)), bf, accuracy, status,
+		floatBit.FormatTF32.Exceptions(bf, rm, t, accuracy, status))
+}
+
+// x87 has its own conversion, and the explicit integer bit doesn't fit the generic formats
+func convertFloat80(bf *big.Float, rm floatBit.RoundingMode, om floatBit.OverflowMode, um floatBit.UnderflowMode,
+	t floatBit.Tininess, rb floatBit.RandomBits) conversion {
+	floatVal, accuracy, status := F80.FromBigFloat(*bf, rm, om, um, t, rb)
+	return newX87Conversion(floatVal, bf, accuracy, status, F80.FormatWithoutIntegerBit.Exceptions(bf, rm, t, accuracy, status))
+}
 
-	// Print the conversion error
-	conv, err := floatVal.ConversionError(bf)
-	var convStr string
-	if err == nil {
-		convStr = conv.Text('e', -1)
+// Converts NaNs to x87
+func convertNaNFloat80(nan floatBit.NaN, p floatBit.NaNPolicy) conversion {
+	floatVal, status, exceptions := F80.FromNaN(nan, p)
+	return newX87Conversion(floatVal, nil, big.Exact, status, exceptions)
+}
+
+// The result of converting a number (or performing an operation) in one of the formats, with everything that is
+// printed about it
+type conversion struct {
+	table floatBit.FloatBitFormat
+	bits  *big.Int
+	width int
+	// The value of the bits, nil for NaNs
+	value *big.Float
+	// The kind and payload of the bits, nil if they aren't a NaN
+	nan *floatBit.NaN
+	// The difference of the value to the exact value it was rounded from, nil if either of them is a NaN
+	err        *big.Float
+	accuracy   big.Accuracy
+	status     floatBit.Status
+	exceptions floatBit.Exceptions
+}
+
+// Put together the conversion result for the bits of a number in the given format. A nil exact value is a NaN
+func newConversion(f floatBit.Format, bits *big.Int, exact *big.Float, accuracy big.Accuracy,
+	status floatBit.Status, exceptions floatBit.Exceptions) conversion {
+	c := conversion{table: f.ToFloatFormat(bits), bits: bits, width: f.Width(), accuracy: accuracy, status: status,
+		exceptions: exceptions}
+	// [big.Float] cannot represent NaN
+	if asBigFloat, err := f.Decode(bits); err == nil {
+		c.value = &asBigFloat
+	} else {
+		nan, _ := f.DecodeNaN(bits)
+		c.nan = &nan
+	}
+	c.err = conversionError(c.value, exact)
+	return c
+}
+
+// Put together the conversion result for an x87 number. A nil exact value is a NaN
+func newX87Conversion(floatVal F80.Bits, exact *big.Float, accuracy big.Accuracy, status floatBit.Status,
+	exceptions floatBit.Exceptions) conversion {
+	bits := new(big.Int).SetUint64(uint64(floatVal.SignExponent))
+	bits.Lsh(bits, 64)
+	bits.Or(bits, new(big.Int).SetUint64(floatVal.Mantissa))
+	c := conversion{table: floatVal.ToFloatFormat(), bits: bits, width: 80, accuracy: accuracy, status: status,
+		exceptions: exceptions}
+	if nan, ok := floatVal.DecodeNaN(); ok {
+		c.nan = &nan
 	} else {
-		convStr = "NaN"
+		asBigFloat := floatVal.ToBigFloat()
+		c.value = &asBigFloat
+	}
+	c.err = conversionError(c.value, exact)
+	return c
+}
+
+// Measures the error of the conversion against the exact input, instead of the number that was converted. The error
+// is rounded to prec bits, the precision of the number that was converted
+func (c conversion) againstExact(exact *big.Float, prec uint) conversion {
+	c.err = conversionError(c.value, exact)
+	if c.err != nil {
+		c.err.SetPrec(prec)
+	}
+	return c
+}
+
+// Returns value - exact, or nil if either of them is a NaN (nil)
+func conversionError(value, exact *big.Float) *big.Float {
+	if value == nil || exact == nil {
+		return nil
+	}
+	// Infinities with the same sign are equal
+	result := new(big.Float)
+	if !value.IsInf() || !exact.IsInf() || value.Signbit() != exact.Signbit() {
+		result.Sub(value, exact)
 	}
-	fmt.Printf("Conversion Error: %s (%s)\n", convStr, accuracy)
+	return result
+}
+
+// Returns the value in decimal, or NaN
+func (c conversion) valueText() string {
+	if c.value == nil {
+		return "NaN"
+	}
+	return c.value.Text('e', -1)
+}
+
+// Returns the value in decimal like [conversion.valueText], but with as many more digits as it takes to tell it apart
+// from the exact value it was converted from. The shortest decimal of a float64 or wider value can be the same as the
+// input, even though the error isn't zero
+func (c conversion) valueTextAgainst(exact *big.Float) string {
+	text := c.valueText()
+	if c.value == nil || exact == nil || c.value.Cmp(exact) == 0 {
+		return text
+	}
+	// The values differ, so their decimals differ with enough digits after the point
+	mantissa, _, _ := strings.Cut(text, "e")
+	digits := 0
+	if _, fraction, ok := strings.Cut(mantissa, "."); ok {
+		digits = len(fraction)
+	}
+	for c.value.Text('e', digits) == exact.Text('e', digits) {
+		digits++
+	}
+	return c.value.Text('e', digits)
+}
+
+// Returns the error in decimal with the given number of digits (-1 for as many as needed), or NaN
+func (c conversion) errorText(prec int) string {
+	if c.err == nil {
+		return "NaN"
+	}
+	return c.err.Text('e', prec)
+}
+
+// Print the bits of a conversion in a table, its value, its error, the status and the exception flags
+func printConversion(c conversion, errorName string) {
+	// Print the bits in a table
+	fmt.Print(c.table.AsTable())
+
+	// Print the decimal and hexfloat values, and the kind and payload of NaNs
+	if c.value != nil {
+		fmt.Printf("Decimal: %s\n", c.value.Text('e', -1))
+		fmt.Printf("Hexfloat: %s\n", c.value.Text('x', -1))
+	} else {
+		fmt.Println("Decimal: NaN")
+		fmt.Println("Hexfloat: NaN")
+		printNaN(*c.nan)
+	}
+
+	// Print the error
+	fmt.Printf("%s: %s (%s)\n", errorName, c.errorText(-1), c.accuracy)
 
 	// Print the bits in binary
-	fmt.Printf("Binary: %0#32b\n", floatVal)
+	fmt.Printf("Binary: 0b%0*b\n", c.width, c.bits)
 
 	// Print the bits in hexadecimal
-	fmt.Printf("Hexadecimal: %0#8x\n", floatVal)
+	fmt.Printf("Hexadecimal: 0x%0*x\n", (c.width+3)/4, c.bits)
 
-	if status != floatBit.Fits {
-		fmt.Printf("%s\n", strings.ToUpper(status.String()))
+	if c.status != floatBit.Fits {
+		fmt.Printf("%s\n", strings.ToUpper(c.status.String()))
 	}
+
+	printExceptions(c.exceptions)
 }
 
-// Call the appropriate functions and methods required to put together the information to print for BF16
-func handleBFloat16(bf *big.Float, rm floatBit.RoundingMode, om floatBit.OverflowMode, um floatBit.UnderflowMode) {
-	// First we print the type
-	fmt.Println("BFloat16")
+// Returns the formats of a --format value that compares several formats, which is either all, or a comma-separated
+// list of formats. Custom formats contain commas themselves, so their options are joined back to them. Returns false
+// if the value selects a single format
+func parseFormatList(format string) ([]scalarFormat, bool, error) {
+	lowerFormat := strings.ToLower(strings.TrimSpace(format))
+	if lowerFormat == "all" {
+		return scalarFormats, true, nil
+	}
 
-	// Get the BFloat16 Value
-	floatVal, accuracy, status := BF16.FromBigFloat(*bf, rm, om, um)
+	var names []string
+	for _, name := range strings.Split(lowerFormat, ",") {
+		name = strings.TrimSpace(name)
+		if len(names) > 0 && strings.HasPrefix(names[len(names)-1], "custom:") && strings.Contains(name, "=") {
+			names[len(names)-1] += "," + name
+			continue
+		}
+		names = append(names, name)
+	}
+	if len(names) == 1 {
+		return nil, false, nil
+	}
 
-	// Print the bits in a table
-	fmt.Print(floatVal.ToFloatFormat().AsTable())
+	formats := make([]scalarFormat, len(names))
+	for i, name := range names {
+		var err error
+		if formats[i], err = lookupScalarFormat(name); err != nil {
+			return nil, true, err
+		}
+	}
+	return formats, true, nil
+}
+
+// Convert the input to each of the formats, and print the results in a single table. The overflow and underflow
+// modes default to the ones of each format
+func handleComparison(valStr string, formats []scalarFormat, precision uint, rm floatBit.RoundingMode,
+	overflowModeStrPtr, underflowModeStrPtr, tininessStrPtr *string, rb floatBit.RandomBits, output string) error {
+	// The input is parsed once, with enough precision for the widest format
+	for _, sf := range formats {
+		precision = max(precision, inputPrecision(sf.names[0]))
+	}
+	if _, ok := floatBit.ParseNaN(valStr); ok {
+		return errors.New("NaN inputs are not supported when comparing formats")
+	}
+	val, err := floatBit.ParseFloat(valStr, precision, rm)
+	if err != nil {
+		return err
+	}
+	t, err := parseTininess(tininessStrPtr)
+	if err != nil {
+		return err
+	}
+	exact, err := parseExactInput(valStr, precision)
+	if err != nil {
+		return err
+	}
+
+	sb := strings.Builder{}
+	writer := tabwriter.NewWriter(&sb, 0, 0, 0, ' ', tabwriter.AlignRight|tabwriter.Debug)
+	fmt.Fprintf(writer, "\tFormat\tBits\tValue\tError\tRelative Error\tAccuracy\tStatus\t\n")
+	records := make([]conversionRecord, 0, len(formats))
+	for _, sf := range formats {
+		overflowMode, err := parseOverflowMode(overflowModeStrPtr, &sf.names[0])
+		if err != nil {
+			return err
+		}
+		underflowMode, err := parseUnderflowMode(underflowModeStrPtr, &sf.names[0])
+		if err != nil {
+			return err
+		}
+
+		c := sf.convert(val, rm, overflowMode, underflowMode, t, rb).againstExact(exact, val.Prec())
+		fmt.Fprintf(writer, "\t%s\t0x%0*x\t%s\t%s\t%s\t%s\t%s\t\n", sf.names[0], (c.width+3)/4, c.bits,
+			c.valueTextAgainst(exact), c.errorText(6), relativeError(c.err, exact), c.accuracy, c.status)
+		record := newConversionRecord(sf.names[0], valStr, exact.Text('x', -1), c)
+		record.setModes(rm, overflowMode, underflowMode, t)
+		records = append(records, record)
+	}
+	if output != "text" {
+		return writeRecords(records, output)
+	}
+	writer.Flush()
+	fmt.Printf("Input: %s (%s)\n", exact.Text('e', -1), exact.Text('x', -1))
+	fmt.Print(sb.String())
+	return nil
+}
+
+// The values of --round-mode, --overflow-mode and --underflow-mode, in the order they are compared in
+var (
+	roundingModeNames = []string{"rne", "rno", "rtz", "rtposinf", "rtneginf", "rtaway", "rthalfzero", "rthalfposinf",
+		"rthalfneginf", "rthalfaway", "sr"}
+	overflowModeNames  = []string{"ieee", "satmax", "satinf", "nan"}
+	underflowModeNames = []string{"subnormal", "satmin", "flushzero"}
+)
+
+// Convert the input to the format with every rounding mode, and print the results in a single table. The overflow and
+// underflow modes are either the given ones, or every one of them if they are all. Every distinct encoding is numbered,
+// so the modes that produce the same bits can be told apart from the ones that don't
+func handleModeMatrix(valStr string, format string, precision uint, overflowModeStr, underflowModeStr string,
+	tininessStrPtr *string, rb floatBit.RandomBits, output string) error {
+	sf, err := lookupScalarFormat(format)
+	if err != nil {
+		return err
+	}
+	t, err := parseTininess(tininessStrPtr)
+	if err != nil {
+		return err
+	}
+	overflowModeStrs := []string{overflowModeStr}
+	if strings.ToLower(overflowModeStr) == "all" {
+		overflowModeStrs = overflowModeNames
+	}
+	underflowModeStrs := []string{underflowModeStr}
+	if strings.ToLower(underflowModeStr) == "all" {
+		underflowModeStrs = underflowModeNames
+	}
+
+	precision = max(precision, inputPrecision(format))
+	if _, ok := floatBit.ParseNaN(valStr); ok {
+		return errors.New("NaN inputs are not rounded, so they are not supported with all rounding modes")
+	}
+	exact, err := parseExactInput(valStr, precision)
+	if err != nil {
+		return err
+	}
+
+	sb := strings.Builder{}
+	writer := tabwriter.NewWriter(&sb, 0, 0, 0, ' ', tabwriter.AlignRight|tabwriter.Debug)
+	fmt.Fprintf(writer, "\tRound Mode\tOverflow\tUnderflow\tBits\tValue\tError\tAccuracy\tStatus\tEncoding\t\n")
+	var encodings []*big.Int
+	var records []conversionRecord
+	for _, roundingModeStr := range roundingModeNames {
+		rm, err := parseRoundingMode(&roundingModeStr)
+		if err != nil {
+			return err
+		}
+		// Inputs that are too large to parse exactly are parsed with the rounding mode
+		roundedVal, err := floatBit.ParseFloat(valStr, precision, rm)
+		if err != nil {
+			return err
+		}
+		for _, overflowModeStr := range overflowModeStrs {
+			for _, underflowModeStr := range underflowModeStrs {
+				om, err := parseOverflowMode(&overflowModeStr, &format)
+				if err != nil {
+					return err
+				}
+				um, err := parseUnderflowMode(&underflowModeStr, &format)
+				if err != nil {
+					return err
+				}
+
+				c := sf.convert(roundedVal, rm, om, um, t, rb).againstExact(exact, roundedVal.Prec())
+				encoding := slices.IndexFunc(encodings, func(bits *big.Int) bool { return bits.Cmp(c.bits) == 0 })
+				if encoding < 0 {
+					encoding = len(encodings)
+					encodings = append(encodings, c.bits)
+				}
+				fmt.Fprintf(writer, "\t%s\t%s\t%s\t0x%0*x\t%s\t%s\t%s\t%s\t#%d\t\n", rm, om, um,
+					(c.width+3)/4, c.bits, c.valueTextAgainst(exact), c.errorText(6), c.accuracy, c.status, encoding+1)
+				record := newConversionRecord(sf.names[0], valStr, exact.Text('x', -1), c)
+				record.setModes(rm, om, um, t)
+				records = append(records, record)
+			}
+		}
+	}
+	if output != "text" {
+		return writeRecords(records, output)
+	}
+	writer.Flush()
+	fmt.Println(sf.title)
+	fmt.Printf("Input: %s (%s)\n", exact.Text('e', -1), exact.Text('x', -1))
+	fmt.Print(sb.String())
+	fmt.Printf("Distinct encodings: %d\n", len(encodings))
+	return nil
+}
+
+// A conversion as it is written by --output=json and --output=csv. The field names are the JSON keys and the CSV
+// header, so they must not change. Numbers are strings, because they don't fit in a JSON number (or a float64). The
+// elements of the block formats and the results of arithmetic operations are written with the same fields
+type conversionRecord struct {
+	Format string `json:"format"`
+	// The input as it was given. Arithmetic operations are written like add(a, b)
+	Input string `json:"input"`
+	// The number the error is measured against: the input, the input rounded to float32 for nvfp4, or the operands
+	// rounded to the format for arithmetic operations
+	InputHexfloat string `json:"input_hexfloat"`
+	// Empty for NaN inputs, which aren't rounded
+	RoundingMode  string `json:"rounding_mode"`
+	OverflowMode  string `json:"overflow_mode"`
+	UnderflowMode string `json:"underflow_mode"`
+	Tininess      string `json:"tininess"`
+	Bits          string `json:"bits"`
+	Sign          string `json:"sign"`
+	Exponent      string `json:"exponent"`
+	Mantissa      string `json:"mantissa"`
+	Label         string `json:"label"`
+	// NaNs are written like the NaN inputs, like -snan(0x2a)
+	Value         string `json:"value"`
+	ValueHexfloat string `json:"value_hexfloat"`
+	Error         string `json:"error"`
+	Accuracy      string `json:"accuracy"`
+	Status        string `json:"status"`
+	// Empty for the elements of the block formats, which don't raise exceptions
+	Exceptions string `json:"exceptions"`
+	// The bits of the shared scale of an MX element, or the block scale of an nvfp4 element. Empty for the scalar
+	// formats
+	Scale string `json:"scale"`
+	// The tensor scale of an nvfp4 element. Empty for the other formats
+	TensorScale string `json:"tensor_scale"`
+}
+
+// The CSV header, in the order of the fields of [conversionRecord]
+var conversionRecordHeader = []string{"format", "input", "input_hexfloat", "rounding_mode", "overflow_mode",
+	"underflow_mode", "tininess", "bits", "sign", "exponent", "mantissa", "label", "value", "value_hexfloat", "error",
+	"accuracy", "status", "exceptions", "scale", "tensor_scale"}
+
+// Put together the record of a conversion to the format with the given name, from the input as it was given and in
+// hexfloat
+func newConversionRecord(format, input, inputHexfloat string, c conversion) conversionRecord {
+	r := conversionRecord{
+		Format:        format,
+		Input:         input,
+		InputHexfloat: inputHexfloat,
+		Bits:          fmt.Sprintf("0x%0*x", (c.width+3)/4, c.bits),
+		Sign:          string(c.table.Sign),
+		Exponent:      string(c.table.Exponent),
+		Mantissa:      string(c.table.Mantissa),
+		Label:         c.table.Label,
+		Error:         c.errorText(-1),
+		Accuracy:      c.accuracy.String(),
+		Status:        c.status.String(),
+		Exceptions:    c.exceptions.String(),
+	}
+	if c.value != nil {
+		r.Value, r.ValueHexfloat = c.value.Text('e', -1), c.value.Text('x', -1)
+	} else {
+		r.Value, r.ValueHexfloat = c.nan.String(), c.nan.String()
+	}
+	return r
+}
+
+// Sets the modes the input was converted with
+func (r *conversionRecord) setModes(rm floatBit.RoundingMode, om floatBit.OverflowMode, um floatBit.UnderflowMode,
+	t floatBit.Tininess) {
+	r.RoundingMode, r.OverflowMode, r.UnderflowMode, r.Tininess = rm.String(), om.String(), um.String(), t.String()
+}
+
+// Returns the fields of the record, in the order of [conversionRecordHeader]
+func (r conversionRecord) fields() []string {
+	return []string{r.Format, r.Input, r.InputHexfloat, r.RoundingMode, r.OverflowMode, r.UnderflowMode, r.Tininess,
+		r.Bits, r.Sign, r.Exponent, r.Mantissa, r.Label, r.Value, r.ValueHexfloat, r.Error, r.Accuracy, r.Status,
+		r.Exceptions, r.Scale, r.TensorScale}
+}
+
+// Write the records as a JSON array, or as CSV with a header row
+func writeRecords(records []conversionRecord, output string) error {
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	}
+
+	writer := csv.NewWriter(os.Stdout)
+	writer.Write(conversionRecordHeader)
+	for _, r := range records {
+		writer.Write(r.fields())
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Returns |err| / |exact| as a string. It is NaN if the error is, or if it is undefined because the exact value is
+// zero or infinite (and the error isn't zero)
+func relativeError(err, exact *big.Float) string {
+	switch {
+	case err == nil:
+		return "NaN"
+	case err.Sign() == 0:
+		return new(big.Float).Text('e', 3)
+	case exact.Sign() == 0 || exact.IsInf():
+		return "NaN"
+	}
+	result := new(big.Float).Quo(err, exact)
+	return result.Abs(result).Text('e', 3)
+}
+
+// Decode the given bits in the format, and print their value and classification. Nothing is rounded, so there is no
+// error and no exceptions
+func handleBits(bitsStr string, format string) error {
+	bits, ok := new(big.Int).SetString(bitsStr, 0)
+	if !ok || bits.Sign() < 0 {
+		return errors.New("Invalid bits " + bitsStr)
+	}
+
+	lowerFormat := strings.ToLower(format)
+	var f floatBit.Format
+	switch lowerFormat {
+	case "x87", "float80", "fp80":
+		// The explicit integer bit doesn't fit the generic formats
+		if bits.BitLen() > 80 {
+			return fmt.Errorf("%s doesn't fit in the 80 bits of %s", bitsStr, format)
+		}
+		mantissaMask := new(big.Int).SetUint64(math.MaxUint64)
+		floatVal := F80.Bits{SignExponent: uint16(new(big.Int).Rsh(bits, 64).Uint64()),
+			Mantissa: new(big.Int).And(bits, mantissaMask).Uint64()}
+		fmt.Println("x87 Extended Precision (80-bit)")
+		// The label of the legacy encodings is their class, which is printed below
+		table := floatVal.ToFloatFormat()
+		table.Label = ""
+		fmt.Print(table.AsTable())
+		if floatVal.IsNaN() {
+			fmt.Println("Decimal: NaN")
+			fmt.Println("Hexfloat: NaN")
+		} else {
+			asBigFloat := floatVal.ToBigFloat()
+			fmt.Printf("Decimal: %s\n", asBigFloat.Text('e', -1))
+			fmt.Printf("Hexfloat: %s\n", asBigFloat.Text('x', -1))
+		}
+		fmt.Printf("Class: %s\n", floatVal.Classify())
+		if nan, ok := floatVal.DecodeNaN(); ok {
+			printNaN(nan)
+		}
+		fmt.Printf("Binary: 0b%016b%064b\n", floatVal.SignExponent, floatVal.Mantissa)
+		fmt.Printf("Hexadecimal: 0x%04x%016x\n", floatVal.SignExponent, floatVal.Mantissa)
+		return nil
+	case "float128", "fp128":
+		f = floatBit.FormatFloat128
+	default:
+		if spec, isCustom := strings.CutPrefix(lowerFormat, "custom:"); isCustom {
+			var err error
+			if f, err = parseCustomFormat(spec); err != nil {
+				return err
+			}
+		} else if f, ok = parseScalarFormat(&format); !ok {
+			return errors.New("Decoding bits is not supported for " + format)
+		}
+	}
+	// TF32 is stored in the upper 19 bits of a 32-bit container, which is what dumps of TF32 tensors show. The
+	// container is accepted as well as the 19 bits, as long as the lower 13 bits are 0
+	isTF32 := lowerFormat == "tf32" || lowerFormat == "tensorfloat32"
+	if isTF32 && bits.BitLen() > f.Width() && bits.BitLen() <= 32 && bits.TrailingZeroBits() >= 13 {
+		bits.Rsh(bits, 13)
+	}
+	if bits.BitLen() > f.Width() && isTF32 {
+		return fmt.Errorf("%s is neither 19 bits of %s, nor a 32-bit container with the lower 13 bits 0", bitsStr,
+			format)
+	}
+	if bits.BitLen() > f.Width() {
+		return fmt.Errorf("%s doesn't fit in the %d bits of %s", bitsStr, f.Width(), format)
+	}
 
-	// Print the decimal value
-	asBigFloat := floatVal.ToBigFloat()
-	fmt.Printf("Decimal: %s\n", asBigFloat.Text('e', -1))
+	// First we print the type. Every format that can be decoded is also one that can be converted to
+	sf, _ := lookupScalarFormat(format)
+	fmt.Println(sf.title)
 
-	// Print the hexfloat value
-	fmt.Printf("Hexfloat: %x\n", floatVal.ToFloat32())
+	// Print the bits in a table
+	fmt.Print(f.ToFloatFormat(bits).AsTable())
 
-	// Print the conversion error
-	conv, err := floatVal.ConversionError(bf)
-	var convStr string
-	if err == nil {
-		convStr = conv.Text('e', -1)
+	// Print the decimal and hexfloat values. [big.Float] cannot represent NaN
+	if asBigFloat, err := f.Decode(bits); err == nil {
+		fmt.Printf("Decimal: %s\n", asBigFloat.Text('e', -1))
+		fmt.Printf("Hexfloat: %s\n", asBigFloat.Text('x', -1))
 	} else {
-		convStr = "NaN"
+		fmt.Println("Decimal: NaN")
+		fmt.Println("Hexfloat: NaN")
+	}
+
+	// Print the classification, and the kind and payload of NaNs
+	fmt.Printf("Class: %s\n", f.Classify(bits))
+	if nan, ok := f.DecodeNaN(bits); ok {
+		printNaN(nan)
 	}
-	fmt.Printf("Conversion Error: %s (%s)\n", convStr, accuracy)
 
 	// Print the bits in binary
-	fmt.Printf("Binary: %0#16b\n", floatVal)
+	fmt.Printf("Binary: 0b%0*b\n", f.Width(), bits)
 
 	// Print the bits in hexadecimal
-	fmt.Printf("Hexadecimal: %0#4x\n", floatVal)
+	fmt.Printf("Hexadecimal: 0x%0*x\n", (f.Width()+3)/4, bits)
+	return nil
+}
 
-	if status != floatBit.Fits {
-		fmt.Printf("%s\n", strings.ToUpper(status.String()))
+// Print the kind and the payload of a NaN
+func printNaN(nan floatBit.NaN) {
+	kind := "quiet"
+	if nan.Signaling {
+		kind = "signaling"
+	}
+	payload := nan.Payload
+	if payload == nil {
+		payload = new(big.Int)
 	}
+	fmt.Printf("NaN: %s, payload %#x\n", kind, payload)
 }
 
-// Call the appropriate functions and methods required to put together the information to print for BF16
-func handleFloat16(bf *big.Float, rm floatBit.RoundingMode, om floatBit.OverflowMode, um floatBit.UnderflowMode) {
+// Convert a NaN input to the format with the NaN policy, and print the result along with its kind and payload. NaNs
+// aren't rounded, so the rounding, overflow and underflow modes don't apply
+func handleNaN(valStr string, nan floatBit.NaN, format string, p floatBit.NaNPolicy, output string) error {
+	sf, err := lookupScalarFormat(format)
+	if err != nil {
+		return err
+	}
+	c := sf.convertNaN(nan, p)
+	if output != "text" {
+		return writeRecords([]conversionRecord{newConversionRecord(sf.names[0], valStr, nan.String(), c)},
+			output)
+	}
+
 	// First we print the type
-	fmt.Println("Float16")
+	fmt.Println(sf.title)
 
-	// Get the Float16 Value
-	floatVal, accuracy, status := F16.FromBigFloat(*bf, rm, om, um)
+	printConversion(c, "Conversion Error")
+	return nil
+}
 
-	// Print the bits in a table
-	fmt.Print(floatVal.ToFloatFormat().AsTable())
+// Print the raised exception flags, if there are any
+func printExceptions(exceptions floatBit.Exceptions) {
+	if exceptions != 0 {
+		fmt.Printf("Exceptions: %s\n", exceptions)
+	}
+}
 
-	// Print the decimal value
-	asBigFloat := floatVal.ToBigFloat()
-	fmt.Printf("Decimal: %s\n", asBigFloat.Text('e', -1))
+// Convert the input the given number of times, and print how often each result was produced. This shows the
+// distribution of stochastic rounding, where every conversion uses new random bits
+func handleSamples(bf *big.Float, name string, f floatBit.Format, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+	um floatBit.UnderflowMode, t floatBit.Tininess, rb floatBit.RandomBits, samples uint) {
+	// First we print the type
+	fmt.Printf("%s (%d samples)\n", name, samples)
 
-	// Print the hexfloat value
-	fmt.Printf("Hexfloat: %x\n", floatVal.ToFloat32())
+	// Count the results by their bits
+	counts := make(map[string]uint)
+	var results []*big.Int
+	for range samples {
+		bits, _, _ := floatBit.Encode(*bf, f, rm, om, um, t, rb)
+		key := bits.String()
+		if counts[key] == 0 {
+			results = append(results, bits)
+		}
+		counts[key]++
+	}
 
-	// Print the conversion error
-	conv, err := floatVal.ConversionError(bf)
-	var convStr string
-	if err == nil {
-		convStr = conv.Text('e', -1)
+	// Print the results in increasing order of their value, with NaNs last
+	slices.SortFunc(results, func(a, b *big.Int) int {
+		aVal, aErr := f.Decode(a)
+		bVal, bErr := f.Decode(b)
+		switch {
+		case aErr != nil && bErr != nil:
+			return a.Cmp(b)
+		case aErr != nil:
+			return 1
+		case bErr != nil:
+			return -1
+		}
+		return aVal.Cmp(&bVal)
+	})
+	for _, bits := range results {
+		decimal := "NaN"
+		if asBigFloat, err := f.Decode(bits); err == nil {
+			decimal = asBigFloat.Text('e', -1)
+		}
+		count := counts[bits.String()]
+		fmt.Printf("0x%0*x %s: %d (%.2f%%)\n", (f.Width()+3)/4, bits, decimal, count,
+			100*float64(count)/float64(samples))
+	}
+}
+
+// Signatures of the arithmetic functions of the format packages
+type (
+	unaryOp[T any] func(T, floatBit.RoundingMode, floatBit.OverflowMode, floatBit.UnderflowMode, floatBit.Tininess,
+		...floatBit.RandomBits) (T, big.Accuracy, floatBit.Status, floatBit.Exceptions)
+	binaryOp[T any] func(T, T, floatBit.RoundingMode, floatBit.OverflowMode, floatBit.UnderflowMode, floatBit.Tininess,
+		...floatBit.RandomBits) (T, big.Accuracy, floatBit.Status, floatBit.Exceptions)
+	ternaryOp[T any] func(T, T, T, floatBit.RoundingMode, floatBit.OverflowMode, floatBit.UnderflowMode, floatBit.Tininess,
+		...floatBit.RandomBits) (T, big.Accuracy, floatBit.Status, floatBit.Exceptions)
+)
+
+// The arithmetic functions of a format package, along with the conversion of the operands
+type arithmetic[T ~uint16 | ~uint32] struct {
+	fromBigFloat func(big.Float, floatBit.RoundingMode, floatBit.OverflowMode, floatBit.UnderflowMode, floatBit.Tininess,
+		...floatBit.RandomBits) (T, big.Accuracy, floatBit.Status)
+	fromNaN            func(floatBit.NaN, floatBit.NaNPolicy) (T, floatBit.Status, floatBit.Exceptions)
+	add, sub, mul, div binaryOp[T]
+	sqrt               unaryOp[T]
+	fma                ternaryOp[T]
+}
+
+// An operand of an arithmetic operation, which is either a number or a NaN
+type operand struct {
+	value *big.Float
+	nan   *floatBit.NaN
+}
+
+// Round the operands to the format, and perform the operation on them. NaN operands are kept as they are, so that
+// signaling NaNs and payloads reach the operation. Returns the bits of the operands and the result
+func (a arithmetic[T]) run(op string, operands []operand, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+	um floatBit.UnderflowMode, t floatBit.Tininess, rb floatBit.RandomBits) ([]*big.Int, *big.Int, big.Accuracy,
+	floatBit.Status, floatBit.Exceptions) {
+	operandBits := make([]T, len(operands))
+	operandInts := make([]*big.Int, len(operands))
+	for i, operand := range operands {
+		if operand.nan != nil {
+			operandBits[i], _, _ = a.fromNaN(*operand.nan, floatBit.NaNPreserve)
+		} else {
+			operandBits[i], _, _ = a.fromBigFloat(*operand.value, rm, om, um, t, rb)
+		}
+		operandInts[i] = new(big.Int).SetUint64(uint64(operandBits[i]))
+	}
+
+	var result T
+	var accuracy big.Accuracy
+	var status floatBit.Status
+	var exceptions floatBit.Exceptions
+	switch op {
+	case "add":
+		result, accuracy, status, exceptions = a.add(operandBits[0], operandBits[1], rm, om, um, t, rb)
+	case "sub":
+		result, accuracy, status, exceptions = a.sub(operandBits[0], operandBits[1], rm, om, um, t, rb)
+	case "mul":
+		result, accuracy, status, exceptions = a.mul(operandBits[0], operandBits[1], rm, om, um, t, rb)
+	case "div":
+		result, accuracy, status, exceptions = a.div(operandBits[0], operandBits[1], rm, om, um, t, rb)
+	case "sqrt":
+		result, accuracy, status, exceptions = a.sqrt(operandBits[0], rm, om, um, t, rb)
+	case "fma":
+		result, accuracy, status, exceptions = a.fma(operandBits[0], operandBits[1], operandBits[2], rm, om, um, t, rb)
+	}
+	return operandInts, new(big.Int).SetUint64(uint64(result)), accuracy, status, exceptions
+}
+
+// Perform an arithmetic operation on float32, bfloat16 or float16 numbers, and print the operands and the result.
+// The operands are rounded to the format first, and the exact result of the operation is rounded only once
+func handleOp(op string, format string, operandStrs []string, precision uint, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess, rb floatBit.RandomBits,
+	output string) error {
+	op = strings.ToLower(op)
+	var arity int
+	switch op {
+	case "sqrt":
+		arity = 1
+	case "add", "sub", "mul", "div":
+		arity = 2
+	case "fma":
+		arity = 3
+	default:
+		return errors.New("Invalid operation " + op)
+	}
+
+	operandNames := []string{"a", "b", "c"}
+	operands := make([]operand, arity)
+	for i := range operands {
+		if operandStrs[i] == "" {
+			return errors.New("Missing operand " + operandNames[i] + " for " + op)
+		}
+		if nan, ok := floatBit.ParseNaN(operandStrs[i]); ok {
+			operands[i].nan = &nan
+			continue
+		}
+		var err error
+		operands[i].value, err = floatBit.ParseFloat(operandStrs[i], precision, rm)
+		if err != nil {
+			return err
+		}
+	}
+
+	var name string
+	var f floatBit.Format
+	var operandBits []*big.Int
+	var resultBits *big.Int
+	var accuracy big.Accuracy
+	var status floatBit.Status
+	var exceptions floatBit.Exceptions
+	switch strings.ToLower(format) {
+	case "float32", "fp32":
+		name, f = "Float32", floatBit.FormatFloat32
+		operandBits, resultBits, accuracy, status, exceptions = arithmetic[F32.Bits]{F32.FromBigFloat, F32.FromNaN, F32.Add, F32.Sub,
+			F32.Mul, F32.Div, F32.Sqrt, F32.FMA}.run(op, operands, rm, om, um, t, rb)
+	case "bfloat16", "bf16":
+		name, f = "BFloat16", floatBit.FormatBFloat16
+		operandBits, resultBits, accuracy, status, exceptions = arithmetic[BF16.Bits]{BF16.FromBigFloat, BF16.FromNaN, BF16.Add, BF16.Sub,
+			BF16.Mul, BF16.Div, BF16.Sqrt, BF16.FMA}.run(op, operands, rm, om, um, t, rb)
+	case "float16", "fp16":
+		name, f = "Float16", floatBit.FormatFloat16
+		operandBits, resultBits, accuracy, status, exceptions = arithmetic[F16.Bits]{F16.FromBigFloat, F16.FromNaN, F16.Add, F16.Sub,
+			F16.Mul, F16.Div, F16.Sqrt, F16.FMA}.run(op, operands, rm, om, um, t, rb)
+	default:
+		return errors.New("Arithmetic operations are only supported for float32, bfloat16 and float16")
+	}
+
+	// The operands, as they were rounded to the format
+	operandVals := make([]*big.Float, arity)
+	operandDecimals := make([]string, arity)
+	operandHexfloats := make([]string, arity)
+	for i, bits := range operandBits {
+		if asBigFloat, err := f.Decode(bits); err == nil {
+			operandVals[i] = &asBigFloat
+			operandDecimals[i], operandHexfloats[i] = asBigFloat.Text('e', -1), asBigFloat.Text('x', -1)
+		} else {
+			nan, _ := f.DecodeNaN(bits)
+			operandDecimals[i], operandHexfloats[i] = nan.String(), nan.String()
+		}
+	}
+
+	// The result of the operation on the rounded operands, rounded to odd with 256 bits (see [floatBit.Add]). It only
+	// differs from the exact result below the last of these bits, and the error is rounded to the precision the
+	// operands were parsed with, so the error is measured against the exact result
+	var exact *big.Float
+	if !slices.Contains(operandVals, nil) {
+		var opExceptions floatBit.Exceptions
+		switch op {
+		case "add":
+			exact, opExceptions = floatBit.Add(operandVals[0], operandVals[1], rm)
+		case "sub":
+			exact, opExceptions = floatBit.Sub(operandVals[0], operandVals[1], rm)
+		case "mul":
+			exact, opExceptions = floatBit.Mul(operandVals[0], operandVals[1])
+		case "div":
+			exact, opExceptions = floatBit.Div(operandVals[0], operandVals[1])
+		case "sqrt":
+			exact, opExceptions = floatBit.Sqrt(operandVals[0])
+		case "fma":
+			exact, opExceptions = floatBit.FMA(operandVals[0], operandVals[1], operandVals[2], rm)
+		}
+		if opExceptions.Has(floatBit.ExceptionInvalid) {
+			exact = nil
+		}
+	}
+
+	c := newConversion(f, resultBits, exact, accuracy, status, exceptions).againstExact(exact, precision)
+	if output != "text" {
+		record := newConversionRecord(strings.ToLower(name), op+"("+strings.Join(operandStrs[:arity], ", ")+")",
+			op+"("+strings.Join(operandHexfloats, ", ")+")", c)
+		record.setModes(rm, om, um, t)
+		return writeRecords([]conversionRecord{record}, output)
+	}
+
+	// First we print the type and the operation
+	fmt.Printf("%s %s\n", name, op)
+
+	// Print the operands, as they were rounded to the format
+	for i, bits := range operandBits {
+		fmt.Printf("%s: %s (0x%0*x)\n", operandNames[i], operandDecimals[i], (f.Width()+3)/4, bits)
+	}
+
+	printConversion(c, "Rounding Error")
+	return nil
+}
+
+// Call the appropriate functions and methods required to put together the information to print for an MX block
+func handleMX(valueStrs []string, values, exacts []big.Float, ef MX.ElementFormat, rm floatBit.RoundingMode,
+	rb floatBit.RandomBits, output string) error {
+	// Quantize the block
+	block, report, err := MX.Quantize(values, ef, rm, rb)
+	if err != nil {
+		return err
+	}
+
+	// The value of every element includes the shared scale. A NaN scale makes every element NaN
+	dequantized, dequantizeErr := MX.Dequantize(block)
+	elements := make([]conversion, len(block.Elements))
+	var maxAbsError big.Float
+	for i, bits := range block.Elements {
+		elements[i] = conversion{table: ef.ToFloatFormat(bits), bits: new(big.Int).SetUint64(uint64(bits)),
+			width: ef.Width(), accuracy: report.Accuracy[i], status: report.Status[i]}
+		if dequantizeErr != nil {
+			elements[i].nan = &floatBit.NaN{}
+			continue
+		}
+		// The errors of the report are measured against the values that were quantized, not the input
+		elements[i].value = &dequantized[i]
+		elements[i] = elements[i].againstExact(&exacts[i], values[i].Prec())
+		var absErr big.Float
+		if absErr.Abs(elements[i].err).Cmp(&maxAbsError) > 0 {
+			maxAbsError.Set(&absErr)
+		}
+	}
+
+	if output != "text" {
+		// The element formats don't raise exceptions, and always saturate to the maximum normal
+		format := strings.ToLower(strings.ReplaceAll(ef.String(), " ", ""))
+		records := make([]conversionRecord, len(elements))
+		for i, c := range elements {
+			records[i] = newConversionRecord(format, valueStrs[i], exacts[i].Text('x', -1), c)
+			records[i].setModes(rm, floatBit.SaturateMax, floatBit.RoundToSubnormal,
+				floatBit.TininessBeforeRounding)
+			records[i].Exceptions = ""
+			records[i].Scale = fmt.Sprintf("%0#2x", block.Scale)
+		}
+		return writeRecords(records, output)
+	}
+
+	// First we print the type
+	fmt.Printf("%s (%d elements)\n", ef, len(values))
+
+	// Print the shared scale
+	fmt.Println("Shared Scale (E8M0)")
+	fmt.Print(block.Scale.ToFloatFormat().AsTable())
+	if block.Scale.IsNaN() {
+		fmt.Println("Decimal: NaN")
 	} else {
-		convStr = "NaN"
+		scaleVal, _ := block.Scale.ToBigFloat()
+		fmt.Printf("Decimal: %s (2^%d)\n", scaleVal.Text('e', -1), block.Scale.Exponent())
 	}
-	fmt.Printf("Conversion Error: %s (%s)\n", convStr, accuracy)
+	fmt.Printf("Hexadecimal: %0#2x\n", block.Scale)
 
-	// Print the bits in binary
-	fmt.Printf("Binary: %0#16b\n", floatVal)
+	for i, c := range elements {
+		fmt.Printf("\nElement %d: %s\n", i, exacts[i].Text('e', -1))
 
-	// Print the bits in hexadecimal
-	fmt.Printf("Hexadecimal: %0#4x\n", floatVal)
+		// Print the bits in a table
+		fmt.Print(c.table.AsTable())
+
+		// Print the decimal value (including the shared scale)
+		fmt.Printf("Decimal: %s\n", c.valueText())
+		fmt.Printf("Conversion Error: %s (%s)\n", c.errorText(-1), c.accuracy)
+
+		// Print the bits in binary
+		fmt.Printf("Binary: %0#*b\n", ef.Width(), block.Elements[i])
+
+		// Print the bits in hexadecimal
+		fmt.Printf("Hexadecimal: %0#2x\n", block.Elements[i])
+
+		if c.status != floatBit.Fits {
+			fmt.Printf("%s\n", strings.ToUpper(c.status.String()))
+		}
+	}
+
+	// Print the block level error
+	if dequantizeErr != nil {
+		fmt.Println("\nMax Abs Error: NaN")
+	} else {
+		fmt.Printf("\nMax Abs Error: %s\n", maxAbsError.Text('e', -1))
+	}
+	return nil
+}
+
+// Call the appropriate functions and methods required to put together the information to print for NVFP4
+func handleNVFP4(valueStrs []string, values []float32, tensorScale float32, rm floatBit.RoundingMode,
+	rb floatBit.RandomBits, output string) error {
+	// Quantize the values
+	block, report, err := NVFP4.QuantizeWithTensorScale(values, tensorScale, rm, rb)
+	if err != nil {
+		return err
+	}
+
+	// The value of every element includes both scales. Infinities and NaNs have no error
+	dequantized := NVFP4.Dequantize(block)
+	elements := make([]conversion, len(block.Elements))
+	for i, bits := range block.Elements {
+		elements[i] = conversion{table: bits.ToFloatFormat(), bits: new(big.Int).SetUint64(uint64(bits)), width: 4,
+			value: big.NewFloat(float64(dequantized[i])), accuracy: report.Accuracy[i], status: report.Status[i]}
+		if report.Status[i] != floatBit.NoEncoding {
+			elements[i].err = &report.Error[i]
+		}
+	}
+
+	if output != "text" {
+		// The elements don't raise exceptions, and always saturate to the maximum normal
+		records := make([]conversionRecord, len(elements))
+		for i, c := range elements {
+			// The values are quantized as float32, so the error is measured against them
+			input := big.NewFloat(float64(values[i]))
+			records[i] = newConversionRecord("nvfp4", valueStrs[i], input.Text('x', -1), c)
+			records[i].setModes(rm, floatBit.SaturateMax, floatBit.RoundToSubnormal,
+				floatBit.TininessBeforeRounding)
+			records[i].Exceptions = ""
+			records[i].Scale = fmt.Sprintf("%0#2x", block.Scales[i/NVFP4.BlockSize])
+			records[i].TensorScale = big.NewFloat(float64(block.TensorScale)).Text('e', -1)
+		}
+		return writeRecords(records, output)
+	}
+
+	// First we print the type
+	fmt.Printf("NVFP4 (%d elements)\n", len(values))
+	fmt.Printf("Tensor Scale: %s\n", big.NewFloat(float64(block.TensorScale)).Text('e', -1))
+
+	for i, c := range elements {
+		// Print the block scale before the first element of every block
+		if i%NVFP4.BlockSize == 0 {
+			scale := block.Scales[i/NVFP4.BlockSize]
+			fmt.Printf("\nBlock %d Scale (E4M3)\n", i/NVFP4.BlockSize)
+			fmt.Print(scale.ToFloatFormat().AsTable())
+			fmt.Printf("Decimal: %s\n", big.NewFloat(float64(scale.ToFloat32())).Text('e', -1))
+			fmt.Printf("Hexadecimal: %0#2x\n", scale)
+		}
+
+		fmt.Printf("\nElement %d: %s\n", i, big.NewFloat(float64(values[i])).Text('e', -1))
+
+		// Print the bits in a table
+		fmt.Print(c.table.AsTable())
+
+		// Print the decimal value (including both scales)
+		fmt.Printf("Decimal: %s\n", c.valueText())
+		fmt.Printf("Conversion Error: %s (%s)\n", c.errorText(-1), c.accuracy)
+
+		// Print the bits in binary
+		fmt.Printf("Binary: %0#4b\n", block.Elements[i])
+
+		// Print the bits in hexadecimal
+		fmt.Printf("Hexadecimal: %0#2x\n", block.Elements[i])
+
+		if c.status != floatBit.Fits {
+			fmt.Printf("%s\n", strings.ToUpper(c.status.String()))
+		}
+	}
+
+	// Print the tensor level summary
+	fmt.Printf("\nMax Abs Error: %s\n", report.MaxAbsError.Text('e', -1))
+	fmt.Printf("Overflows: %d\n", report.Overflows)
+	fmt.Printf("Underflows: %d\n", report.Underflows)
+	return nil
+}
+
+// Parse the NVFP4 tensor scale. auto derives the tensor scale from the values
+func parseTensorScale(tensorScaleStrPtr *string, values []float32) (float32, error) {
+	if strings.ToLower(*tensorScaleStrPtr) == "auto" {
+		return NVFP4.ComputeTensorScale(values), nil
+	}
+	tensorScale, _, err := big.ParseFloat(*tensorScaleStrPtr, 0, 24, big.ToNearestEven)
+	if err != nil {
+		return 0, err
+	}
+	asFloat32, _ := tensorScale.Float32()
+	return asFloat32, nil
+}
+
+// Parse the MX element format. The second return value is false if the
+// format is not an MX format
+func parseMXFormat(formatStrPtr *string) (MX.ElementFormat, bool) {
+	switch strings.ToLower(*formatStrPtr) {
+	case "mxfp8e4m3", "mxfp8":
+		return MX.MXFP8E4M3, true
+	case "mxfp8e5m2":
+		return MX.MXFP8E5M2, true
+	case "mxfp6e2m3", "mxfp6":
+		return MX.MXFP6E2M3, true
+	case "mxfp6e3m2":
+		return MX.MXFP6E3M2, true
+	case "mxfp4e2m1", "mxfp4":
+		return MX.MXFP4E2M1, true
+	case "mxint8":
+		return MX.MXINT8, true
+	default:
+		return 0, false
+	}
+}
+
+// Returns the descriptor of a scalar format, which can be used with the generic encoder. The second return value
+// is false if the format doesn't have one
+func parseScalarFormat(formatStrPtr *string) (floatBit.Format, bool) {
+	switch strings.ToLower(*formatStrPtr) {
+	case "float64", "fp64":
+		return floatBit.FormatFloat64, true
+	case "float32", "fp32":
+		return floatBit.FormatFloat32, true
+	case "bfloat16", "bf16":
+		return floatBit.FormatBFloat16, true
+	case "float16", "fp16":
+		return floatBit.FormatFloat16, true
+	case "tf32", "tensorfloat32":
+		return floatBit.FormatTF32, true
+	case "e4m3", "fp8e4m3":
+		return floatBit.FormatE4M3, true
+	case "e5m2", "fp8e5m2":
+		return floatBit.FormatE5M2, true
+	case "e4m3fnuz", "fp8e4m3fnuz":
+		return floatBit.FormatE4M3FNUZ, true
+	case "e5m2fnuz", "fp8e5m2fnuz":
+		return floatBit.FormatE5M2FNUZ, true
+	case "e2m3", "fp6e2m3":
+		return floatBit.FormatE2M3, true
+	case "e3m2", "fp6e3m2":
+		return floatBit.FormatE3M2, true
+	case "e2m1", "fp4e2m1":
+		return floatBit.FormatE2M1, true
+	default:
+		return floatBit.Format{}, false
+	}
+}
+
+// Parse the description of a custom format, a comma-separated list of key=value options. The exponent bits (e) and
+// mantissa bits (m) are required. The rest of the options default to an IEEE-754 like format
+func parseCustomFormat(spec string) (floatBit.Format, error) {
+	var f floatBit.Format
+	var hasExponent, hasMantissa, hasBias, hasInfinity, hasNegativeZero bool
+	for _, option := range strings.Split(spec, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(option), "=")
+		if !found {
+			return f, errors.New("Invalid custom format option " + option)
+		}
+		var err error
+		switch key {
+		case "e":
+			f.ExponentBits, err = strconv.Atoi(value)
+			hasExponent = true
+		case "m":
+			f.MantissaBits, err = strconv.Atoi(value)
+			hasMantissa = true
+		case "bias":
+			f.Bias, err = strconv.Atoi(value)
+			hasBias = true
+		case "inf":
+			f.HasInfinity, err = strconv.ParseBool(value)
+			hasInfinity = true
+		case "negzero":
+			f.HasNegativeZero, err = strconv.ParseBool(value)
+			hasNegativeZero = true
+		case "nan":
+			switch value {
+			case "ieee":
+				f.NaN = floatBit.NaNIEEE
+			case "allones":
+				f.NaN = floatBit.NaNAllOnes
+			case "negzero":
+				f.NaN = floatBit.NaNNegativeZero
+			case "none":
+				f.NaN = floatBit.NoNaN
+			default:
+				err = errors.New("Unsupported NaN encoding " + value)
+			}
+		default:
+			err = errors.New("Unsupported custom format option " + key)
+		}
+		if err != nil {
+			return f, fmt.Errorf("Invalid custom format option %s: %w", option, err)
+		}
+	}
+
+	if !hasExponent || !hasMantissa {
+		return f, errors.New("Custom formats require the e and m options")
+	}
+	if !hasBias && f.ExponentBits >= 1 && f.ExponentBits <= 31 {
+		f.Bias = 1<<(f.ExponentBits-1) - 1
+	}
+	if !hasInfinity {
+		f.HasInfinity = f.NaN == floatBit.NaNIEEE
+	}
+	if !hasNegativeZero {
+		f.HasNegativeZero = f.NaN != floatBit.NaNNegativeZero
+	}
+	if err := f.Validate(); err != nil {
+		return f, fmt.Errorf("Invalid custom format: %w", err)
+	}
+	return f, nil
+}
 
-	if status != floatBit.Fits {
-		fmt.Printf("%s\n", strings.ToUpper(status.String()))
+// Split a comma-separated list of floating point numbers into the numbers, without the surrounding spaces
+func splitValueList(valStr string) []string {
+	valueStrs := strings.Split(valStr, ",")
+	for i := range valueStrs {
+		valueStrs[i] = strings.TrimSpace(valueStrs[i])
 	}
+	return valueStrs
 }
 
-// Underflow mode to use
-func parseUnderflowMode(underflowModeStrPtr *string) (floatBit.UnderflowMode, error) {
+// Parse a list of floating point numbers. Returns the numbers to convert (see [floatBit.ParseFloat]), and the numbers
+// as they were given (see [floatBit.ParseFloatNearest])
+func parseValueList(valueStrs []string, precision uint, rm floatBit.RoundingMode) ([]big.Float, []big.Float, error) {
+	values := make([]big.Float, len(valueStrs))
+	exacts := make([]big.Float, len(valueStrs))
+	for i, valueStr := range valueStrs {
+		value, err := floatBit.ParseFloat(valueStr, precision, rm)
+		if err != nil {
+			return nil, nil, err
+		}
+		exact, err := parseExactInput(valueStr, precision)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i], exacts[i] = *value, *exact
+	}
+	return values, exacts, nil
+}
+
+// Parse the input as it was given, to print it and to measure the conversion error against. The input that is
+// converted is rounded to odd instead, and its last bit is only a sticky bit
+func parseExactInput(valStr string, precision uint) (*big.Float, error) {
+	return floatBit.ParseFloatNearest(valStr, max(precision, wideInputPrecision))
+}
+
+// Returns true for the formats defined by IEEE-754 (and x87), which default to the IEEE-754 overflow and underflow
+// behavior
+func isIEEEFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case "float128", "fp128", "x87", "float80", "fp80", "float64", "fp64", "float32", "fp32", "float16", "fp16":
+		return true
+	default:
+		return false
+	}
+}
+
+// Underflow mode to use. Without one, the IEEE-754 formats round to the subnormals like IEEE-754 hardware does
+func parseUnderflowMode(underflowModeStrPtr *string, formatStrPtr *string) (floatBit.UnderflowMode, error) {
 	var underflowMode floatBit.UnderflowMode
-	switch strings.ToLower(*underflowModeStrPtr) {
+	underflowModeStr := strings.ToLower(*underflowModeStrPtr)
+	if underflowModeStr == "" {
+		underflowModeStr = "satmin"
+		if isIEEEFormat(*formatStrPtr) {
+			underflowModeStr = "subnormal"
+		}
+	}
+	switch underflowModeStr {
+	case "subnormal":
+		underflowMode = floatBit.RoundToSubnormal
 	case "satmin":
 		underflowMode = floatBit.SaturateMin
 	case "flushzero":
@@ -211,10 +1624,45 @@ func parseUnderflowMode(underflowModeStrPtr *string) (floatBit.UnderflowMode, er
 	return underflowMode, nil
 }
 
+// Parse when tininess is detected
+func parseTininess(tininessStrPtr *string) (floatBit.Tininess, error) {
+	switch strings.ToLower(*tininessStrPtr) {
+	case "before":
+		return floatBit.TininessBeforeRounding, nil
+	case "after":
+		return floatBit.TininessAfterRounding, nil
+	default:
+		return floatBit.TininessBeforeRounding, errors.New("Unsupported Tininess " + *tininessStrPtr)
+	}
+}
+
+// NaN policy to use
+func parseNaNPolicy(nanPolicyStrPtr *string) (floatBit.NaNPolicy, error) {
+	switch strings.ToLower(*nanPolicyStrPtr) {
+	case "canonicalize":
+		return floatBit.NaNCanonicalize, nil
+	case "preserve":
+		return floatBit.NaNPreserve, nil
+	case "quiet":
+		return floatBit.NaNQuiet, nil
+	default:
+		return floatBit.NaNQuiet, errors.New("Unsupported NaN policy " + *nanPolicyStrPtr)
+	}
+}
+
 // Overflow mode to use
-func parseOverflowMode(overflowModeStrPtr *string) (floatBit.OverflowMode, error) {
+func parseOverflowMode(overflowModeStrPtr *string, formatStrPtr *string) (floatBit.OverflowMode, error) {
 	var overflowMode floatBit.OverflowMode
-	switch strings.ToLower(*overflowModeStrPtr) {
+	overflowModeStr := strings.ToLower(*overflowModeStrPtr)
+	if overflowModeStr == "" {
+		overflowModeStr = "satmax"
+		if isIEEEFormat(*formatStrPtr) {
+			overflowModeStr = "ieee"
+		}
+	}
+	switch overflowModeStr {
+	case "ieee":
+		overflowMode = floatBit.OverflowIEEE
 	case "nan":
 		overflowMode = floatBit.MakeNaN
 	case "satmax":
@@ -227,6 +1675,17 @@ func parseOverflowMode(overflowModeStrPtr *string) (floatBit.OverflowMode, error
 	return overflowMode, nil
 }
 
+// Output format to use
+func parseOutput(outputStrPtr *string) (string, error) {
+	output := strings.ToLower(*outputStrPtr)
+	switch output {
+	case "text", "json", "csv":
+		return output, nil
+	default:
+		return output, errors.New("Unsupported output " + *outputStrPtr)
+	}
+}
+
 // Rounding mode to use
 func parseRoundingMode(roundingModeStrPtr *string) (floatBit.RoundingMode, error) {
 	var roundMode floatBit.RoundingMode
@@ -247,6 +1706,12 @@ func parseRoundingMode(roundingModeStrPtr *string) (floatBit.RoundingMode, error
 		roundMode = floatBit.RoundHalfTowardsPositiveInf
 	case "rthalfneginf":
 		roundMode = floatBit.RoundHalfTowardsNegativeInf
+	case "rtaway":
+		roundMode = floatBit.RoundAwayFromZero
+	case "rthalfaway":
+		roundMode = floatBit.RoundHalfAwayFromZero
+	case "sr":
+		roundMode = floatBit.RoundStochastic
 	default:
 		return roundMode, errors.New("Unsupported rounding mode " + *roundingModeStrPtr)
 	}
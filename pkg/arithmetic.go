@@ -0,0 +1,137 @@
+package floatBit
+
+import "math/big"
+
+// Precision of the results of the arithmetic functions. The results are
+// rounded to odd, so rounding them again to a format with at most
+// arithmeticPrecision-2 bits of precision is the same as rounding the exact
+// result, and [RoundStochastic] sees more than 64 bits of the discarded
+// fraction of any of the formats
+const arithmeticPrecision uint = 256
+
+// Returns x + y rounded to odd, which can be rounded again to the target
+// format with any rounding mode (see [ParseFloat]), and the exceptions raised
+// by the operation itself. The result is nil (NaN) if [ExceptionInvalid] is
+// raised, which is the case for the sum of two infinities with different
+// signs. rm is the rounding mode that the result is rounded
+// with afterwards. It decides the sign of a zero sum of two numbers with
+// different signs, which is -0 for [RoundTowardsNegativeInf], and +0 otherwise
+func Add(x, y *big.Float, rm RoundingMode) (*big.Float, Exceptions) {
+	if x.IsInf() && y.IsInf() && x.Signbit() != y.Signbit() {
+		return nil, ExceptionInvalid
+	}
+	result := new(big.Float).SetPrec(arithmeticPrecision).SetMode(big.ToZero)
+	result.Add(x, y)
+	if result.Sign() == 0 {
+		// The sum of two zeros with the same sign keeps the sign. Otherwise,
+		// the operands cancelled out exactly
+		negative := x.Signbit() && y.Signbit()
+		if x.Signbit() != y.Signbit() {
+			negative = rm == RoundTowardsNegativeInf
+		}
+		if negative != result.Signbit() {
+			result.Neg(result)
+		}
+	}
+	return roundToOdd(result), 0
+}
+
+// Returns x - y rounded to odd. See [Add]
+func Sub(x, y *big.Float, rm RoundingMode) (*big.Float, Exceptions) {
+	return Add(x, new(big.Float).Neg(y), rm)
+}
+
+// Returns x * y rounded to odd. See [Add]. The result is NaN for the product
+// of a zero and an infinity
+func Mul(x, y *big.Float) (*big.Float, Exceptions) {
+	if (x.IsInf() && y.Sign() == 0) || (x.Sign() == 0 && y.IsInf()) {
+		return nil, ExceptionInvalid
+	}
+	result := new(big.Float).SetPrec(arithmeticPrecision).SetMode(big.ToZero)
+	return roundToOdd(result.Mul(x, y)), 0
+}
+
+// Returns x / y rounded to odd. See [Add]. The result is NaN for 0 / 0 and for
+// the quotient of two infinities. Dividing a finite non-zero number by zero
+// returns an infinity, and raises [ExceptionDivideByZero]
+func Div(x, y *big.Float) (*big.Float, Exceptions) {
+	if (x.Sign() == 0 && y.Sign() == 0) || (x.IsInf() && y.IsInf()) {
+		return nil, ExceptionInvalid
+	}
+	var exceptions Exceptions
+	if y.Sign() == 0 && !x.IsInf() {
+		exceptions = ExceptionDivideByZero
+	}
+	result := new(big.Float).SetPrec(arithmeticPrecision).SetMode(big.ToZero)
+	return roundToOdd(result.Quo(x, y)), exceptions
+}
+
+// Returns the square root of x rounded to odd. See [Add]. The result is NaN if
+// x is less than zero. The square root of -0 is -0
+func Sqrt(x *big.Float) (*big.Float, Exceptions) {
+	if x.Sign() < 0 {
+		return nil, ExceptionInvalid
+	}
+	if x.Sign() == 0 || x.IsInf() {
+		return new(big.Float).Set(x), 0
+	}
+
+	// [big.Float.Sqrt] doesn't report whether the result is exact, so the
+	// square root is computed on integers instead. x is scaled by an even
+	// power of two, to an integer with at least twice the precision of the
+	// result
+	exponent := x.MantExp(nil)
+	shift := 2*int(arithmeticPrecision) + int(x.MinPrec()) - exponent
+	if shift%2 != 0 {
+		shift++
+	}
+	scaled, _ := new(big.Float).SetMantExp(x, shift).Int(nil)
+	root := new(big.Int).Sqrt(scaled)
+
+	result := new(big.Float).SetPrec(arithmeticPrecision).SetMode(big.ToZero)
+	acc := result.SetInt(root).Acc()
+	result.SetMantExp(result, -shift/2)
+	if acc == big.Exact && new(big.Int).Mul(root, root).Cmp(scaled) != 0 {
+		// The integer square root is truncated, so the result is too
+		acc = big.Below
+	}
+	return roundToOdd(result, acc), 0
+}
+
+// Returns x * y + z, rounded only once to odd. See [Add]. The result is NaN
+// for the product of a zero and an infinity, and if the product is an
+// infinity with a different sign than an infinite z
+func FMA(x, y, z *big.Float, rm RoundingMode) (*big.Float, Exceptions) {
+	if (x.IsInf() && y.Sign() == 0) || (x.Sign() == 0 && y.IsInf()) {
+		return nil, ExceptionInvalid
+	}
+	// The product is exact, so it's only rounded by the addition
+	var product big.Float
+	product.SetPrec(max(x.MinPrec()+y.MinPrec(), 1)).Mul(x, y)
+	return Add(&product, z, rm)
+}
+
+// Rounds the given number to odd: a number that was rounded towards zero gets
+// its last bit set, if it isn't already, whenever it was inexact. The
+// accuracy of the rounding is taken from the number, unless it is passed
+// explicitly
+func roundToOdd(result *big.Float, acc ...big.Accuracy) *big.Float {
+	inexact := result.Acc() != big.Exact
+	if len(acc) > 0 {
+		inexact = acc[0] != big.Exact
+	}
+	if !inexact || result.IsInf() {
+		return result
+	}
+	// The LSB of the result is 2^-prec times the leading bit, which is
+	// 2^(exponent-1)
+	prec := int(result.Prec())
+	exponent := result.MantExp(nil)
+	mantissa, _ := new(big.Float).SetMantExp(result, prec-exponent).Int(nil)
+	if mantissa.Bit(0) == 0 {
+		var lsb big.Float
+		lsb.SetMantExp(big.NewFloat(float64(result.Sign())), exponent-prec)
+		result.Add(result, &lsb)
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package floatBit_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+// The square root of an exact square must be exact, and anything slightly
+// larger must round to odd just above it
+func TestSqrt(t *testing.T) {
+	rng := rand.New(rand.NewSource(16))
+	for range 200 {
+		mantissa := new(big.Int).Rand(rng, new(big.Int).Lsh(big.NewInt(1), 100))
+		mantissa.SetBit(mantissa, 100, 1)
+		root := new(big.Float).SetInt(mantissa)
+		root.SetMantExp(root, rng.Intn(2000)-1000)
+		square := new(big.Float).SetPrec(202).Mul(root, root)
+
+		result, exceptions := floatBit.Sqrt(square)
+		if exceptions != 0 || result.Cmp(root) != 0 {
+			t.Fatalf("Input: %v Expected: %v, Got: %v", square.Text('p', 0),
+				root.Text('p', 0), result.Text('p', 0))
+		}
+
+		// The next number above the square at 1000 bits
+		above := new(big.Float).SetPrec(1000).Set(square)
+		ulp := new(big.Float).SetMantExp(big.NewFloat(1), square.MantExp(nil)-1000)
+		above.Add(above, ulp)
+		result, exceptions = floatBit.Sqrt(above)
+		mant := new(big.Float)
+		exponent := result.MantExp(mant)
+		lsb, _ := mant.SetMantExp(mant, int(result.Prec())).Int(nil)
+		if exceptions != 0 || result.Cmp(root) <= 0 || lsb.Bit(0) != 1 ||
+			new(big.Float).Sub(result, root).MantExp(nil) != exponent-int(result.Prec())+1 {
+			t.Fatalf("Input: %v Expected the odd number above %v, Got: %v",
+				above.Text('p', 0), root.Text('p', 0), result.Text('p', 0))
+		}
+	}
+
+	if result, exceptions := floatBit.Sqrt(big.NewFloat(-1)); result != nil ||
+		exceptions != floatBit.ExceptionInvalid {
+		t.Errorf("Expected NaN and an invalid exception for the square root of -1")
+	}
+}
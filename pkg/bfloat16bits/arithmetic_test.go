@@ -0,0 +1,79 @@
+package BF16
+
+import (
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+func TestArithmetic(t *testing.T) {
+	testCases := []struct {
+		name string
+		// In
+		op      string
+		a, b, c Bits
+		rm      floatBit.RoundingMode
+		// Out
+		goldenVal        Bits
+		goldenAcc        big.Accuracy
+		goldenStatus     floatBit.Status
+		goldenExceptions floatBit.Exceptions
+	}{
+		{"InfMinusInf", "add", Bits(PositiveInfinity), Bits(NegativeInfinity), 0,
+			floatBit.RoundNearestEven, Bits(NaN), big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"NaNInput", "sqrt", Bits(NegativeNaN), 0, 0, floatBit.RoundNearestEven,
+			Bits(NegativeNaN), big.Exact, floatBit.Fits, 0},
+		{"SignalingNaNPayload", "sub", 0x3f80, 0x7f85, 0, floatBit.RoundNearestEven,
+			0x7fc5, big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"CancellationRTNegInf", "sub", 0x3f80, 0x3f80, 0,
+			floatBit.RoundTowardsNegativeInf, Bits(NegativeZero), big.Exact,
+			floatBit.Fits, 0},
+		// 1 + 2^-40 rounds to 1 when it is rounded to float32 first
+		{"SingleRoundingRTPosInf", "add", 0x3f80, 0x2b80, 0,
+			floatBit.RoundTowardsPositiveInf, 0x3f81, big.Above, floatBit.Fits, floatBit.ExceptionInexact},
+		{"OneThirdRNE", "div", 0x3f80, 0x4040, 0, floatBit.RoundNearestEven,
+			0x3eab, big.Above, floatBit.Fits, floatBit.ExceptionInexact},
+		{"SqrtTwoRTZ", "sqrt", 0x4000, 0, 0, floatBit.RoundTowardsZero, 0x3fb5,
+			big.Below, floatBit.Fits, floatBit.ExceptionInexact},
+		{"Overflow", "mul", Bits(PositiveMaxNormal), 0xc000, 0,
+			floatBit.RoundNearestEven, Bits(NegativeInfinity), big.Below,
+			floatBit.Overflow, floatBit.ExceptionOverflow | floatBit.ExceptionInexact},
+		// (1 + 2^-7)^2 - (1 + 2^-6) is 2^-14 exactly, while the rounded
+		// product cancels out completely
+		{"FMAExact", "fma", 0x3f81, 0x3f81, 0xbf82, floatBit.RoundNearestEven,
+			0x3880, big.Exact, floatBit.Fits, 0},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var resultVal Bits
+			var resultAcc big.Accuracy
+			var resultStatus floatBit.Status
+			var resultExceptions floatBit.Exceptions
+			om, um, tininess := floatBit.SaturateInf, floatBit.FlushToZero, floatBit.TininessBeforeRounding
+			switch tt.op {
+			case "add":
+				resultVal, resultAcc, resultStatus, resultExceptions = Add(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "sub":
+				resultVal, resultAcc, resultStatus, resultExceptions = Sub(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "mul":
+				resultVal, resultAcc, resultStatus, resultExceptions = Mul(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "div":
+				resultVal, resultAcc, resultStatus, resultExceptions = Div(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "sqrt":
+				resultVal, resultAcc, resultStatus, resultExceptions = Sqrt(tt.a, tt.rm, om, um, tininess)
+			case "fma":
+				resultVal, resultAcc, resultStatus, resultExceptions = FMA(tt.a, tt.b, tt.c, tt.rm, om, um, tininess)
+			}
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) ||
+				(resultStatus != tt.goldenStatus) || (resultExceptions != tt.goldenExceptions) {
+				t.Logf("a: %0#4x b: %0#4x c: %0#4x Rounding Mode: %v", tt.a, tt.b, tt.c, tt.rm)
+				t.Errorf("Expected Result: %0#4x, Got: %0#4x", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v", tt.goldenStatus, resultStatus)
+				t.Errorf("Expected Exceptions: %v, Got: %v", tt.goldenExceptions, resultExceptions)
+			}
+		})
+	}
+}
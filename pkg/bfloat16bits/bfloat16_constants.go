@@ -19,13 +19,12 @@ const (
 
 	// In bfloat16 format, all numbers with the exponent bits = 11111111
 	// and, mantissa bits not all zero, constitute the special NaN value
-	// Though, there technically are two types, we lump them together
-	// into just a single NaN. And, whenver the result of an operation is a
-	// NaN, we encode it with the same sign as that of the result and,
-	// with the mantissa LSB=1, and rest of the mantissa bits=0
-	NaN         uint16 = 0x7f81
-	PositiveNaN uint16 = 0x7f81
-	NegativeNaN uint16 = 0xff81
+	// Like the float32 format, the mantissa MSB is the quiet bit, and
+	// whenever the result of an operation is a NaN, we encode it as a quiet
+	// NaN with the payload bits=0
+	NaN         uint16 = 0x7fc0
+	PositiveNaN uint16 = 0x7fc0
+	NegativeNaN uint16 = 0xffc0
 
 	ExponentBias int = 127
 	ExponentMin  int = -126
@@ -1059,6 +1059,15 @@ func TestRoundNearestOdd(t *testing.T) {
 	}
 }
 
+// Parses the input with more precision than float32 and float64 have
+func parseBigFloat(input string) big.Float {
+	result, _, err := big.ParseFloat(input, 0, 200, big.ToNearestEven)
+	if err != nil {
+		panic(err)
+	}
+	return *result
+}
+
 func TestFromBigFloat(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -1282,10 +1291,50 @@ func TestFromBigFloat(t *testing.T) {
 			goldenAcc:    big.Above,
 			goldenStatus: floatBit.Underflow,
 		},
+		{
+			name:         "StickyBelowFloat32Precision",
+			input:        parseBigFloat("0x1.01000000000001p0"),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0x3f81),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegativeStickyBelowFloat32Precision",
+			input:        parseBigFloat("-0x1.01000000000001p0"),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0xbf81),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "UnderflowBelowFloat32MinSubnormal",
+			input:        parseBigFloat("1e-45"),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMinSubnormal),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
+		{
+			name:         "NegativeUnderflowBelowFloat32MinSubnormal",
+			input:        parseBigFloat("-1e-45"),
+			rm:           floatBit.RoundTowardsZero,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(NegativeZero),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
 	}
 
 	for _, tt := range testCases {
-		resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um)
+		resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessBeforeRounding)
 		if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
 			t.Logf("Failed Input Set:\n")
 			t.Logf("Name: %s", tt.name)
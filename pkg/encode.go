@@ -0,0 +1,242 @@
+package floatBit
+
+import (
+	"math/big"
+)
+
+// Convert the given [big.Float] arbitrary precision floating-point number to
+// the bits of a number in the given [Format]. If the number cannot be
+// represented in the format exactly, then the rounding mode, overflow mode and
+// underflow mode decide the result, just like for the conversion functions of
+// the format specific packages. Returns the result bits, a [big.Accuracy]
+// which encodes whether the result value was the same, larger or smaller than
+// the input, and a [Status] which encodes whether the result fit in the
+// format, caused overflow or underflow. The [Tininess] decides when the result
+// underflows.
+//
+// [RoundStochastic] needs the optional rb argument, which is ignored for the
+// other rounding modes. Panics if the format doesn't pass [Format.Validate]
+func Encode(input big.Float, f Format, rm RoundingMode, om OverflowMode,
+	um UnderflowMode, t Tininess, rb ...RandomBits) (*big.Int, big.Accuracy, Status) {
+
+	if err := f.Validate(); err != nil {
+		panic("Unsupported Format encountered: " + err.Error())
+	}
+
+	var signBit uint
+	if input.Signbit() {
+		signBit = 1
+	}
+
+	// Special Case #1: Infinities
+	// Infinities convert to their counterparts exactly, if the format has
+	// them. Otherwise, the overflow mode decides the result, but the status
+	// is always [NoEncoding]
+	if input.IsInf() {
+		if f.HasInfinity {
+			return f.infinity(signBit), big.Exact, Fits
+		}
+		resultVal, resultAcc, _ := f.handleOverflow(signBit, om.Resolve(rm, signBit != 0))
+		return resultVal, resultAcc, NoEncoding
+	}
+
+	// Special Case #2: Zeros
+	// Both Negative and Positive Zeros convert to their counterparts exactly.
+	// Formats without a negative zero use the positive zero for both
+	if input.Sign() == 0 {
+		return f.zero(signBit), big.Exact, Fits
+	}
+
+	// The rest of the conversion only deals with the magnitude of the input
+	var absInput big.Float
+	absInput.Abs(&input)
+
+	// Special Case #3: Input exceeds the maximum normal value (in magnitude).
+	// With [OverflowIEEE], the input only overflows if it still exceeds it
+	// after rounding, which any input at least 1 ULP above it does
+	maxFinite, _ := f.Decode(f.maxFinite(0))
+	var overflowLimit big.Float
+	overflowLimit.SetMantExp(big.NewFloat(1), maxFinite.MantExp(nil)-1-f.MantissaBits)
+	overflowLimit.Add(&overflowLimit, &maxFinite)
+	if absInput.Cmp(&maxFinite) > 0 &&
+		(om != OverflowIEEE || absInput.Cmp(&overflowLimit) >= 0) {
+		return f.handleOverflow(signBit, om.Resolve(rm, signBit != 0))
+	}
+
+	// The smallest exponent of a normal number. Subnormals share the ULP of
+	// the numbers with this exponent
+	exponentMin := 1 - f.Bias
+
+	// Special Case #4: Input is smaller than the minimum subnormal value (in
+	// magnitude). When tininess is detected after rounding, or with
+	// [RoundToSubnormal], the input is rounded first instead
+	var minSubnormal big.Float
+	minSubnormal.SetMantExp(big.NewFloat(1), exponentMin-f.MantissaBits)
+	tiny := absInput.Cmp(&minSubnormal) < 0
+	if tiny && !um.RoundsBelowMinSubnormal(t) {
+		return f.handleUnderflow(signBit, um)
+	}
+
+	// MantExp returns the exponent for a mantissa in [0.5, 1.0), but the
+	// format uses a mantissa in [1.0, 2.0)
+	actualExponent := absInput.MantExp(nil) - 1
+
+	// The exponent of the last mantissa bit. This is the size of 1 ULP of
+	// the result
+	ulpExponent := max(actualExponent, exponentMin) - f.MantissaBits
+
+	// Scale the input, so that the bits that can be represented in the
+	// format make up the integer part, with one extra bit for the first bit
+	// that cannot be represented. Converting that to an integer truncates
+	// the rest of the bits, and the accuracy tells us if any of them were
+	// set.
+	var scaledInput big.Float
+	scaledInput.SetMantExp(&absInput, 1-ulpExponent)
+	scaledInteger, scaledAcc := scaledInput.Int(nil)
+	roundBit := scaledInteger.Bit(0)
+	sticky := scaledAcc != big.Exact
+
+	// Just like for float64, placing the biased ULP exponent above the
+	// truncated mantissa gives the encoding of the exponent and mantissa
+	// bits directly, and rounding up is adding 1 to it
+	truncatedMantissa := new(big.Int).Rsh(scaledInteger, 1)
+	biasedUlpExponent := big.NewInt(int64(ulpExponent - (exponentMin - f.MantissaBits)))
+	exponentMantissaComposite := new(big.Int).Add(truncatedMantissa,
+		biasedUlpExponent.Lsh(biasedUlpExponent, uint(f.MantissaBits)))
+
+	if roundBit == 0 && !sticky {
+		return f.withSign(exponentMantissaComposite, signBit), big.Exact, Fits
+	}
+
+	isOdd := exponentMantissaComposite.Bit(0) == 1
+	// The discarded bits are larger than half an ULP if the round bit and
+	// any of the bits below it are set, and exactly half if only the round
+	// bit is set
+	aboveHalf := roundBit == 1 && sticky
+	isHalf := roundBit == 1 && !sticky
+	isPositive := signBit == 0
+
+	// Whether to move away from zero (increase the magnitude)
+	var roundAway bool
+	switch rm {
+	case RoundTowardsZero:
+		roundAway = false
+	case RoundTowardsPositiveInf:
+		roundAway = isPositive
+	case RoundTowardsNegativeInf:
+		roundAway = !isPositive
+	case RoundHalfTowardsZero:
+		roundAway = aboveHalf
+	case RoundHalfTowardsPositiveInf:
+		roundAway = aboveHalf || (isHalf && isPositive)
+	case RoundHalfTowardsNegativeInf:
+		roundAway = aboveHalf || (isHalf && !isPositive)
+	case RoundNearestEven:
+		roundAway = aboveHalf || (isHalf && isOdd)
+	case RoundNearestOdd:
+		roundAway = aboveHalf || (isHalf && !isOdd)
+	case RoundAwayFromZero:
+		roundAway = true
+	case RoundHalfAwayFromZero:
+		roundAway = aboveHalf || isHalf
+	case RoundStochastic:
+		// The discarded part of the magnitude, in ULPs
+		var fraction big.Float
+		fraction.SetMantExp(&scaledInput, -1)
+		fraction.Sub(&fraction, new(big.Float).SetInt(truncatedMantissa))
+		roundAway = StochasticRoundAway(&fraction, rb...)
+	default:
+		panic("Unsupported RoundingMode encountered")
+	}
+
+	// Truncating makes the magnitude smaller, and rounding away makes it
+	// larger
+	resultAcc := big.Below
+	if roundAway {
+		exponentMantissaComposite.Add(exponentMantissaComposite, big.NewInt(1))
+		resultAcc = big.Above
+	}
+	if !isPositive {
+		resultAcc = -resultAcc
+	}
+
+	// Only an input above the max normal value (with [OverflowIEEE])
+	// can round past it. Only an input smaller than the minimum subnormal can
+	// round to zero, which is when it underflows after rounding
+	underflows := tiny
+	if t == TininessAfterRounding {
+		underflows = exponentMantissaComposite.Sign() == 0
+	}
+	switch {
+	case exponentMantissaComposite.Cmp(f.maxFinite(0)) > 0:
+		return f.handleOverflow(signBit, om.Resolve(rm, signBit != 0))
+	case !underflows:
+		return f.withSign(exponentMantissaComposite, signBit), resultAcc, Fits
+	case um != RoundToSubnormal:
+		return f.handleUnderflow(signBit, um)
+	case exponentMantissaComposite.Sign() == 0:
+		// Formats without a negative zero use the positive zero
+		return f.zero(signBit), resultAcc, Underflow
+	default:
+		return f.withSign(exponentMantissaComposite, signBit), resultAcc, Underflow
+	}
+}
+
+// Utility function that sets the sign bit of the given encoding of a
+// magnitude
+func (f Format) withSign(magnitude *big.Int, signBit uint) *big.Int {
+	return magnitude.SetBit(magnitude, f.ExponentBits+f.MantissaBits, signBit)
+}
+
+// Utility function that returns the result for the case when the conversion
+// results in underflow
+func (f Format) handleUnderflow(signBit uint, um UnderflowMode) (*big.Int,
+	big.Accuracy, Status) {
+	switch um {
+	case FlushToZero:
+		if signBit == 0 {
+			// Zero is less than any positive number that underflows
+			return f.zero(signBit), big.Below, Underflow
+		}
+		return f.zero(signBit), big.Above, Underflow
+	case SaturateMin:
+		minSubnormal := f.withSign(big.NewInt(1), signBit)
+		if signBit == 0 {
+			// Min subnormal is larger than any number that underflows
+			return minSubnormal, big.Above, Underflow
+		}
+		return minSubnormal, big.Below, Underflow
+	default:
+		panic("Unsupported UnderflowMode encountered")
+	}
+}
+
+// Utility function that returns the result for the case when the conversion
+// results in overflow. Formats without infinities return NaN for
+// [SaturateInf] with the [NoEncoding] status, and formats without NaNs
+// saturate to the maximum normal, with the [NoEncoding] status, for both
+// [SaturateInf] and [MakeNaN]
+func (f Format) handleOverflow(signBit uint, om OverflowMode) (*big.Int,
+	big.Accuracy, Status) {
+	// Infinities and NaNs are larger in magnitude than any number that
+	// overflows, and the maximum normal is smaller
+	awayAcc, towardsAcc := big.Above, big.Below
+	if signBit != 0 {
+		awayAcc, towardsAcc = big.Below, big.Above
+	}
+
+	switch {
+	case om == SaturateMax:
+		return f.maxFinite(signBit), towardsAcc, Overflow
+	case om != SaturateInf && om != MakeNaN:
+		panic("Unsupported OverflowMode encountered")
+	case f.NaN == NoNaN:
+		return f.maxFinite(signBit), towardsAcc, NoEncoding
+	case om == MakeNaN:
+		return f.nan(signBit), awayAcc, Overflow
+	case f.HasInfinity:
+		return f.infinity(signBit), awayAcc, Overflow
+	default:
+		return f.nan(signBit), awayAcc, NoEncoding
+	}
+}
@@ -0,0 +1,422 @@
+package floatBit_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	BF16 "github.com/shantanu-gontia/float-conv/pkg/bfloat16bits"
+	F16 "github.com/shantanu-gontia/float-conv/pkg/float16bits"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+	E2M1 "github.com/shantanu-gontia/float-conv/pkg/fp4e2m1bits"
+	E2M3 "github.com/shantanu-gontia/float-conv/pkg/fp6e2m3bits"
+	E3M2 "github.com/shantanu-gontia/float-conv/pkg/fp6e3m2bits"
+	E4M3 "github.com/shantanu-gontia/float-conv/pkg/fp8e4m3bits"
+	E4M3FNUZ "github.com/shantanu-gontia/float-conv/pkg/fp8e4m3fnuzbits"
+	E5M2 "github.com/shantanu-gontia/float-conv/pkg/fp8e5m2bits"
+	E5M2FNUZ "github.com/shantanu-gontia/float-conv/pkg/fp8e5m2fnuzbits"
+)
+
+var (
+	roundingModes = []floatBit.RoundingMode{floatBit.RoundTowardsZero,
+		floatBit.RoundTowardsNegativeInf, floatBit.RoundTowardsPositiveInf,
+		floatBit.RoundHalfTowardsZero, floatBit.RoundHalfTowardsNegativeInf,
+		floatBit.RoundHalfTowardsPositiveInf, floatBit.RoundNearestEven,
+		floatBit.RoundNearestOdd, floatBit.RoundAwayFromZero,
+		floatBit.RoundHalfAwayFromZero}
+	overflowModes  = []floatBit.OverflowMode{floatBit.MakeNaN, floatBit.SaturateMax, floatBit.SaturateInf}
+	underflowModes = []floatBit.UnderflowMode{floatBit.SaturateMin, floatBit.FlushToZero,
+		floatBit.RoundToSubnormal}
+	tininesses = []floatBit.Tininess{floatBit.TininessBeforeRounding, floatBit.TininessAfterRounding}
+)
+
+// Converts a float64 with a format specific package, returning the bits as
+// a uint64
+type converter func(float64, floatBit.RoundingMode, floatBit.OverflowMode,
+	floatBit.UnderflowMode) (uint64, big.Accuracy, floatBit.Status)
+
+// Adapts the FromFloat32 functions of the format specific packages to a
+// [converter]. The inputs used with these must be exact float32 values
+func fromFloat32[T ~uint8 | ~uint16](from func(float32, floatBit.RoundingMode,
+	floatBit.OverflowMode, floatBit.UnderflowMode, floatBit.Tininess,
+	...floatBit.RandomBits) (T, big.Accuracy, floatBit.Status)) converter {
+	return func(input float64, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+		um floatBit.UnderflowMode) (uint64, big.Accuracy, floatBit.Status) {
+		resultVal, resultAcc, resultStatus := from(float32(input), rm, om, um,
+			floatBit.TininessBeforeRounding)
+		return uint64(resultVal), resultAcc, resultStatus
+	}
+}
+
+// Returns float32 inputs that exercise every exponent of the smaller formats,
+// including ties, subnormals, overflow and underflow, and some random values
+func float32Inputs(rng *rand.Rand) []float64 {
+	inputs := []float64{0, math.Copysign(0, -1), math.Inf(1), math.Inf(-1),
+		math.MaxFloat32, math.SmallestNonzeroFloat32}
+	for exponent := -150; exponent <= 20; exponent++ {
+		for _, mantissa := range []float64{1, 1.03125, 1.0625, 1.125, 1.25,
+			1.375, 1.5, 1.625, 1.75, 1.875, 1.9375, 1.96875, 1.99999988079071044921875} {
+			input := math.Ldexp(mantissa, exponent)
+			if float64(float32(input)) == input {
+				inputs = append(inputs, input)
+			}
+		}
+	}
+	for range 2000 {
+		asUint32 := rng.Uint32() & 0x7fffffff
+		// Move the exponent of half of the random values to the range of
+		// the smaller formats
+		if rng.Intn(2) == 0 {
+			asUint32 = asUint32&0x007fffff | uint32(rng.Intn(150))<<23
+		}
+		if asFloat32 := math.Float32frombits(asUint32); !math.IsNaN(float64(asFloat32)) {
+			inputs = append(inputs, float64(asFloat32))
+		}
+	}
+	withNegatives := make([]float64, 0, 2*len(inputs))
+	for _, input := range inputs {
+		withNegatives = append(withNegatives, input, -input)
+	}
+	return withNegatives
+}
+
+// Returns float64 inputs for the float32 conversion
+func float64Inputs(rng *rand.Rand) []float64 {
+	inputs := []float64{0, math.Copysign(0, -1), math.Inf(1), math.Inf(-1),
+		math.MaxFloat32, math.SmallestNonzeroFloat32, math.MaxFloat64,
+		math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat32 / 2,
+		math.SmallestNonzeroFloat32 * 0.75}
+	for range 5000 {
+		mantissa := 1 + float64(rng.Int63n(1<<52))/(1<<52)
+		// Some mantissas are ties or close to ties in float32
+		switch rng.Intn(3) {
+		case 0:
+			mantissa = math.Float64frombits(math.Float64bits(mantissa) | 1<<28)
+		case 1:
+			mantissa = math.Float64frombits(math.Float64bits(mantissa)&^(1<<29-1) | 1<<28)
+		}
+		inputs = append(inputs, math.Ldexp(mantissa, rng.Intn(300)-160))
+	}
+	withNegatives := make([]float64, 0, 2*len(inputs))
+	for _, input := range inputs {
+		withNegatives = append(withNegatives, input, -input)
+	}
+	return withNegatives
+}
+
+// Checks that Encode gives the same result as the format specific package, for
+// every combination of rounding, overflow and underflow modes, with tininess
+// detected before rounding. [floatBit.RoundToSubnormal] is left out, since the
+// packages hand it over to Encode
+func runEquivalenceTest(t *testing.T, f floatBit.Format, convert converter,
+	inputs []float64) {
+	for _, rm := range roundingModes {
+		for _, om := range overflowModes {
+			for _, um := range underflowModes {
+				if um.RoundsBelowMinSubnormal(floatBit.TininessBeforeRounding) {
+					continue
+				}
+				failures := 0
+				for _, input := range inputs {
+					goldenVal, goldenAcc, goldenStatus := convert(input, rm, om, um)
+					resultVal, resultAcc, resultStatus := floatBit.Encode(
+						*big.NewFloat(input), f, rm, om, um, floatBit.TininessBeforeRounding)
+					if !resultVal.IsUint64() || resultVal.Uint64() != goldenVal ||
+						resultAcc != goldenAcc || resultStatus != goldenStatus {
+						t.Errorf("Input: %v, Format: %v, RoundingMode: %v, OverflowMode: %v, UnderflowMode: %v\n",
+							input, f, rm, om, um)
+						t.Errorf("Expected: %#x %v %v, Got: %#x %v %v\n", goldenVal,
+							goldenAcc, goldenStatus, resultVal, resultAcc, resultStatus)
+						failures++
+					}
+					if failures > 5 {
+						t.FailNow()
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestEncodeFloat32(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	convert := func(input float64, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+		um floatBit.UnderflowMode) (uint64, big.Accuracy, floatBit.Status) {
+		resultVal, resultAcc, resultStatus := F32.FromFloat64(input, rm, om, um, floatBit.TininessBeforeRounding)
+		return uint64(resultVal), resultAcc, resultStatus
+	}
+	runEquivalenceTest(t, floatBit.FormatFloat32, convert, float64Inputs(rng))
+}
+
+func TestEncodeSmallFormats(t *testing.T) {
+	testCases := []struct {
+		name    string
+		format  floatBit.Format
+		convert converter
+	}{
+		{"BFloat16", floatBit.FormatBFloat16, fromFloat32(BF16.FromFloat32)},
+		{"Float16", floatBit.FormatFloat16, fromFloat32(F16.FromFloat32)},
+		{"E5M2", floatBit.FormatE5M2, fromFloat32(E5M2.FromFloat32)},
+		{"E4M3", floatBit.FormatE4M3, fromFloat32(E4M3.FromFloat32)},
+		{"E5M2FNUZ", floatBit.FormatE5M2FNUZ, fromFloat32(E5M2FNUZ.FromFloat32)},
+		{"E4M3FNUZ", floatBit.FormatE4M3FNUZ, fromFloat32(E4M3FNUZ.FromFloat32)},
+		{"E3M2", floatBit.FormatE3M2, fromFloat32(E3M2.FromFloat32)},
+		{"E2M3", floatBit.FormatE2M3, fromFloat32(E2M3.FromFloat32)},
+		{"E2M1", floatBit.FormatE2M1, fromFloat32(E2M1.FromFloat32)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			runEquivalenceTest(t, tt.format, tt.convert, float32Inputs(rng))
+		})
+	}
+}
+
+// Checks that Encode rounds 200-bit inputs correctly, by comparing the result
+// with its neighbours in the format. This doesn't depend on float32 or float64
+// in any way, so it also covers inputs that they cannot represent
+func TestEncodeCorrectlyRounded(t *testing.T) {
+	formats := []floatBit.Format{floatBit.FormatFloat32, floatBit.FormatBFloat16,
+		floatBit.FormatFloat16, floatBit.FormatE4M3, floatBit.FormatE5M2FNUZ,
+		floatBit.FormatE2M1, {3, 4, 3, true, floatBit.NaNIEEE, true},
+		{6, 9, 31, true, floatBit.NaNIEEE, true}}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, f := range formats {
+		t.Run(f.String(), func(t *testing.T) {
+			// The largest magnitude encoding, which upper can't go past
+			maxMagnitude := new(big.Int).Lsh(big.NewInt(1), uint(f.ExponentBits+f.MantissaBits))
+			maxMagnitude.Sub(maxMagnitude, big.NewInt(1))
+			for range 2000 {
+				// A random 200-bit input, which lies between two neighbouring
+				// finite numbers of the format, and is sometimes the midpoint
+				// of the two. Inputs below the minimum subnormal underflow
+				// instead of rounding, so they are skipped
+				var magnitude *big.Int
+				for {
+					magnitude = new(big.Int).Rand(rng, maxMagnitude)
+					_, err := f.Decode(new(big.Int).Add(magnitude, big.NewInt(1)))
+					if magnitude.Sign() != 0 && err == nil {
+						break
+					}
+				}
+				lower, _ := f.Decode(magnitude)
+				upper, _ := f.Decode(new(big.Int).Add(magnitude, big.NewInt(1)))
+				if upper.IsInf() {
+					continue
+				}
+				var input, gap big.Float
+				input.SetPrec(200)
+				gap.SetPrec(200).Sub(&upper, &lower)
+				switch rng.Intn(3) {
+				case 0:
+					input.Add(&lower, gap.Quo(&gap, big.NewFloat(2)))
+				default:
+					offset := new(big.Float).SetPrec(200).SetInt(new(big.Int).Rand(rng,
+						new(big.Int).Lsh(big.NewInt(1), 190)))
+					offset.SetMantExp(offset, -190)
+					input.Add(&lower, gap.Mul(&gap, offset))
+				}
+				negate := rng.Intn(2) == 0
+				if negate {
+					input.Neg(&input)
+					lower.Neg(&lower)
+					upper.Neg(&upper)
+				}
+
+				for _, rm := range roundingModes {
+					resultVal, resultAcc, resultStatus := floatBit.Encode(input, f, rm,
+						floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding)
+					result, _ := f.Decode(resultVal)
+					golden := roundReference(&input, &lower, &upper, rm)
+					goldenAcc := big.Accuracy(golden.Cmp(&input))
+					if result.Cmp(golden) != 0 || resultAcc != goldenAcc ||
+						resultStatus != floatBit.Fits {
+						t.Errorf("Input: %s, RoundingMode: %v, Expected: %s, Got: %s %v %v\n",
+							input.Text('x', -1), rm, golden.Text('x', -1), result.Text('x', -1),
+							resultAcc, resultStatus)
+					}
+				}
+			}
+		})
+	}
+}
+
+// Returns the correctly rounded value of input, which lies between the
+// neighbours lower and upper (lower is closer to zero)
+func roundReference(input, lower, upper *big.Float, rm floatBit.RoundingMode) *big.Float {
+	if input.Cmp(lower) == 0 {
+		return lower
+	}
+	var midpoint big.Float
+	midpoint.SetPrec(201).Add(lower, upper)
+	midpoint.Quo(&midpoint, big.NewFloat(2))
+	// Compare the magnitudes
+	halfCmp := new(big.Float).Abs(input).Cmp(new(big.Float).Abs(&midpoint))
+	isPositive := input.Sign() > 0
+	// The mantissa of lower is odd if lower is an odd multiple of the gap
+	// between lower and upper
+	var gap, ratio big.Float
+	gap.SetPrec(201).Sub(upper, lower)
+	ratio.SetPrec(400).Quo(lower, &gap)
+	ratioInt, _ := ratio.Abs(&ratio).Int(nil)
+	isOdd := ratioInt.Bit(0) == 1
+
+	var roundAway bool
+	switch rm {
+	case floatBit.RoundTowardsZero:
+		roundAway = false
+	case floatBit.RoundTowardsPositiveInf:
+		roundAway = isPositive
+	case floatBit.RoundTowardsNegativeInf:
+		roundAway = !isPositive
+	case floatBit.RoundHalfTowardsZero:
+		roundAway = halfCmp > 0
+	case floatBit.RoundHalfTowardsPositiveInf:
+		roundAway = halfCmp > 0 || (halfCmp == 0 && isPositive)
+	case floatBit.RoundHalfTowardsNegativeInf:
+		roundAway = halfCmp > 0 || (halfCmp == 0 && !isPositive)
+	case floatBit.RoundNearestEven:
+		roundAway = halfCmp > 0 || (halfCmp == 0 && isOdd)
+	case floatBit.RoundNearestOdd:
+		roundAway = halfCmp > 0 || (halfCmp == 0 && !isOdd)
+	case floatBit.RoundAwayFromZero:
+		roundAway = true
+	case floatBit.RoundHalfAwayFromZero:
+		roundAway = halfCmp >= 0
+	}
+	if roundAway {
+		return upper
+	}
+	return lower
+}
+
+// With tininess after rounding, or with [floatBit.RoundToSubnormal], inputs
+// below the minimum subnormal round like any other input. They only underflow
+// when they round to zero with tininess after rounding, and always underflow
+// before rounding
+func TestEncodeBelowMinSubnormal(t *testing.T) {
+	formats := []floatBit.Format{floatBit.FormatFloat32, floatBit.FormatBFloat16,
+		floatBit.FormatE4M3, floatBit.FormatE5M2FNUZ, floatBit.FormatE2M1}
+	fractions := []float64{0.25, 0.5, 0.75, 0x1p-20}
+	modes := []struct {
+		um floatBit.UnderflowMode
+		t  floatBit.Tininess
+	}{
+		{floatBit.FlushToZero, floatBit.TininessAfterRounding},
+		{floatBit.RoundToSubnormal, floatBit.TininessBeforeRounding},
+		{floatBit.RoundToSubnormal, floatBit.TininessAfterRounding},
+	}
+
+	for _, f := range formats {
+		t.Run(f.String(), func(t *testing.T) {
+			minSubnormal, _ := f.Decode(big.NewInt(1))
+			for _, fraction := range fractions {
+				for _, negate := range []bool{false, true} {
+					var input, lower, upper big.Float
+					input.SetPrec(200).Mul(&minSubnormal, big.NewFloat(fraction))
+					upper.Set(&minSubnormal)
+					if negate {
+						input.Neg(&input)
+						lower.Neg(&lower)
+						upper.Neg(&upper)
+					}
+					for _, rm := range roundingModes {
+						for _, mode := range modes {
+							golden := roundReference(&input, &lower, &upper, rm)
+							goldenAcc := big.Accuracy(golden.Cmp(&input))
+							goldenStatus := floatBit.Underflow
+							if mode.t == floatBit.TininessAfterRounding && golden.Sign() != 0 {
+								goldenStatus = floatBit.Fits
+							}
+							resultVal, resultAcc, resultStatus := floatBit.Encode(input, f, rm,
+								floatBit.SaturateInf, mode.um, mode.t)
+							result, _ := f.Decode(resultVal)
+							if result.Cmp(golden) != 0 ||
+								(f.HasNegativeZero && result.Signbit() != negate) ||
+								resultAcc != goldenAcc || resultStatus != goldenStatus {
+								t.Errorf("Input: %s, RoundingMode: %v, UnderflowMode: %v, Tininess: %v, Expected: %s %v %v, Got: %s %v %v\n",
+									input.Text('x', -1), rm, mode.um, mode.t, golden.Text('x', -1), goldenAcc,
+									goldenStatus, result.Text('x', -1), resultAcc, resultStatus)
+							}
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// With [floatBit.OverflowIEEE], the rounding mode and the sign decide whether
+// the result is an infinity or the max normal value, and numbers just above the
+// max normal value can round down to it
+func TestEncodeOverflowIEEE(t *testing.T) {
+	testCases := []struct {
+		name string
+		// In
+		input string
+		f     floatBit.Format
+		rm    floatBit.RoundingMode
+		// Out
+		goldenVal    uint64
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		// The max normal value of float16 is 65504, and the next number would
+		// be 65536
+		{"RoundsDownToMax", "65519", floatBit.FormatFloat16, floatBit.RoundNearestEven,
+			0x7bff, big.Below, floatBit.Fits},
+		{"TieRoundsUp", "65520", floatBit.FormatFloat16, floatBit.RoundNearestEven,
+			0x7c00, big.Above, floatBit.Overflow},
+		{"RoundsUpPastMax", "65505", floatBit.FormatFloat16, floatBit.RoundTowardsPositiveInf,
+			0x7c00, big.Above, floatBit.Overflow},
+		{"TruncatesToMax", "65535", floatBit.FormatFloat16, floatBit.RoundTowardsZero,
+			0x7bff, big.Below, floatBit.Fits},
+		{"RTZ", "-1e6", floatBit.FormatFloat16, floatBit.RoundTowardsZero,
+			0xfbff, big.Above, floatBit.Overflow},
+		{"RTPosInf", "1e6", floatBit.FormatFloat16, floatBit.RoundTowardsPositiveInf,
+			0x7c00, big.Above, floatBit.Overflow},
+		{"NegativeRTPosInf", "-1e6", floatBit.FormatFloat16, floatBit.RoundTowardsPositiveInf,
+			0xfbff, big.Above, floatBit.Overflow},
+		{"RTNegInf", "1e6", floatBit.FormatFloat16, floatBit.RoundTowardsNegativeInf,
+			0x7bff, big.Below, floatBit.Overflow},
+		{"NegativeRTNegInf", "-1e6", floatBit.FormatFloat16, floatBit.RoundTowardsNegativeInf,
+			0xfc00, big.Below, floatBit.Overflow},
+		{"AwayFromZero", "1e6", floatBit.FormatFloat16, floatBit.RoundAwayFromZero,
+			0x7c00, big.Above, floatBit.Overflow},
+		// E4M3 has no infinities, and its max normal value is 448, with 480
+		// encoding a NaN
+		{"NoInfinityRoundsDownToMax", "460", floatBit.FormatE4M3, floatBit.RoundNearestEven,
+			0x7e, big.Below, floatBit.Fits},
+		{"NoInfinityRNE", "1000", floatBit.FormatE4M3, floatBit.RoundNearestEven,
+			0x7f, big.Above, floatBit.NoEncoding},
+		{"NoInfinityRTZ", "1000", floatBit.FormatE4M3, floatBit.RoundTowardsZero,
+			0x7e, big.Below, floatBit.Overflow},
+		{"InfinityInputRTPosInf", "-Inf", floatBit.FormatE4M3, floatBit.RoundTowardsPositiveInf,
+			0xfe, big.Above, floatBit.NoEncoding},
+		// E2M1 has neither, so the max normal value (6) is the closest
+		{"NoNaNRNE", "7", floatBit.FormatE2M1, floatBit.RoundNearestEven,
+			0x7, big.Below, floatBit.NoEncoding},
+		{"NoNaNRoundsDownToMax", "6.5", floatBit.FormatE2M1, floatBit.RoundNearestEven,
+			0x7, big.Below, floatBit.Fits},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _, err := big.ParseFloat(tt.input, 0, 200, big.ToNearestEven)
+			if err != nil {
+				t.Fatalf("Invalid input %s: %v", tt.input, err)
+			}
+			resultVal, resultAcc, resultStatus := floatBit.Encode(*input, tt.f, tt.rm,
+				floatBit.OverflowIEEE, floatBit.SaturateMin, floatBit.TininessBeforeRounding)
+			if resultVal.Uint64() != tt.goldenVal || resultAcc != tt.goldenAcc ||
+				resultStatus != tt.goldenStatus {
+				t.Errorf("Input: %s Expected: %#x %v %v, Got: %#x %v %v", tt.input, tt.goldenVal,
+					tt.goldenAcc, tt.goldenStatus, resultVal, resultAcc, resultStatus)
+			}
+		})
+	}
+}
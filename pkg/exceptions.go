@@ -0,0 +1,166 @@
+package floatBit
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Exceptions is a set of the IEEE-754 exception flags raised by a conversion
+// or an arithmetic operation. Unlike [Status], several flags can be raised
+// together, like overflow and inexact
+type Exceptions uint8
+
+// Exceptions
+//
+// ExceptionInvalid: The operation has no defined result, like Inf - Inf or
+// 0 * Inf, and the result is NaN. Also raised for infinities converted to a
+// format without infinities, NaNs converted to a format without NaNs, and
+// signaling NaN operands
+//
+// ExceptionDivideByZero: A finite non-zero number was divided by zero, and
+// the result is an exact infinity
+//
+// ExceptionOverflow: The input rounded to the precision of the format, as if
+// the exponent range was unbounded, is larger than the largest finite number
+// in magnitude
+//
+// ExceptionUnderflow: The result is tiny (non-zero and smaller than the
+// minimum normal in magnitude) and inexact
+//
+// ExceptionInexact: The result is not the same as the exact result
+const (
+	ExceptionInvalid Exceptions = 1 << iota
+	ExceptionDivideByZero
+	ExceptionOverflow
+	ExceptionUnderflow
+	ExceptionInexact
+)
+
+// Returns true if all of the given flags are raised
+func (e Exceptions) Has(flags Exceptions) bool {
+	return e&flags == flags
+}
+
+// Stringer interface for Exceptions. The raised flags are separated by |
+func (e Exceptions) String() string {
+	if e == 0 {
+		return "none"
+	}
+	var names []string
+	for _, flag := range []struct {
+		flag Exceptions
+		name string
+	}{
+		{ExceptionInvalid, "invalid"},
+		{ExceptionDivideByZero, "divide_by_zero"},
+		{ExceptionOverflow, "overflow"},
+		{ExceptionUnderflow, "underflow"},
+		{ExceptionInexact, "inexact"},
+	} {
+		if e.Has(flag.flag) {
+			names = append(names, flag.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// Returns the exceptions raised by converting the input to the format with
+// the given rounding mode and [Tininess], given the [big.Accuracy] and
+// [Status] the conversion returned. Overflow and underflow (as decided by the
+// [Status]) always produce an inexact result. Like IEEE-754, overflow is
+// raised when the input rounded with an unbounded exponent range is larger
+// than the largest finite number, which is independent of the overflow mode,
+// and underflow is also raised for any other inexact result that is tiny,
+// i.e. smaller in magnitude than the minimum normal, either before or after
+// rounding
+func (f Format) Exceptions(input *big.Float, rm RoundingMode, t Tininess,
+	acc big.Accuracy, status Status) Exceptions {
+	var result Exceptions
+	if acc != big.Exact {
+		result |= ExceptionInexact
+	}
+
+	switch status {
+	case Overflow:
+		result |= ExceptionInexact
+	case Underflow:
+		result |= ExceptionUnderflow | ExceptionInexact
+	case NoEncoding:
+		// Either an infinity the format can't encode, which has no
+		// meaningful accuracy, or a finite number that overflowed to one
+		if input.IsInf() {
+			return ExceptionInvalid
+		}
+		result |= ExceptionInexact
+	}
+
+	if result.Has(ExceptionInexact) && f.overflows(input, rm, status) {
+		result |= ExceptionOverflow
+	}
+	if result.Has(ExceptionInexact) && f.isTiny(input, rm, t) {
+		result |= ExceptionUnderflow
+	}
+	return result
+}
+
+// Returns true if the finite input, rounded to the precision of the format as
+// if the exponent range was unbounded, is larger than the largest finite
+// number in magnitude. The overflow modes other than [OverflowIEEE] report
+// [Overflow] for every input larger than the largest finite number, before
+// rounding, so the [Status] can't decide this. [RoundStochastic] can't be
+// repeated though, so it is decided by the status
+func (f Format) overflows(input *big.Float, rm RoundingMode,
+	status Status) bool {
+	if input.IsInf() {
+		return false
+	}
+	if rm == RoundStochastic {
+		return status == Overflow || status == NoEncoding
+	}
+	maxFinite, _ := f.Decode(f.maxFinite(0))
+	var absInput big.Float
+	if absInput.Abs(input).Cmp(&maxFinite) <= 0 {
+		return false
+	}
+
+	// A format with the same precision, where the binades above the largest
+	// finite number of this format have normal numbers
+	unbounded := Format{ExponentBits: f.ExponentBits + 2,
+		MantissaBits: f.MantissaBits, Bias: f.Bias, NaN: NoNaN,
+		HasNegativeZero: true}
+	roundedBits, _, _ := Encode(*input, unbounded, rm, SaturateMax, SaturateMin,
+		TininessBeforeRounding)
+	rounded, _ := unbounded.Decode(roundedBits)
+	return rounded.Abs(&rounded).Cmp(&maxFinite) > 0
+}
+
+// Returns true if the given number is not zero, and smaller in magnitude than
+// the minimum normal of the format, 2^(1-bias). With
+// [TininessAfterRounding], the number is rounded to the precision of the
+// format first, as if the exponent range was unbounded
+func (f Format) isTiny(input *big.Float, rm RoundingMode,
+	t Tininess) bool {
+	if input.Sign() == 0 || input.IsInf() {
+		return false
+	}
+	// The input is in [2^(exponent-1), 2^exponent)
+	exponent := input.MantExp(nil)
+	if exponent > 1-f.Bias {
+		return false
+	}
+	// Rounding can only reach the minimum normal from the binade just below
+	// it, where the format only has subnormals
+	if t != TininessAfterRounding || rm == RoundStochastic || exponent < 1-f.Bias {
+		return true
+	}
+
+	// A format with the same precision, where the binade below the minimum
+	// normal of this format has normal numbers
+	unbounded := Format{ExponentBits: max(f.ExponentBits, 2),
+		MantissaBits: f.MantissaBits, Bias: f.Bias + 1, NaN: NoNaN,
+		HasNegativeZero: true}
+	roundedBits, _, _ := Encode(*input, unbounded, rm, SaturateMax, SaturateMin,
+		TininessBeforeRounding)
+	rounded, _ := unbounded.Decode(roundedBits)
+	return rounded.MantExp(nil) <= 1-f.Bias
+}
@@ -0,0 +1,129 @@
+package floatBit_test
+
+import (
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+func TestExceptions(t *testing.T) {
+	overflowInexact := floatBit.ExceptionOverflow | floatBit.ExceptionInexact
+	underflowInexact := floatBit.ExceptionUnderflow | floatBit.ExceptionInexact
+	testCases := []struct {
+		name string
+		// In
+		input string
+		f     floatBit.Format
+		rm    floatBit.RoundingMode
+		om    floatBit.OverflowMode
+		um    floatBit.UnderflowMode
+		t     floatBit.Tininess
+		// Out
+		golden floatBit.Exceptions
+	}{
+		{"Exact", "1.5", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding, 0},
+		{"Inexact", "0.1", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding, floatBit.ExceptionInexact},
+		{"Overflow", "1e39", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding, overflowInexact},
+		{"OverflowSaturateMax", "-1e39", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateMax, floatBit.SaturateMin, floatBit.TininessBeforeRounding, overflowInexact},
+		{"Underflow", "1e-50", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding, underflowInexact},
+		{"TinyExact", "0x1p-130", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding, 0},
+		{"TinyInexact", "0x1.000001p-130", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding, underflowInexact},
+		{"SmallestNormalInexact", "0x1.0000001p-126", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding,
+			floatBit.ExceptionInexact},
+		{"InfinityWithoutInfinities", "-Inf", floatBit.FormatE4M3, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding,
+			floatBit.ExceptionInvalid},
+		{"OverflowWithoutInfinities", "1e10", floatBit.FormatE4M3, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding, overflowInexact},
+		{"OverflowWithoutNaNs", "1e10", floatBit.FormatE3M2, floatBit.RoundNearestEven,
+			floatBit.MakeNaN, floatBit.SaturateMin, floatBit.TininessBeforeRounding, overflowInexact},
+		// The minimum normal of E4M3FNUZ is 2^-7 = 0.0078125
+		{"NotTinyFNUZ", "0.01", floatBit.FormatE4M3FNUZ, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding, floatBit.ExceptionInexact},
+		{"TinyFNUZ", "0.005", floatBit.FormatE4M3FNUZ, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding, underflowInexact},
+		// Just below the minimum normal, so this rounds up to it. It is only
+		// tiny before rounding
+		{"RoundsToMinNormal", "0x1.fffffffp-127", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessBeforeRounding, underflowInexact},
+		{"RoundsToMinNormalAfter", "0x1.fffffffp-127", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessAfterRounding,
+			floatBit.ExceptionInexact},
+		// The subnormal result is the same, but with an unbounded exponent
+		// the input would round down below the minimum normal
+		{"RoundsBelowMinNormalAfter", "0x1.fffffe8p-127", floatBit.FormatFloat32,
+			floatBit.RoundNearestEven, floatBit.SaturateInf,
+			floatBit.SaturateMin, floatBit.TininessAfterRounding, underflowInexact},
+		{"TruncatedBelowMinNormalAfter", "0x1.fffffffp-127", floatBit.FormatFloat32,
+			floatBit.RoundTowardsZero, floatBit.SaturateInf,
+			floatBit.SaturateMin, floatBit.TininessAfterRounding, underflowInexact},
+		{"StochasticAfter", "0x1.fffffffp-127", floatBit.FormatFloat32, floatBit.RoundStochastic,
+			floatBit.SaturateInf, floatBit.SaturateMin, floatBit.TininessAfterRounding,
+			underflowInexact},
+		// Rounds up to the minimum subnormal, so it doesn't underflow after
+		// rounding, but it is still tiny
+		{"RoundsToMinSubnormalAfter", "0x1.8p-150", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.FlushToZero, floatBit.TininessAfterRounding,
+			underflowInexact},
+		{"RoundToSubnormal", "0x1.8p-150", floatBit.FormatFloat32, floatBit.RoundNearestEven,
+			floatBit.SaturateInf, floatBit.RoundToSubnormal, floatBit.TininessBeforeRounding, underflowInexact},
+		// 449 rounds to the largest finite number of E4M3, 448, which fits,
+		// even though the status is overflow
+		{"RoundsToMaxFinite", "449", floatBit.FormatE4M3, floatBit.RoundNearestEven,
+			floatBit.SaturateMax, floatBit.SaturateMin, floatBit.TininessBeforeRounding, floatBit.ExceptionInexact},
+		{"RoundsAboveMaxFinite", "470", floatBit.FormatE4M3, floatBit.RoundNearestEven,
+			floatBit.SaturateMax, floatBit.SaturateMin, floatBit.TininessBeforeRounding, overflowInexact},
+		{"RoundsToMaxFiniteFloat16", "65505", floatBit.FormatFloat16, floatBit.RoundNearestEven,
+			floatBit.SaturateMax, floatBit.RoundToSubnormal, floatBit.TininessBeforeRounding, floatBit.ExceptionInexact},
+		{"RoundsAboveMaxFiniteFloat16", "65520", floatBit.FormatFloat16, floatBit.RoundNearestEven,
+			floatBit.SaturateMax, floatBit.RoundToSubnormal, floatBit.TininessBeforeRounding, overflowInexact},
+		// Rounding towards zero never rounds past the largest finite number
+		{"TruncatedToMaxFinite", "65535", floatBit.FormatFloat16, floatBit.RoundTowardsZero,
+			floatBit.OverflowIEEE, floatBit.RoundToSubnormal, floatBit.TininessBeforeRounding, floatBit.ExceptionInexact},
+		{"RoundsToMinNormalE2M1After", "0.9", floatBit.FormatE2M1, floatBit.RoundNearestEven,
+			floatBit.SaturateMax, floatBit.FlushToZero, floatBit.TininessAfterRounding,
+			floatBit.ExceptionInexact},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _, err := big.ParseFloat(tt.input, 0, 200, big.ToNearestEven)
+			if err != nil {
+				t.Fatalf("Invalid input %s: %v", tt.input, err)
+			}
+			_, acc, status := floatBit.Encode(*input, tt.f, tt.rm, tt.om, tt.um, tt.t,
+				floatBit.ExplicitRandomBits(0))
+			result := tt.f.Exceptions(input, tt.rm, tt.t, acc, status)
+			if result != tt.golden {
+				t.Errorf("Input: %s Accuracy: %v Status: %v Expected: %v, Got: %v", tt.input, acc,
+					status, tt.golden, result)
+			}
+		})
+	}
+}
+
+func TestExceptionsString(t *testing.T) {
+	testCases := []struct {
+		exceptions floatBit.Exceptions
+		golden     string
+	}{
+		{0, "none"},
+		{floatBit.ExceptionInexact, "inexact"},
+		{floatBit.ExceptionOverflow | floatBit.ExceptionInexact, "overflow|inexact"},
+		{floatBit.ExceptionInvalid | floatBit.ExceptionDivideByZero, "invalid|divide_by_zero"},
+	}
+	for _, tt := range testCases {
+		if result := tt.exceptions.String(); result != tt.golden {
+			t.Errorf("Expected: %s, Got: %s", tt.golden, result)
+		}
+	}
+}
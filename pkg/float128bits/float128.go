@@ -0,0 +1,194 @@
+package F128
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"slices"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+// Bits represents the bits which make up a IEEE-754 binary128 (quad
+// precision) number. Go doesn't have a 128-bit integer type, so the bits are
+// split into two halves. Hi holds the sign bit, the 15 exponent bits and the
+// upper 48 mantissa bits, and Lo holds the lower 64 mantissa bits.
+//
+// There is no native quad precision type either, so all the conversions are
+// done directly on [big.Float]
+type Bits struct {
+	Hi uint64
+	Lo uint64
+}
+
+// Returns true if the given [Bits] represent a NaN
+func (input Bits) IsNaN() bool {
+	return input.Hi&ExponentMask == ExponentMask &&
+		(input.Hi&MantissaHiMask != 0 || input.Lo != 0)
+}
+
+// Convert the given [Bits] type to a [big.Float] arbitrary precision
+// floating-point number. The result has 113 bits of precision, so the
+// conversion is always exact. Panics if the receiver is a NaN, since
+// [big.Float] cannot represent NaNs
+func (input Bits) ToBigFloat() big.Float {
+	if input.IsNaN() {
+		panic("NaN cannot be represented as a big.Float")
+	}
+
+	var result big.Float
+	result.SetPrec(uint(MantissaBits + 1))
+
+	signBit := input.Hi >> 63
+	exponentBits := int((input.Hi & ExponentMask) >> 48)
+	mantissa := new(big.Int).SetUint64(input.Hi & MantissaHiMask)
+	mantissa.Lsh(mantissa, 64)
+	mantissa.Or(mantissa, new(big.Int).SetUint64(input.Lo))
+
+	switch exponentBits {
+	case 0x7fff:
+		result.SetInf(signBit != 0)
+		return result
+	case 0:
+		// Subnormals have the same exponent as the minimum normal, but no
+		// implicit 1
+		exponentBits = 1
+	default:
+		mantissa.SetBit(mantissa, MantissaBits, 1)
+	}
+
+	// The value is the mantissa (as an integer) times 2 to the power of the
+	// exponent of its LSB
+	result.SetInt(mantissa)
+	result.SetMantExp(&result, exponentBits-ExponentBias-MantissaBits)
+	if signBit != 0 {
+		result.Neg(&result)
+	}
+	return result
+}
+
+// Convert the given [big.Float] arbitrary precision floating-point number
+// to a [Bits] type representing the bits of a binary128 number. If the number
+// cannot be represented in binary128 format exactly, then the rounding mode,
+// overflow mode and underflow mode decide the result. Returns the result
+// [Bits], a [big.Accuracy] which encodes whether the result value was the same,
+// larger or smaller than the input, and a [floatBit.Status] which encodes,
+// whether the result fit in the [Bits], caused overflow or underflow.
+// The [floatBit.Tininess] decides when the result underflows.
+// [floatBit.RoundStochastic] needs the optional rb argument.
+func FromBigFloat(input big.Float, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+	resultBits, resultAcc, resultStatus := floatBit.Encode(input,
+		floatBit.FormatFloat128, rm, om, um, t, rb...)
+	return fromBigInt(resultBits), resultAcc, resultStatus
+}
+
+// Returns the [floatBit.NaN] the given [Bits] encode, and false if they
+// aren't a NaN. See [floatBit.Format.DecodeNaN]
+func (input Bits) DecodeNaN() (floatBit.NaN, bool) {
+	asBigInt := new(big.Int).Lsh(new(big.Int).SetUint64(input.Hi), 64)
+	asBigInt.Or(asBigInt, new(big.Int).SetUint64(input.Lo))
+	return floatBit.FormatFloat128.DecodeNaN(asBigInt)
+}
+
+// Convert the given [floatBit.NaN] to a [Bits] type representing the bits of
+// a float128 NaN, with the given [floatBit.NaNPolicy]. Returns the result
+// [Bits], a [floatBit.Status] and the [floatBit.Exceptions] raised by the
+// conversion. See [floatBit.Format.EncodeNaN]
+func FromNaN(input floatBit.NaN, p floatBit.NaNPolicy) (Bits, floatBit.Status,
+	floatBit.Exceptions) {
+	resultBits, resultStatus, resultExceptions := floatBit.FormatFloat128.EncodeNaN(input, p)
+	return fromBigInt(resultBits), resultStatus, resultExceptions
+}
+
+// Utility function that splits a non-negative integer smaller than 2^128 into
+// the two halves of [Bits]
+func fromBigInt(input *big.Int) Bits {
+	var asBytes [16]byte
+	input.FillBytes(asBytes[:])
+	return Bits{Hi: binary.BigEndian.Uint64(asBytes[:8]),
+		Lo: binary.BigEndian.Uint64(asBytes[8:])}
+}
+
+// ToFloatFormat converts the given Bits type representing the bits that make
+// up a binary128 number into [floatBit.FloatBitFormat]
+// Implements the FloatBitFormatter Interface
+func (b *Bits) ToFloatFormat() floatBit.FloatBitFormat {
+	// Iterate over the bits and construct the return Values
+
+	signBits := (b.Hi & SignMask) >> 63
+	exponentBits := (b.Hi & ExponentMask) >> 48
+
+	// 1 Sign Bit
+	signRetVal := make([]byte, 0, 1)
+	if signBits == 0 {
+		signRetVal = append(signRetVal, byte('0'))
+	} else {
+		signRetVal = append(signRetVal, byte('1'))
+	}
+
+	// 15 Exponent Bits
+	exponentRetVal := make([]byte, 0, 15)
+	for i := 0; i < 15; i++ {
+		currentExponentBit := exponentBits & 0x1
+		var valueToAppend byte
+		if currentExponentBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		exponentRetVal = append(exponentRetVal, valueToAppend)
+		exponentBits >>= 1
+	}
+	slices.Reverse(exponentRetVal)
+
+	// 112 Mantissa Bits. The lower 64 come from Lo and the upper 48 from Hi
+	mantissaRetVal := make([]byte, 0, 112)
+	mantissaLo := b.Lo
+	mantissaHi := b.Hi & MantissaHiMask
+	for i := 0; i < 112; i++ {
+		var currentMantissaBit uint64
+		if i < 64 {
+			currentMantissaBit = mantissaLo & 0x1
+			mantissaLo >>= 1
+		} else {
+			currentMantissaBit = mantissaHi & 0x1
+			mantissaHi >>= 1
+		}
+		var valueToAppend byte
+		if currentMantissaBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		mantissaRetVal = append(mantissaRetVal, valueToAppend)
+	}
+	slices.Reverse(mantissaRetVal)
+
+	return floatBit.FloatBitFormat{Sign: signRetVal,
+		Exponent: exponentRetVal, Mantissa: mantissaRetVal}
+}
+
+// Conversion error returns the difference between the input [big.Float]
+// number and the binary128 number represented by the bits in the [Bits]
+// receiver
+func (b *Bits) ConversionError(input *big.Float) (big.Float, error) {
+	// If the receiver is a NaN then we return an error
+	if b.IsNaN() {
+		return *big.NewFloat(0.0), errors.New("NaN encountered")
+	}
+
+	asBigFloat := b.ToBigFloat()
+
+	// Infinities are equal to infinities of the same sign
+	if asBigFloat.IsInf() && input.IsInf() &&
+		asBigFloat.Signbit() == input.Signbit() {
+		return *big.NewFloat(0), nil
+	}
+
+	// The input may have more precision than binary128, so the difference
+	// uses the larger of the two precisions instead of the receiver's
+	convDiff := new(big.Float).Sub(&asBigFloat, input)
+	return *convDiff, nil
+}
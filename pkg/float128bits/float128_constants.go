@@ -0,0 +1,37 @@
+package F128
+
+const (
+	// Masks for the upper 64 bits. The lower 64 bits only hold mantissa bits
+	SignMask       uint64 = 0x8000_0000_0000_0000
+	ExponentMask   uint64 = 0x7fff_0000_0000_0000
+	MantissaHiMask uint64 = 0x0000_ffff_ffff_ffff
+
+	ExponentBias int = 16383
+	ExponentMin  int = -16382
+	ExponentMax  int = 16383
+
+	// Number of explicitly stored mantissa bits
+	MantissaBits int = 112
+)
+
+// The special values are variables, because Go doesn't have struct constants.
+// They must not be modified.
+var (
+	PositiveInfinity = Bits{Hi: 0x7fff_0000_0000_0000, Lo: 0}
+	NegativeInfinity = Bits{Hi: 0xffff_0000_0000_0000, Lo: 0}
+
+	PositiveZero = Bits{Hi: 0, Lo: 0}
+	NegativeZero = Bits{Hi: 0x8000_0000_0000_0000, Lo: 0}
+
+	PositiveMaxNormal = Bits{Hi: 0x7ffe_ffff_ffff_ffff, Lo: 0xffff_ffff_ffff_ffff}
+	NegativeMaxNormal = Bits{Hi: 0xfffe_ffff_ffff_ffff, Lo: 0xffff_ffff_ffff_ffff}
+
+	PositiveMinSubnormal = Bits{Hi: 0, Lo: 1}
+	NegativeMinSubnormal = Bits{Hi: 0x8000_0000_0000_0000, Lo: 1}
+
+	// Like the float32 format, the mantissa MSB is the quiet bit, and we
+	// encode NaN results as quiet NaNs with the payload bits=0
+	NaN         = Bits{Hi: 0x7fff_8000_0000_0000, Lo: 0}
+	PositiveNaN = Bits{Hi: 0x7fff_8000_0000_0000, Lo: 0}
+	NegativeNaN = Bits{Hi: 0xffff_8000_0000_0000, Lo: 0}
+)
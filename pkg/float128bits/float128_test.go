@@ -0,0 +1,194 @@
+package F128
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+// Parse the given string into a [big.Float] with enough precision to hold all
+// the test inputs exactly
+func parseBigFloat(input string) big.Float {
+	result, _, err := big.ParseFloat(input, 0, 300, big.ToNearestEven)
+	if err != nil {
+		panic(err)
+	}
+	return *result
+}
+
+func TestToBigFloat(t *testing.T) {
+	testCases := []struct {
+		input  Bits
+		golden string
+	}{
+		{PositiveZero, "0x0p+00"},
+		{Bits{Hi: 0x3fff_0000_0000_0000, Lo: 0}, "0x1p+00"},
+		// 1 + 2^-112 needs all 113 bits of precision
+		{Bits{Hi: 0xbfff_0000_0000_0000, Lo: 1}, "-0x1.0000000000000000000000000001p+00"},
+		{PositiveMaxNormal, "0x1.ffffffffffffffffffffffffffffp+16383"},
+		{PositiveMinSubnormal, "0x1p-16494"},
+		{Bits{Hi: 0x0000_8000_0000_0000, Lo: 0}, "0x1p-16383"},
+		{NegativeInfinity, "-Inf"},
+	}
+
+	for _, tt := range testCases {
+		result := tt.input.ToBigFloat()
+		if result.Text('x', -1) != tt.golden || result.Prec() != 113 {
+			t.Errorf("Input: %0#16x %0#16x, Expected: %s, Got: %s (precision %d)",
+				tt.input.Hi, tt.input.Lo, tt.golden, result.Text('x', -1), result.Prec())
+		}
+	}
+
+	negativeZero := NegativeZero.ToBigFloat()
+	if negativeZero.Sign() != 0 || !negativeZero.Signbit() {
+		t.Errorf("Expected -0, Got: %v", negativeZero.String())
+	}
+}
+
+func TestIsNaN(t *testing.T) {
+	testCases := []struct {
+		input  Bits
+		golden bool
+	}{
+		{PositiveNaN, true},
+		{NegativeNaN, true},
+		{Bits{Hi: 0x7fff_8000_0000_0000, Lo: 0}, true},
+		{PositiveInfinity, false},
+		{PositiveMaxNormal, false},
+	}
+
+	for _, tt := range testCases {
+		if result := tt.input.IsNaN(); result != tt.golden {
+			t.Errorf("Input: %0#16x %0#16x, Expected: %v, Got: %v", tt.input.Hi, tt.input.Lo, tt.golden, result)
+		}
+	}
+}
+
+// Test case shared by all the rounding function tests
+func TestFromBigFloat(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input big.Float
+		rm    floatBit.RoundingMode
+		um    floatBit.UnderflowMode
+		om    floatBit.OverflowMode
+		// Outputs
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{"PosInfInput", *big.NewFloat(math.Inf(1)), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, PositiveInfinity, big.Exact, floatBit.Fits},
+		{"NegZeroInput", *big.NewFloat(math.Copysign(0, -1)), floatBit.RoundTowardsPositiveInf,
+			floatBit.SaturateMin, floatBit.SaturateMax, NegativeZero, big.Exact, floatBit.Fits},
+		// 0.1 in the directed rounding modes
+		{"TenthRTZ", parseBigFloat("0.1"), floatBit.RoundTowardsZero, floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits{Hi: 0x3ffb_9999_9999_9999, Lo: 0x9999_9999_9999_9999}, big.Below, floatBit.Fits},
+		{"TenthRNE", parseBigFloat("0.1"), floatBit.RoundNearestEven, floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits{Hi: 0x3ffb_9999_9999_9999, Lo: 0x9999_9999_9999_999a}, big.Above, floatBit.Fits},
+		{"NegTenthRTPosInf", parseBigFloat("-0.1"), floatBit.RoundTowardsPositiveInf, floatBit.SaturateMin,
+			floatBit.SaturateMax, Bits{Hi: 0xbffb_9999_9999_9999, Lo: 0x9999_9999_9999_9999}, big.Above, floatBit.Fits},
+		// 1 + 2^-113 is exactly halfway between 1 and the next binary128 number
+		{"TieRNE", parseBigFloat("0x1.00000000000000000000000000008p0"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits{Hi: 0x3fff_0000_0000_0000, Lo: 0}, big.Below, floatBit.Fits},
+		{"TieRNO", parseBigFloat("0x1.00000000000000000000000000008p0"), floatBit.RoundNearestOdd,
+			floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits{Hi: 0x3fff_0000_0000_0000, Lo: 1}, big.Above, floatBit.Fits},
+		{"TieRoundHalfTowardsNegativeInf", parseBigFloat("-0x1.00000000000000000000000000008p0"),
+			floatBit.RoundHalfTowardsNegativeInf, floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits{Hi: 0xbfff_0000_0000_0000, Lo: 1}, big.Below, floatBit.Fits},
+		// The carry out of the lower half goes into the upper half
+		{"TieCarriesIntoHi", parseBigFloat("0x1.000000000000ffffffffffffffff8p0"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits{Hi: 0x3fff_0000_0000_0001, Lo: 0}, big.Above, floatBit.Fits},
+		// Subnormals
+		{"SubnormalTieRNE", parseBigFloat("0x1.8p-16494"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits{Hi: 0, Lo: 2}, big.Above, floatBit.Fits},
+		{"SubnormalToNormal", parseBigFloat("0x1.ffffffffffffffffffffffffffff8p-16383"),
+			floatBit.RoundTowardsPositiveInf, floatBit.FlushToZero, floatBit.SaturateMax,
+			Bits{Hi: 0x0001_0000_0000_0000, Lo: 0}, big.Above, floatBit.Fits},
+		// Underflow
+		{"UnderflowFlushToZero", parseBigFloat("-0x1p-16495"), floatBit.RoundTowardsNegativeInf,
+			floatBit.FlushToZero, floatBit.SaturateMax, NegativeZero, big.Above, floatBit.Underflow},
+		{"UnderflowSaturateMin", parseBigFloat("0x1.fffp-16495"), floatBit.RoundTowardsZero,
+			floatBit.SaturateMin, floatBit.SaturateMax, PositiveMinSubnormal, big.Above, floatBit.Underflow},
+		// Overflow
+		{"MaxNormalExact", parseBigFloat("0x1.ffffffffffffffffffffffffffffp16383"), floatBit.RoundTowardsPositiveInf,
+			floatBit.SaturateMin, floatBit.MakeNaN, PositiveMaxNormal, big.Exact, floatBit.Fits},
+		{"OverflowSaturateMax", parseBigFloat("0x1p16384"), floatBit.RoundTowardsZero,
+			floatBit.SaturateMin, floatBit.SaturateMax, PositiveMaxNormal, big.Below, floatBit.Overflow},
+		{"OverflowSaturateInf", parseBigFloat("-0x1p16384"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateInf, NegativeInfinity, big.Below, floatBit.Overflow},
+		// The rounding mode decides the response to overflow, after rounding
+		{"OverflowIEEERoundsDownToMax", parseBigFloat("0x1.ffffffffffffffffffffffffffff7p16383"),
+			floatBit.RoundNearestEven, floatBit.SaturateMin, floatBit.OverflowIEEE, PositiveMaxNormal, big.Below,
+			floatBit.Fits},
+		{"OverflowIEEERTPosInf", parseBigFloat("-0x1p16384"), floatBit.RoundTowardsPositiveInf,
+			floatBit.SaturateMin, floatBit.OverflowIEEE, NegativeMaxNormal, big.Above, floatBit.Overflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessBeforeRounding)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("Name: %s Input: %v", tt.name, tt.input.Text('p', 0))
+				t.Errorf("Expected Result: %#x, Got: %#x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+// Tininess after rounding only underflows when the result would round to zero
+func TestFromBigFloatTininessAfterRounding(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input big.Float
+		rm    floatBit.RoundingMode
+		um    floatBit.UnderflowMode
+		om    floatBit.OverflowMode
+		// Outputs
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{"RoundsToMinSubnormalAfter", parseBigFloat("0x1.8p-16495"), floatBit.RoundNearestEven,
+			floatBit.FlushToZero, floatBit.SaturateMax, PositiveMinSubnormal,
+			big.Above, floatBit.Fits},
+		{"TieToZeroAfter", parseBigFloat("-0x1p-16495"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, NegativeMinSubnormal,
+			big.Below, floatBit.Underflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessAfterRounding)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("Name: %s Input: %v", tt.name, tt.input.Text('p', 0))
+				t.Errorf("Expected Result: %#x, Got: %#x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+func TestToFloatFormat(t *testing.T) {
+	input := Bits{Hi: 0xc000_8000_0000_0000, Lo: 1}
+	result := input.ToFloatFormat()
+	// The mantissa has a 1 at both ends
+	goldenMantissa := "1" + strings.Repeat("0", 110) + "1"
+	if string(result.Sign) != "1" || string(result.Exponent) != "100000000000000" ||
+		string(result.Mantissa) != goldenMantissa {
+		t.Errorf("Expected Sign: 1, Exponent: 100000000000000, Mantissa: %s. Got: %v", goldenMantissa, result)
+	}
+}
@@ -0,0 +1,81 @@
+package F16
+
+import (
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+func TestArithmetic(t *testing.T) {
+	testCases := []struct {
+		name string
+		// In
+		op      string
+		a, b, c Bits
+		rm      floatBit.RoundingMode
+		// Out
+		goldenVal        Bits
+		goldenAcc        big.Accuracy
+		goldenStatus     floatBit.Status
+		goldenExceptions floatBit.Exceptions
+	}{
+		{"ZeroOverZero", "div", Bits(NegativeZero), 0, 0, floatBit.RoundNearestEven,
+			Bits(NaN), big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"DivideByZero", "div", 0x3c00, Bits(NegativeZero), 0,
+			floatBit.RoundNearestEven, Bits(NegativeInfinity), big.Exact,
+			floatBit.Fits, floatBit.ExceptionDivideByZero},
+		{"SqrtNegativeZero", "sqrt", Bits(NegativeZero), 0, 0,
+			floatBit.RoundNearestEven, Bits(NegativeZero), big.Exact, floatBit.Fits, 0},
+		{"OneThirdRNE", "div", 0x3c00, 0x4200, 0, floatBit.RoundNearestEven,
+			0x3555, big.Below, floatBit.Fits, floatBit.ExceptionInexact},
+		{"Overflow", "add", Bits(PositiveMaxNormal), Bits(PositiveMaxNormal), 0,
+			floatBit.RoundNearestEven, Bits(PositiveInfinity), big.Above,
+			floatBit.Overflow, floatBit.ExceptionOverflow | floatBit.ExceptionInexact},
+		// 2^-28 is below the minimum subnormal
+		{"Underflow", "mul", 0x0400, 0x0400, 0, floatBit.RoundNearestEven,
+			Bits(PositiveZero), big.Below, floatBit.Underflow, floatBit.ExceptionUnderflow | floatBit.ExceptionInexact},
+		// (1 + 2^-10)^2 - (1 + 2^-9) is 2^-20 exactly, while the rounded
+		// product cancels out completely
+		{"FMAExact", "fma", 0x3c01, 0x3c01, 0xbc02, floatBit.RoundNearestEven,
+			0x0010, big.Exact, floatBit.Fits, 0},
+		// The payload of the first NaN is kept, even if the other one is
+		// signaling
+		{"QuietNaNPayload", "div", 0xfe2a, 0x7c01, 0, floatBit.RoundNearestEven,
+			0xfe2a, big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"FMAInvalid", "fma", 0, Bits(NegativeInfinity), 0x3c00,
+			floatBit.RoundNearestEven, Bits(NaN), big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var resultVal Bits
+			var resultAcc big.Accuracy
+			var resultStatus floatBit.Status
+			var resultExceptions floatBit.Exceptions
+			om, um, tininess := floatBit.SaturateInf, floatBit.FlushToZero, floatBit.TininessBeforeRounding
+			switch tt.op {
+			case "add":
+				resultVal, resultAcc, resultStatus, resultExceptions = Add(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "sub":
+				resultVal, resultAcc, resultStatus, resultExceptions = Sub(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "mul":
+				resultVal, resultAcc, resultStatus, resultExceptions = Mul(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "div":
+				resultVal, resultAcc, resultStatus, resultExceptions = Div(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "sqrt":
+				resultVal, resultAcc, resultStatus, resultExceptions = Sqrt(tt.a, tt.rm, om, um, tininess)
+			case "fma":
+				resultVal, resultAcc, resultStatus, resultExceptions = FMA(tt.a, tt.b, tt.c, tt.rm, om, um, tininess)
+			}
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) ||
+				(resultStatus != tt.goldenStatus) || (resultExceptions != tt.goldenExceptions) {
+				t.Logf("a: %0#4x b: %0#4x c: %0#4x Rounding Mode: %v", tt.a, tt.b, tt.c, tt.rm)
+				t.Errorf("Expected Result: %0#4x, Got: %0#4x", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v", tt.goldenStatus, resultStatus)
+				t.Errorf("Expected Exceptions: %v, Got: %v", tt.goldenExceptions, resultExceptions)
+			}
+		})
+	}
+}
@@ -35,7 +35,8 @@ type Bits uint16
 // inside a float32 value. This is effectively, a bit_cast to float16,
 // followed by a upcast to float32. Since Go doesn't natively support
 // float16 values, this method performs some bit-twiddling, to align the bits
-// per the float32 bit representation
+// per the float32 bit representation. NaNs keep their sign, but always give
+// the quiet NaN with a zero payload. Use [Bits.DecodeNaN] to get the payload
 func (input Bits) ToFloat32() float32 {
 	asUint16 := uint16(input)
 	// Extract the Sign, Exponent and Mantissa
@@ -128,6 +129,11 @@ func (input Bits) ToFloat32() float32 {
 		float32MantissaBits)
 }
 
+// Returns true if the given [Bits] represent a NaN
+func (input Bits) IsNaN() bool {
+	return uint16(input)&ExponentMask == ExponentMask && uint16(input)&MantissaMask != 0
+}
+
 // Convert the given [Bits] type to a [big.Float] arbitrary precision
 // floating-point number
 func (input Bits) ToBigFloat() big.Float {
@@ -143,68 +149,45 @@ func (input Bits) ToBigFloat() big.Float {
 // [Bits], a [big.Accuracy] which encodes whether the result value was the same,
 // larger or smaller than the input, and a [floatBit.Status] which encodes,
 // whether the result fit in the [Bits], caused overflow or underflow.
+// The [floatBit.Tininess] decides when the result underflows.
+// [floatBit.RoundStochastic] needs the optional rb argument.
 func FromBigFloat(input big.Float, rm floatBit.RoundingMode,
-	om floatBit.OverflowMode, um floatBit.UnderflowMode) (Bits,
-	big.Accuracy, floatBit.Status) {
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+
+	// Converting to an intermediate float32 first would round the input
+	// twice, and lose the bits below float32 precision. The generic encoder
+	// extracts the mantissa and the sticky bit directly from the [big.Float]
+	// instead, so the result is correctly rounded at any input precision
+	resultBits, resultAcc, resultStatus := floatBit.Encode(input,
+		floatBit.FormatFloat16, rm, om, um, t, rb...)
+	return Bits(resultBits.Uint64()), resultAcc, resultStatus
+}
 
-	// Since the [big] package's methods do not support rounding modes for
-	// direct conversion to bfloat16. We convert to an intermediate [float32]
-	// number and use our custom conversion functions [FromFloat32] to convert
-	// to [Bits]
-	input.SetMode(big.ToZero)
-	closestFloat32, fromBigFloatAcc := input.Float32()
-
-	var asFloat32 float32
-	// big.Float.Float32() returns the float32 closest to the input.
-	// This might cause it to round up for some cases.
-	// But, we need to get the value with extra precision truncated
-	// Therefore, to get the truncated result, we need to subtract 1 ULP of
-	// precision if the number is positive and the float32 is larger, or
-	// if the number is negative and the float32 is smaller, or alternatively
-	// if the .Float32() returns big.Above as the accuracy, because for
-	// truncation this should always be big.Below
-	// Note that however, we need to exempt, the case where the results
-	// becomes infinity.
-	if math.IsInf(float64(closestFloat32), 1) && fromBigFloatAcc == big.Above {
-		// If input was greater than F32 Maximum Normal, then closestFloat32
-		// would be +inf, and the accuracy returned would be big.Above
-		// F32.PositiveMaxNormal will trigger overflow repsonse in BF16
-		asFloat32 = math.Float32frombits(F32.PositiveMaxNormal)
-	} else if math.IsInf(float64(closestFloat32), -1) && fromBigFloatAcc == big.Below {
-		// Similarly,
-		// for -inf case, it will be big.Below.
-		// F32.NegativeMaxNormal will trigger overflow response in BF16
-		asFloat32 = math.Float32frombits(F32.NegativeMaxNormal)
-	} else if closestFloat32 == 0.0 && fromBigFloatAcc == big.Below {
-		// We also need to do this for the cases, where closestFloat32 is smaller
-		// than the minimum float32 subnormal, again, because we want to handle
-		// the underflow response in the BF16 methods.
-		// F32.PositiveMinSubnormal will trigger underflow response in BF16
-		asFloat32 = math.Float32frombits(F32.PositiveMinSubnormal)
-	} else if closestFloat32 == -0.0 && fromBigFloatAcc == big.Above {
-		// And for the negative case
-		// F32.NegativeMinSubnormal will trigger underflow response in BF16
-		asFloat32 = math.Float32frombits(F32.NegativeMinSubnormal)
-	} else if (input.Sign() > 0 && fromBigFloatAcc == big.Above) ||
-		(input.Sign() < 0 && fromBigFloatAcc == big.Below) {
-		// For positive numbers if the accuracy was big.Above, then Float32()
-		// caused rounding away from zero. This is undesirable. To make it
-		// truncation we need to subtract 1 ULP from the number
-		closestFloat32Bits := math.Float32bits(closestFloat32)
-		asFloat32 = math.Float32frombits(closestFloat32Bits - 1)
-	} else {
-		asFloat32 = closestFloat32
-	}
+// Returns the [floatBit.NaN] the given [Bits] encode, and false if they
+// aren't a NaN. See [floatBit.Format.DecodeNaN]
+func (input Bits) DecodeNaN() (floatBit.NaN, bool) {
+	return floatBit.FormatFloat16.DecodeNaN(new(big.Int).SetUint64(uint64(input)))
+}
 
-	resultBits, resultAcc, resultStatus := FromFloat32(asFloat32, rm, om, um)
-	return resultBits, resultAcc, resultStatus
+// Convert the given [floatBit.NaN] to a [Bits] type representing the bits of
+// a float16 NaN, with the given [floatBit.NaNPolicy]. Returns the result
+// [Bits], a [floatBit.Status] and the [floatBit.Exceptions] raised by the
+// conversion. See [floatBit.Format.EncodeNaN]
+func FromNaN(input floatBit.NaN, p floatBit.NaNPolicy) (Bits, floatBit.Status,
+	floatBit.Exceptions) {
+	resultBits, resultStatus, resultExceptions := floatBit.FormatFloat16.EncodeNaN(input, p)
+	return Bits(resultBits.Uint64()), resultStatus, resultExceptions
 }
 
 // Convert the given float32 number into a [Bits] type which represents the bits
 // of a half-preicision floating point number. Signature and usage is identical
-// to [FromBigFloat] except the parameter input is float32
+// to [FromBigFloat] except the parameter input is float32. NaNs are always
+// canonicalized to [NaN], which drops the sign and the payload, like
+// [floatBit.NaNCanonicalize]. Use [FromNaN] to choose the [floatBit.NaNPolicy]
 func FromFloat32(input float32, rm floatBit.RoundingMode,
-	om floatBit.OverflowMode, um floatBit.UnderflowMode) (Bits, big.Accuracy,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy,
 	floatBit.Status) {
 	// There are some special cases we need to handle, particularly those for
 	// values like Inf, NaN which have special encodings in the two formats
@@ -224,6 +207,16 @@ func FromFloat32(input float32, rm floatBit.RoundingMode,
 		return Bits(NaN), big.Exact, floatBit.Fits
 	}
 
+	// Stochastic rounding needs all of the discarded bits, not just the
+	// round and sticky bits, which [FromBigFloat] extracts. [FromBigFloat]
+	// also takes care of rounding the numbers below the minimum subnormal
+	// (see [floatBit.UnderflowMode.RoundsBelowMinSubnormal]), and of detecting
+	// overflow after rounding for [floatBit.OverflowIEEE]
+	if rm == floatBit.RoundStochastic || um.RoundsBelowMinSubnormal(t) ||
+		om == floatBit.OverflowIEEE {
+		return FromBigFloat(*big.NewFloat(float64(input)), rm, om, um, t, rb...)
+	}
+
 	// For the remaining cases, we need access to the underlying bits of the
 	// float32 number. As such, we need to
 	// first view the type as a uint32 format. This will allow us to
@@ -379,6 +372,12 @@ func FromFloat32(input float32, rm floatBit.RoundingMode,
 	case floatBit.RoundNearestOdd:
 		resultVal, resultAcc = roundNearestOdd(signBit, adjustedExponent,
 			alignedMantissa, lostPrecision)
+	case floatBit.RoundAwayFromZero:
+		resultVal, resultAcc = roundAwayFromZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfAwayFromZero:
+		resultVal, resultAcc = roundHalfAwayFromZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
 	}
 
 	return resultVal, resultAcc, floatBit.Fits
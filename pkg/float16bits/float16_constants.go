@@ -9,7 +9,7 @@ const (
 	NegativeInfinity uint16 = 0b1_11111_0000000000
 
 	PositiveMaxNormal uint16 = 0b0_11110_1111111111
-	NegativeMaxNormal uint16 = 0b0_11110_1111111111
+	NegativeMaxNormal uint16 = 0b1_11110_1111111111
 
 	PositiveZero uint16 = 0b0_00000_0000000000
 	NegativeZero uint16 = 0b1_00000_0000000000
@@ -19,12 +19,13 @@ const (
 
 	// In float16 format, all numbers with the exponent bits = 11111
 	// and mantissa bits not all zero, constitute the special NaN value
-	// We just use the first of these values as the flag NaN value, whenever
-	// we want to return one. When parsing, all these values will be treated
-	// as NaN
-	NaN         uint16 = 0b0_11111_0000000001
-	PositiveNaN uint16 = 0b0_11111_0000000001
-	NegativeNaN uint16 = 0b1_11111_0000000001
+	// The mantissa MSB is the quiet bit, and the rest of the mantissa bits
+	// are the payload. We use the quiet NaN with payload 0 as the flag NaN
+	// value, whenever we want to return one. When parsing, all these values
+	// will be treated as NaN
+	NaN         uint16 = 0b0_11111_1000000000
+	PositiveNaN uint16 = 0b0_11111_1000000000
+	NegativeNaN uint16 = 0b1_11111_1000000000
 
 	ExponentBias int = 15
 	ExponentMin  int = -14
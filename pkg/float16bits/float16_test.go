@@ -1430,6 +1430,15 @@ func TestRoundNearestOdd(t *testing.T) {
 	}
 }
 
+// Parses the input with more precision than float32 and float64 have
+func parseBigFloat(input string) big.Float {
+	result, _, err := big.ParseFloat(input, 0, 200, big.ToNearestEven)
+	if err != nil {
+		panic(err)
+	}
+	return *result
+}
+
 func TestFromBigFloat(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -1653,10 +1662,82 @@ func TestFromBigFloat(t *testing.T) {
 			goldenAcc:    big.Above,
 			goldenStatus: floatBit.Underflow,
 		},
+		// 1e-8 is below half of the minimum subnormal (2^-24), so it rounds
+		// to zero, and anything above half rounds up to it
+		{
+			name:         "RoundToSubnormalZero",
+			input:        *big.NewFloat(1e-8),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.RoundToSubnormal,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveZero),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Underflow,
+		},
+		{
+			name:         "RoundToSubnormalMin",
+			input:        *big.NewFloat(-4e-8),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.RoundToSubnormal,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(NegativeMinSubnormal),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Underflow,
+		},
+		{
+			name:         "RoundToSubnormalRTPosInf",
+			input:        *big.NewFloat(1e-46),
+			rm:           floatBit.RoundTowardsPositiveInf,
+			um:           floatBit.RoundToSubnormal,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMinSubnormal),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
+		{
+			name:         "StickyBelowFloat32Precision",
+			input:        parseBigFloat("0x1.002000000000001p0"),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0x3c01),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegativeStickyBelowFloat32Precision",
+			input:        parseBigFloat("-0x1.002000000000001p0"),
+			rm:           floatBit.RoundHalfTowardsZero,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0xbc01),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "UnderflowBelowFloat32MinSubnormal",
+			input:        parseBigFloat("1e-45"),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMinSubnormal),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
+		{
+			name:         "NegativeUnderflowBelowFloat32MinSubnormal",
+			input:        parseBigFloat("-1e-45"),
+			rm:           floatBit.RoundTowardsZero,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(NegativeZero),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
 	}
 
 	for _, tt := range testCases {
-		resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um)
+		resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessBeforeRounding)
 		if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
 			t.Logf("Failed Input Set:\n")
 			t.Logf("Name: %s", tt.name)
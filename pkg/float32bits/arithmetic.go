@@ -0,0 +1,132 @@
+package F32
+
+import (
+	"math/big"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+// Returns a + b as a [float32] number. The exact sum is rounded only once, with
+// the given rounding mode, overflow mode, underflow mode and tininess, just
+// like
+// [FromBigFloat] does. Returns the result [Bits], a [big.Accuracy] which
+// encodes whether the result was the same, larger or smaller than the exact
+// sum, a [floatBit.Status], and the [floatBit.Exceptions] raised by the
+// addition and the rounding. If either input is a NaN, the result is the first
+// NaN input, quieted, and [floatBit.ExceptionInvalid] is raised if either of
+// them is a signaling NaN (see [floatBit.Format.PropagateNaN]). If the sum is
+// undefined (Inf - Inf), the result is [NaN] and [floatBit.ExceptionInvalid]
+// is raised.
+// [floatBit.RoundStochastic] needs the optional rb argument.
+func Add(a, b Bits, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+	um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status,
+	floatBit.Exceptions) {
+	if result, exceptions, ok := propagateNaN(a, b); ok {
+		return result, big.Exact, floatBit.Fits, exceptions
+	}
+	x, y := a.ToBigFloat(), b.ToBigFloat()
+	result, exceptions := floatBit.Add(&x, &y, rm)
+	return roundResult(result, exceptions, rm, om, um, t, rb...)
+}
+
+// Returns a - b as a [float32] number. See [Add]
+func Sub(a, b Bits, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+	um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status,
+	floatBit.Exceptions) {
+	if result, exceptions, ok := propagateNaN(a, b); ok {
+		return result, big.Exact, floatBit.Fits, exceptions
+	}
+	x, y := a.ToBigFloat(), b.ToBigFloat()
+	result, exceptions := floatBit.Sub(&x, &y, rm)
+	return roundResult(result, exceptions, rm, om, um, t, rb...)
+}
+
+// Returns a * b as a [float32] number. See [Add]. The product of a zero and an
+// infinity is [NaN]
+func Mul(a, b Bits, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+	um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status,
+	floatBit.Exceptions) {
+	if result, exceptions, ok := propagateNaN(a, b); ok {
+		return result, big.Exact, floatBit.Fits, exceptions
+	}
+	x, y := a.ToBigFloat(), b.ToBigFloat()
+	result, exceptions := floatBit.Mul(&x, &y)
+	return roundResult(result, exceptions, rm, om, um, t, rb...)
+}
+
+// Returns a / b as a [float32] number. See [Add]. 0 / 0 and Inf / Inf are
+// [NaN], and any other number divided by zero is an infinity, which raises
+// [floatBit.ExceptionDivideByZero] for finite numbers
+func Div(a, b Bits, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+	um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status,
+	floatBit.Exceptions) {
+	if result, exceptions, ok := propagateNaN(a, b); ok {
+		return result, big.Exact, floatBit.Fits, exceptions
+	}
+	x, y := a.ToBigFloat(), b.ToBigFloat()
+	result, exceptions := floatBit.Div(&x, &y)
+	return roundResult(result, exceptions, rm, om, um, t, rb...)
+}
+
+// Returns the square root of a as a [float32] number. See [Add]. The square root
+// of a number less than zero is [NaN]
+func Sqrt(a Bits, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+	um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status,
+	floatBit.Exceptions) {
+	if result, exceptions, ok := propagateNaN(a); ok {
+		return result, big.Exact, floatBit.Fits, exceptions
+	}
+	x := a.ToBigFloat()
+	result, exceptions := floatBit.Sqrt(&x)
+	return roundResult(result, exceptions, rm, om, um, t, rb...)
+}
+
+// Returns a * b + c as a [float32] number, with a single rounding of the exact
+// result. See [Add]
+func FMA(a, b, c Bits, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+	um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status,
+	floatBit.Exceptions) {
+	if result, exceptions, ok := propagateNaN(a, b, c); ok {
+		return result, big.Exact, floatBit.Fits, exceptions
+	}
+	x, y, z := a.ToBigFloat(), b.ToBigFloat(), c.ToBigFloat()
+	result, exceptions := floatBit.FMA(&x, &y, &z, rm)
+	return roundResult(result, exceptions, rm, om, um, t, rb...)
+}
+
+// Rounds the result of one of the [floatBit] arithmetic functions to
+// [float32]. The result is rounded to odd with enough precision, so the
+// accuracy of the rounding and the exceptions it raises are the same as for
+// the exact result
+func roundResult(result *big.Float, exceptions floatBit.Exceptions,
+	rm floatBit.RoundingMode, om floatBit.OverflowMode, um floatBit.UnderflowMode,
+	t floatBit.Tininess, rb ...floatBit.RandomBits) (Bits, big.Accuracy,
+	floatBit.Status, floatBit.Exceptions) {
+	if result == nil {
+		return Bits(NaN), big.Exact, floatBit.Fits, exceptions
+	}
+	resultVal, resultAcc, resultStatus := FromBigFloat(*result, rm, om, um, t, rb...)
+	exceptions |= floatBit.FormatFloat32.Exceptions(result, rm, t, resultAcc,
+		resultStatus)
+	return resultVal, resultAcc, resultStatus, exceptions
+}
+
+// Returns the result of an operation on the given operands, if any of them is
+// a NaN. See [floatBit.Format.PropagateNaN]
+func propagateNaN(operands ...Bits) (Bits, floatBit.Exceptions, bool) {
+	asBigInts := make([]*big.Int, len(operands))
+	for i, operand := range operands {
+		asBigInts[i] = new(big.Int).SetUint64(uint64(operand))
+	}
+	result, exceptions, ok := floatBit.FormatFloat32.PropagateNaN(asBigInts...)
+	if !ok {
+		return 0, 0, false
+	}
+	return Bits(result.Uint64()), exceptions, true
+}
@@ -0,0 +1,171 @@
+package F32
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+// With round to nearest even, the results must match the native float32
+// operations. Results below the minimum subnormal are decided by the
+// underflow mode instead, so they are skipped
+func TestArithmeticNative(t *testing.T) {
+	rng := rand.New(rand.NewSource(16))
+	randomFloat32 := func() float32 {
+		for {
+			result := math.Float32frombits(rng.Uint32())
+			if !math.IsNaN(float64(result)) {
+				return result
+			}
+		}
+	}
+
+	for range 10000 {
+		a, b := randomFloat32(), randomFloat32()
+		x, y := FromFloat32(a), FromFloat32(b)
+		testCases := []struct {
+			name   string
+			golden float32
+			op     func(Bits, Bits, floatBit.RoundingMode, floatBit.OverflowMode,
+				floatBit.UnderflowMode, floatBit.Tininess, ...floatBit.RandomBits) (Bits,
+				big.Accuracy, floatBit.Status, floatBit.Exceptions)
+		}{
+			{"Add", a + b, Add},
+			{"Sub", a - b, Sub},
+			{"Mul", a * b, Mul},
+			{"Div", a / b, Div},
+			{"Sqrt", float32(math.Sqrt(float64(a))),
+				func(a, _ Bits, rm floatBit.RoundingMode, om floatBit.OverflowMode,
+					um floatBit.UnderflowMode, tininess floatBit.Tininess,
+					rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status,
+					floatBit.Exceptions) {
+					return Sqrt(a, rm, om, um, tininess, rb...)
+				}},
+		}
+		for _, tt := range testCases {
+			result, _, status, _ := tt.op(x, y, floatBit.RoundNearestEven,
+				floatBit.SaturateInf, floatBit.FlushToZero, floatBit.TininessBeforeRounding)
+			if status == floatBit.Underflow {
+				continue
+			}
+			golden := FromFloat32(tt.golden)
+			if math.IsNaN(float64(tt.golden)) {
+				golden = Bits(NaN)
+			}
+			if result != golden {
+				t.Errorf("%s: a: %0#8x b: %0#8x Expected: %0#8x, Got: %0#8x",
+					tt.name, x, y, golden, result)
+			}
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	maxNormal := Bits(PositiveMaxNormal)
+	testCases := []struct {
+		name string
+		// In
+		op      string
+		a, b, c Bits
+		rm      floatBit.RoundingMode
+		// Out
+		goldenVal        Bits
+		goldenAcc        big.Accuracy
+		goldenStatus     floatBit.Status
+		goldenExceptions floatBit.Exceptions
+	}{
+		{"InfMinusInf", "sub", Bits(PositiveInfinity), Bits(PositiveInfinity), 0,
+			floatBit.RoundNearestEven, Bits(NaN), big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"ZeroTimesInf", "mul", Bits(NegativeZero), Bits(PositiveInfinity), 0,
+			floatBit.RoundNearestEven, Bits(NaN), big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"ZeroOverZero", "div", 0, 0, 0, floatBit.RoundNearestEven, Bits(NaN),
+			big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"SqrtNegative", "sqrt", 0xbf80_0000, 0, 0, floatBit.RoundNearestEven,
+			Bits(NaN), big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"NaNInput", "add", Bits(NegativeNaN), 0x3f80_0000, 0,
+			floatBit.RoundNearestEven, Bits(NegativeNaN), big.Exact, floatBit.Fits, 0},
+		// The first NaN is quieted, and keeps its payload
+		{"SignalingNaNPayload", "mul", 0x3f80_0000, 0xff80_002a, Bits(NaN),
+			floatBit.RoundNearestEven, 0xffc0_002a, big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"FirstNaNPayload", "fma", 0x7fc0_0001, 0x3f80_0000, 0xff80_0002,
+			floatBit.RoundNearestEven, 0x7fc0_0001, big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"DivideByZero", "div", 0xbf80_0000, 0, 0, floatBit.RoundNearestEven,
+			Bits(NegativeInfinity), big.Exact, floatBit.Fits, floatBit.ExceptionDivideByZero},
+		{"SqrtNegativeZero", "sqrt", Bits(NegativeZero), 0, 0,
+			floatBit.RoundNearestEven, Bits(NegativeZero), big.Exact, floatBit.Fits, 0},
+		{"ZerosWithDifferentSigns", "add", Bits(PositiveZero), Bits(NegativeZero), 0,
+			floatBit.RoundNearestEven, Bits(PositiveZero), big.Exact, floatBit.Fits, 0},
+		{"ZerosWithDifferentSignsRTNegInf", "add", Bits(PositiveZero),
+			Bits(NegativeZero), 0, floatBit.RoundTowardsNegativeInf,
+			Bits(NegativeZero), big.Exact, floatBit.Fits, 0},
+		{"NegativeZeros", "add", Bits(NegativeZero), Bits(NegativeZero), 0,
+			floatBit.RoundNearestEven, Bits(NegativeZero), big.Exact, floatBit.Fits, 0},
+		{"CancellationRTNegInf", "sub", 0x3f80_0000, 0x3f80_0000, 0,
+			floatBit.RoundTowardsNegativeInf, Bits(NegativeZero), big.Exact,
+			floatBit.Fits, 0},
+		{"Overflow", "add", maxNormal, maxNormal, 0, floatBit.RoundNearestEven,
+			Bits(PositiveInfinity), big.Above, floatBit.Overflow, floatBit.ExceptionOverflow | floatBit.ExceptionInexact},
+		// 1 + 2^-40 rounds to 1 when it is rounded to float64 or float32
+		// first
+		{"SingleRoundingRTPosInf", "add", 0x3f80_0000, 0x2b80_0000, 0,
+			floatBit.RoundTowardsPositiveInf, 0x3f80_0001, big.Above, floatBit.Fits, floatBit.ExceptionInexact},
+		{"OneThirdRTZ", "div", 0x3f80_0000, 0x4040_0000, 0, floatBit.RoundTowardsZero,
+			0x3eaa_aaaa, big.Below, floatBit.Fits, floatBit.ExceptionInexact},
+		{"OneThirdRTPosInf", "div", 0x3f80_0000, 0x4040_0000, 0,
+			floatBit.RoundTowardsPositiveInf, 0x3eaa_aaab, big.Above, floatBit.Fits, floatBit.ExceptionInexact},
+		// 2^-130 is tiny, but only raises underflow when it's inexact
+		{"TinyExact", "mul", 0x0d80_0000, 0x3080_0000, 0, floatBit.RoundNearestEven,
+			0x0008_0000, big.Exact, floatBit.Fits, 0},
+		{"TinyInexact", "mul", 0x0d80_0000, 0x3080_0001, 0, floatBit.RoundNearestEven,
+			0x0008_0000, big.Below, floatBit.Fits,
+			floatBit.ExceptionUnderflow | floatBit.ExceptionInexact},
+		{"SqrtExact", "sqrt", 0x4110_0000, 0, 0, floatBit.RoundTowardsZero,
+			0x4040_0000, big.Exact, floatBit.Fits, 0},
+		{"SqrtTwoRTPosInf", "sqrt", 0x4000_0000, 0, 0,
+			floatBit.RoundTowardsPositiveInf, 0x3fb5_04f4, big.Above, floatBit.Fits, floatBit.ExceptionInexact},
+		// (1 + 2^-23)^2 - (1 + 2^-22) is 2^-46 exactly, while the rounded
+		// product cancels out completely
+		{"FMAExact", "fma", 0x3f80_0001, 0x3f80_0001, 0xbf80_0002,
+			floatBit.RoundNearestEven, 0x2880_0000, big.Exact, floatBit.Fits, 0},
+		{"FMAInvalid", "fma", Bits(PositiveInfinity), 0, 0x3f80_0000,
+			floatBit.RoundNearestEven, Bits(NaN), big.Exact, floatBit.Fits, floatBit.ExceptionInvalid},
+		{"FMAInfinities", "fma", Bits(PositiveInfinity), 0xbf80_0000,
+			Bits(PositiveInfinity), floatBit.RoundNearestEven, Bits(NaN), big.Exact,
+			floatBit.Fits, floatBit.ExceptionInvalid},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var resultVal Bits
+			var resultAcc big.Accuracy
+			var resultStatus floatBit.Status
+			var resultExceptions floatBit.Exceptions
+			om, um, tininess := floatBit.SaturateInf, floatBit.FlushToZero, floatBit.TininessBeforeRounding
+			switch tt.op {
+			case "add":
+				resultVal, resultAcc, resultStatus, resultExceptions = Add(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "sub":
+				resultVal, resultAcc, resultStatus, resultExceptions = Sub(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "mul":
+				resultVal, resultAcc, resultStatus, resultExceptions = Mul(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "div":
+				resultVal, resultAcc, resultStatus, resultExceptions = Div(tt.a, tt.b, tt.rm, om, um, tininess)
+			case "sqrt":
+				resultVal, resultAcc, resultStatus, resultExceptions = Sqrt(tt.a, tt.rm, om, um, tininess)
+			case "fma":
+				resultVal, resultAcc, resultStatus, resultExceptions = FMA(tt.a, tt.b, tt.c, tt.rm, om, um, tininess)
+			}
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) ||
+				(resultStatus != tt.goldenStatus) || (resultExceptions != tt.goldenExceptions) {
+				t.Logf("a: %0#8x b: %0#8x c: %0#8x Rounding Mode: %v", tt.a, tt.b, tt.c, tt.rm)
+				t.Errorf("Expected Result: %0#8x, Got: %0#8x", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v", tt.goldenStatus, resultStatus)
+				t.Errorf("Expected Exceptions: %v, Got: %v", tt.goldenExceptions, resultExceptions)
+			}
+		})
+	}
+}
@@ -49,6 +49,11 @@ func (input Bits) ToFloat32() float32 {
 	return math.Float32frombits(uint32(input))
 }
 
+// Returns true if the given [Bits] represent a NaN
+func (input Bits) IsNaN() bool {
+	return uint32(input)&ExponentMask == ExponentMask && uint32(input)&MantissaMask != 0
+}
+
 // Convert the given [Bits] type to a [big.Float] arbitrary precision
 // floating-point number
 func (input Bits) ToBigFloat() big.Float {
@@ -63,73 +68,45 @@ func (input Bits) ToBigFloat() big.Float {
 // [Bits], a [big.Accuracy] which encodes whether the result value was the same,
 // larger () or smaller than the input, and a [floatBit.Status] which encodes,
 // whether the result fit in the [Bits], caused overflow or underflow.
+// The [floatBit.Tininess] decides when the result underflows.
+// [floatBit.RoundStochastic] needs the optional rb argument.
 func FromBigFloat(input big.Float, rm floatBit.RoundingMode,
-	om floatBit.OverflowMode, um floatBit.UnderflowMode) (Bits,
-	big.Accuracy, floatBit.Status) {
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+
+	// Converting to an intermediate float64 first would round the input
+	// twice, and lose the bits below float64 precision. The generic encoder
+	// extracts the mantissa and the sticky bit directly from the [big.Float]
+	// instead, so the result is correctly rounded at any input precision
+	resultBits, resultAcc, resultStatus := floatBit.Encode(input,
+		floatBit.FormatFloat32, rm, om, um, t, rb...)
+	return Bits(resultBits.Uint64()), resultAcc, resultStatus
+}
 
-	// We need to get the value with extra precision truncated
-	// But, Float64() returns the float64 value that is closest to the input.
-	// Therefore, to get the truncated result, we need to subtract 1 ULP of
-	// precision if the number is positive and the float64 is larger, or
-	// if the number is negative and the float64 is smaller
-	// Since the [big] package's methods do not support rounding modes for
-	// direct conversion to float32 with all the other metadata we want.
-	// We convert to an intermediate float64 number and use our custom
-	// conversion functions [FromFloat32] to convert to [Bits]
-	closestFloat64, fromBigFloatAcc := input.Float64()
-
-	var asFloat64 float64
-	// Float64() returns the float64 value that is closest to the input.
-	// This might cause it to round up for some cases.
-	// But, we need to get the value with extra precision truncated
-	// Therefore, to get the truncated result, we need to subtract 1 ULP of
-	// precision if the number is positive and the float64 is larger, or
-	// if the number is negative and the float64 is smaller, or alternatively
-	// if the .Float64() returns big.Above as the accuracy, because for
-	// truncation this should always be big.Below
-	// Note that however, we need to exempt, the case where the results
-	// becomes infinity.
-	if math.IsInf(closestFloat64, 1) && fromBigFloatAcc == big.Above {
-		// If input was greater than float64 Maximum Normal, then closestFloat64
-		// would be +inf, and the accuracy returned would be big.Above
-		// MaxFloat64 will trigger overflow repsonse in F32
-		asFloat64 = math.MaxFloat64
-	} else if math.IsInf(closestFloat64, -1) && fromBigFloatAcc == big.Below {
-		// Similarly,
-		// for -inf case, it will be big.Below.
-		// -MaxFloat64 will trigger overflow response in F32
-		asFloat64 = -math.MaxFloat64
-	} else if closestFloat64 == 0.0 && fromBigFloatAcc == big.Below {
-		// We also need to do this for the cases, where closestFloat64 is smaller
-		// than the minimum float64 subnormal, again, because we want to handle
-		// the underflow response in the F32 methods.
-		// SmallestNonzeroFloat64 will trigger underflow response in F32
-		asFloat64 = math.SmallestNonzeroFloat64
-	} else if closestFloat64 == -0.0 && fromBigFloatAcc == big.Above {
-		// And for the negative case
-		// F32.NegativeMinSubnormal will trigger underflow response in BF16
-		asFloat64 = -math.SmallestNonzeroFloat64
-	} else if (input.Sign() > 0 && fromBigFloatAcc == big.Above) ||
-		(input.Sign() < 0 && fromBigFloatAcc == big.Below) {
-		// For positive numbers if the accuracy was big.Above, then Float32()
-		// caused rounding away from zero. This is undesirable. To make it
-		// truncation we need to subtract 1 ULP from the number
-		closestFloat64Bits := math.Float64bits(closestFloat64)
-		asFloat64 = math.Float64frombits(closestFloat64Bits - 1)
-	} else {
-		asFloat64 = closestFloat64
-	}
+// Returns the [floatBit.NaN] the given [Bits] encode, and false if they
+// aren't a NaN. See [floatBit.Format.DecodeNaN]
+func (input Bits) DecodeNaN() (floatBit.NaN, bool) {
+	return floatBit.FormatFloat32.DecodeNaN(new(big.Int).SetUint64(uint64(input)))
+}
 
-	resultBits, resultAcc, resultStatus := FromFloat64(asFloat64, rm, om, um)
-	return resultBits, resultAcc, resultStatus
+// Convert the given [floatBit.NaN] to a [Bits] type representing the bits of
+// a float32 NaN, with the given [floatBit.NaNPolicy]. Returns the result
+// [Bits], a [floatBit.Status] and the [floatBit.Exceptions] raised by the
+// conversion. See [floatBit.Format.EncodeNaN]
+func FromNaN(input floatBit.NaN, p floatBit.NaNPolicy) (Bits, floatBit.Status,
+	floatBit.Exceptions) {
+	resultBits, resultStatus, resultExceptions := floatBit.FormatFloat32.EncodeNaN(input, p)
+	return Bits(resultBits.Uint64()), resultStatus, resultExceptions
 }
 
 // Convert the given [float64] number to a [Bits] type which represents the bits
 // of a [float32] number. Signature and usage is identical to [FromBigFloat],
-// except the parameter input for this function is [float64]
+// except the parameter input for this function is [float64]. NaNs are always
+// canonicalized to [NaN], which drops the sign and the payload, like
+// [floatBit.NaNCanonicalize]. Use [FromNaN] to choose the [floatBit.NaNPolicy]
 func FromFloat64(input float64, rm floatBit.RoundingMode,
-	om floatBit.OverflowMode, um floatBit.UnderflowMode) (Bits,
-	big.Accuracy, floatBit.Status) {
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
 
 	// There are some special cases we need to handle, particularly, the special
 	// values like Inf, NaN which have special encodings in the two formats
@@ -151,6 +128,16 @@ func FromFloat64(input float64, rm floatBit.RoundingMode,
 		return Bits(NaN), big.Exact, floatBit.Fits
 	}
 
+	// Stochastic rounding needs all of the discarded bits, not just the
+	// round and sticky bits, which [FromBigFloat] extracts. [FromBigFloat]
+	// also takes care of rounding the numbers below the minimum subnormal
+	// (see [floatBit.UnderflowMode.RoundsBelowMinSubnormal]), and of detecting
+	// overflow after rounding for [floatBit.OverflowIEEE]
+	if rm == floatBit.RoundStochastic || um.RoundsBelowMinSubnormal(t) ||
+		om == floatBit.OverflowIEEE {
+		return FromBigFloat(*big.NewFloat(input), rm, om, um, t, rb...)
+	}
+
 	// For the remaining cases, we need access to the underlying bits of the
 	// float64 number. As such, we need to
 	// first view the type as a uint64 format. This will allow us to
@@ -310,6 +297,12 @@ func FromFloat64(input float64, rm floatBit.RoundingMode,
 	case floatBit.RoundNearestOdd:
 		resultVal, resultAcc = roundNearestOdd(signBit, adjustedExponent,
 			alignedMantissa, lostPrecision)
+	case floatBit.RoundAwayFromZero:
+		resultVal, resultAcc = roundAwayFromZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfAwayFromZero:
+		resultVal, resultAcc = roundHalfAwayFromZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
 	}
 
 	return resultVal, resultAcc, floatBit.Fits
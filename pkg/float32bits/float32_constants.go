@@ -19,13 +19,13 @@ const (
 
 	// In float32 format, all numbers with the exponent bits = 11111111
 	// and, mantissa bits not all zero, constitute the special NaN value
-	// Though, there technically are two types, we lump them together
-	// into just a single NaN. And, whenver the result of an operation is a
-	// NaN, we encode it with the same sign as that of the result and,
-	// with the mantissa LSB=1, and rest of the mantissa bits=0
-	NaN         uint32 = 0x7f800001
-	PositiveNaN uint32 = 0x7f800001
-	NegativeNaN uint32 = 0xff800001
+	// The mantissa MSB is the quiet bit, which is 1 for quiet NaNs and 0 for
+	// signaling NaNs, and the rest of the mantissa bits are the payload.
+	// Whenever the result of an operation is a NaN, we encode it as a quiet
+	// NaN with the payload bits=0
+	NaN         uint32 = 0x7fc0_0000
+	PositiveNaN uint32 = 0x7fc0_0000
+	NegativeNaN uint32 = 0xffc0_0000
 
 	ExponentBias int = 127
 	ExponentMin  int = -126
@@ -1390,6 +1390,15 @@ func TestRoundNearestOdd(t *testing.T) {
 	}
 }
 
+// Parses the input with more precision than float32 and float64 have
+func parseBigFloat(input string) big.Float {
+	result, _, err := big.ParseFloat(input, 0, 200, big.ToNearestEven)
+	if err != nil {
+		panic(err)
+	}
+	return *result
+}
+
 func TestFromBigFloat(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -1613,10 +1622,50 @@ func TestFromBigFloat(t *testing.T) {
 			goldenAcc:    big.Above,
 			goldenStatus: floatBit.Underflow,
 		},
+		{
+			name:         "StickyBelowFloat64Precision",
+			input:        parseBigFloat("0x1.00000100000000000001p0"),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0x3f800001),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegativeStickyBelowFloat64Precision",
+			input:        parseBigFloat("-0x1.00000100000000000001p0"),
+			rm:           floatBit.RoundHalfTowardsZero,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0xbf800001),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "UnderflowBelowFloat64MinSubnormal",
+			input:        parseBigFloat("1e-400"),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMinSubnormal),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
+		{
+			name:         "SubnormalTieWithSticky",
+			input:        parseBigFloat("0x1.80000000000000000000001p-149"),
+			rm:           floatBit.RoundHalfTowardsZero,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0x00000002),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
 	}
 
 	for _, tt := range testCases {
-		resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um)
+		resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessBeforeRounding)
 		if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
 			t.Logf("Failed Input Set:\n")
 			t.Logf("Name: %s", tt.name)
@@ -0,0 +1,154 @@
+package F64
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+// Alias type for uint64. This is used to represent the bits which make up
+// a IEEE-754 binary64 number. This type also comes with additional utility
+// methods to support Floating point conversions with different Rounding
+// Modes and Out of Bounds Responses.
+//
+// The standard library can only convert a [big.Float] to a float64 with the
+// rounding modes supported by [big.RoundingMode]. The conversion in this
+// package works directly on the [big.Float] so that every
+// [floatBit.RoundingMode] is supported.
+type Bits uint64
+
+// Convert a float64 number to its constituent bits
+// This is effectively just a bit_cast from float64 to uint64
+func FromFloat64(input float64) Bits {
+	return Bits(math.Float64bits(input))
+}
+
+// Convert the given [Bits] type to the [float64] number it represents
+// This is effectively just a bit_cast from [uint64] to [float64]
+func (input Bits) ToFloat64() float64 {
+	return math.Float64frombits(uint64(input))
+}
+
+// Convert the given [Bits] type to a [big.Float] arbitrary precision
+// floating-point number
+func (input Bits) ToBigFloat() big.Float {
+	asBigFloat := *big.NewFloat(input.ToFloat64())
+	return asBigFloat
+}
+
+// Convert the given [big.Float] arbitrary precision floating-point number
+// to a [Bits] type representing the bits of a [float64] number. If the number
+// cannot be represented in [float64] format exactly, then the rounding mode,
+// overflow mode and underflow mode decide the result. Returns the result
+// [Bits], a [big.Accuracy] which encodes whether the result value was the same,
+// larger or smaller than the input, and a [floatBit.Status] which encodes,
+// whether the result fit in the [Bits], caused overflow or underflow.
+// The [floatBit.Tininess] decides when the result underflows.
+// [floatBit.RoundStochastic] needs the optional rb argument.
+func FromBigFloat(input big.Float, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+	resultBits, resultAcc, resultStatus := floatBit.Encode(input,
+		floatBit.FormatFloat64, rm, om, um, t, rb...)
+	return Bits(resultBits.Uint64()), resultAcc, resultStatus
+}
+
+// Returns the [floatBit.NaN] the given [Bits] encode, and false if they
+// aren't a NaN. See [floatBit.Format.DecodeNaN]
+func (input Bits) DecodeNaN() (floatBit.NaN, bool) {
+	return floatBit.FormatFloat64.DecodeNaN(new(big.Int).SetUint64(uint64(input)))
+}
+
+// Convert the given [floatBit.NaN] to a [Bits] type representing the bits of
+// a float64 NaN, with the given [floatBit.NaNPolicy]. Returns the result
+// [Bits], a [floatBit.Status] and the [floatBit.Exceptions] raised by the
+// conversion. See [floatBit.Format.EncodeNaN]
+func FromNaN(input floatBit.NaN, p floatBit.NaNPolicy) (Bits, floatBit.Status,
+	floatBit.Exceptions) {
+	resultBits, resultStatus, resultExceptions := floatBit.FormatFloat64.EncodeNaN(input, p)
+	return Bits(resultBits.Uint64()), resultStatus, resultExceptions
+}
+
+// ToFloatFormat converts the given Bits type representing the bits that make
+// up a float64 number into [floatBit.FloatBitFormat]
+// Implements the FloatBitFormatter Interface
+func (b *Bits) ToFloatFormat() floatBit.FloatBitFormat {
+	// Iterate over the bits and construct the return Values
+
+	asUint := uint64(*b)
+	signBits := (asUint & SignMask) >> 63
+	exponentBits := (asUint & ExponentMask) >> 52
+	mantissaBits := asUint & MantissaMask
+
+	// 1 Sign Bit
+	signRetVal := make([]byte, 0, 1)
+	if signBits == 0 {
+		signRetVal = append(signRetVal, byte('0'))
+	} else {
+		signRetVal = append(signRetVal, byte('1'))
+	}
+
+	// 11 Exponent Bits
+	exponentRetVal := make([]byte, 0, 11)
+	for i := 0; i < 11; i++ {
+		currentExponentBit := exponentBits & 0x1
+		var valueToAppend byte
+		if currentExponentBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		exponentRetVal = append(exponentRetVal, valueToAppend)
+		exponentBits >>= 1
+	}
+	slices.Reverse(exponentRetVal)
+
+	// 52 Mantissa Bits
+	mantissaRetVal := make([]byte, 0, 52)
+	for i := 0; i < 52; i++ {
+		currentMantissaBit := mantissaBits & 0x1
+		var valueToAppend byte
+		if currentMantissaBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		mantissaRetVal = append(mantissaRetVal, valueToAppend)
+		mantissaBits >>= 1
+	}
+	slices.Reverse(mantissaRetVal)
+
+	return floatBit.FloatBitFormat{Sign: signRetVal,
+		Exponent: exponentRetVal, Mantissa: mantissaRetVal}
+}
+
+// Conversion error returns the difference between the input [big.Float]
+// number and the float64 number represented by the bits in the [Bits] receiver
+func (b *Bits) ConversionError(input *big.Float) (big.Float, error) {
+	// If the receiver is a NaN then we return an error
+	asFloat64 := b.ToFloat64()
+	if math.IsNaN(asFloat64) {
+		return *big.NewFloat(0.0), errors.New("NaN encountered")
+	}
+
+	// Positive Infinity == Positive Infinity
+	if math.IsInf(asFloat64, 1) &&
+		(input.IsInf() && (input.Sign() > 0)) {
+		return *big.NewFloat(0), nil
+	}
+
+	// Negative Infinity == Negative Infinity
+	if math.IsInf(asFloat64, -1) &&
+		(input.IsInf() && (input.Sign() < 0)) {
+		return *big.NewFloat(0), nil
+	}
+
+	// The input usually has more precision than float64, so the difference
+	// uses the larger of the two precisions instead of the receiver's
+	asBigFloat := b.ToBigFloat()
+	convDiff := new(big.Float).Sub(&asBigFloat, input)
+	return *convDiff, nil
+}
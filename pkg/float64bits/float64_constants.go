@@ -0,0 +1,34 @@
+package F64
+
+const (
+	SignMask     uint64 = 0x8000_0000_0000_0000
+	ExponentMask uint64 = 0x7ff0_0000_0000_0000
+	MantissaMask uint64 = 0x000f_ffff_ffff_ffff
+
+	PositiveInfinity uint64 = 0x7ff0_0000_0000_0000
+	NegativeInfinity uint64 = 0xfff0_0000_0000_0000
+
+	PositiveZero uint64 = 0x0000_0000_0000_0000
+	NegativeZero uint64 = 0x8000_0000_0000_0000
+
+	PositiveMaxNormal uint64 = 0x7fef_ffff_ffff_ffff
+	NegativeMaxNormal uint64 = 0xffef_ffff_ffff_ffff
+
+	PositiveMinSubnormal uint64 = 0x0000_0000_0000_0001
+	NegativeMinSubnormal uint64 = 0x8000_0000_0000_0001
+
+	// In float64 format, all numbers with the exponent bits = 11111111111
+	// and, mantissa bits not all zero, constitute the special NaN value
+	// Like the float32 format, the mantissa MSB is the quiet bit, and we
+	// encode NaN results as quiet NaNs with the payload bits=0
+	NaN         uint64 = 0x7ff8_0000_0000_0000
+	PositiveNaN uint64 = 0x7ff8_0000_0000_0000
+	NegativeNaN uint64 = 0xfff8_0000_0000_0000
+
+	ExponentBias int = 1023
+	ExponentMin  int = -1022
+	ExponentMax  int = 1023
+
+	// Number of explicitly stored mantissa bits
+	MantissaBits int = 52
+)
@@ -0,0 +1,189 @@
+package F64
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+func TestToFloat64(t *testing.T) {
+	testCases := []struct {
+		input  Bits
+		golden float64
+	}{
+		{Bits(PositiveZero), 0.0},
+		{Bits(0x3ff0_0000_0000_0000), 1.0},
+		{Bits(0xc004_0000_0000_0000), -2.5},
+		{Bits(PositiveMaxNormal), math.MaxFloat64},
+		{Bits(PositiveMinSubnormal), math.SmallestNonzeroFloat64},
+		{Bits(NegativeInfinity), math.Inf(-1)},
+	}
+
+	for _, tt := range testCases {
+		if result := tt.input.ToFloat64(); result != tt.golden {
+			t.Errorf("Input: %0#16x, Expected: %v, Got: %v", uint64(tt.input), tt.golden, result)
+		}
+		if result := FromFloat64(tt.golden); result != tt.input {
+			t.Errorf("Input: %v, Expected: %0#16x, Got: %0#16x", tt.golden, uint64(tt.input), uint64(result))
+		}
+	}
+}
+
+// Test case shared by all the rounding function tests
+// Parse the given string into a [big.Float] with enough precision to hold all
+// the test inputs exactly
+func parseBigFloat(input string) big.Float {
+	result, _, err := big.ParseFloat(input, 0, 200, big.ToNearestEven)
+	if err != nil {
+		panic(err)
+	}
+	return *result
+}
+
+func TestFromBigFloat(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input big.Float
+		rm    floatBit.RoundingMode
+		um    floatBit.UnderflowMode
+		om    floatBit.OverflowMode
+		// Outputs
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{"PosInfInput", *big.NewFloat(math.Inf(1)), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(PositiveInfinity), big.Exact, floatBit.Fits},
+		{"NegZeroInput", *big.NewFloat(math.Copysign(0, -1)), floatBit.RoundTowardsPositiveInf,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(NegativeZero), big.Exact, floatBit.Fits},
+		{"OneExact", parseBigFloat("1"), floatBit.RoundNearestOdd,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0x3ff0_0000_0000_0000), big.Exact, floatBit.Fits},
+		// 0.1 in all the rounding modes
+		{"TenthRTZ", parseBigFloat("0.1"), floatBit.RoundTowardsZero,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0x3fb9_9999_9999_9999), big.Below, floatBit.Fits},
+		{"TenthRTPosInf", parseBigFloat("0.1"), floatBit.RoundTowardsPositiveInf,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0x3fb9_9999_9999_999a), big.Above, floatBit.Fits},
+		{"NegTenthRTPosInf", parseBigFloat("-0.1"), floatBit.RoundTowardsPositiveInf,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0xbfb9_9999_9999_9999), big.Above, floatBit.Fits},
+		{"NegTenthRTNegInf", parseBigFloat("-0.1"), floatBit.RoundTowardsNegativeInf,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0xbfb9_9999_9999_999a), big.Below, floatBit.Fits},
+		{"TenthRNE", parseBigFloat("0.1"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0x3fb9_9999_9999_999a), big.Above, floatBit.Fits},
+		{"TenthRNO", parseBigFloat("0.1"), floatBit.RoundNearestOdd,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0x3fb9_9999_9999_999a), big.Above, floatBit.Fits},
+		// 1 + 2^-53 is exactly halfway between 1 and the next float64
+		{"TieRNE", parseBigFloat("0x1.00000000000008p0"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0x3ff0_0000_0000_0000), big.Below, floatBit.Fits},
+		{"TieRNO", parseBigFloat("0x1.00000000000008p0"), floatBit.RoundNearestOdd,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0x3ff0_0000_0000_0001), big.Above, floatBit.Fits},
+		{"TieRoundHalfTowardsZero", parseBigFloat("-0x1.00000000000008p0"), floatBit.RoundHalfTowardsZero,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0xbff0_0000_0000_0000), big.Above, floatBit.Fits},
+		{"TieRoundHalfTowardsPositiveInf", parseBigFloat("0x1.00000000000008p0"),
+			floatBit.RoundHalfTowardsPositiveInf, floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits(0x3ff0_0000_0000_0001), big.Above, floatBit.Fits},
+		{"TieRoundHalfTowardsNegativeInf", parseBigFloat("-0x1.00000000000008p0"),
+			floatBit.RoundHalfTowardsNegativeInf, floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits(0xbff0_0000_0000_0001), big.Below, floatBit.Fits},
+		// Subnormals
+		{"SubnormalTieRNE", parseBigFloat("0x1.8p-1074"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(0x0000_0000_0000_0002), big.Above, floatBit.Fits},
+		{"SubnormalRTZ", parseBigFloat("-0x1.8p-1074"), floatBit.RoundTowardsZero,
+			floatBit.FlushToZero, floatBit.SaturateMax, Bits(0x8000_0000_0000_0001), big.Above, floatBit.Fits},
+		{"SubnormalToNormal", parseBigFloat("0x0.fffffffffffff8p-1022"), floatBit.RoundTowardsPositiveInf,
+			floatBit.FlushToZero, floatBit.SaturateMax, Bits(0x0010_0000_0000_0000), big.Above, floatBit.Fits},
+		{"MinSubnormalExact", parseBigFloat("0x1p-1074"), floatBit.RoundTowardsZero,
+			floatBit.FlushToZero, floatBit.SaturateMax, Bits(PositiveMinSubnormal), big.Exact, floatBit.Fits},
+		// Underflow
+		{"UnderflowFlushToZero", parseBigFloat("0x1.fffp-1075"), floatBit.RoundTowardsPositiveInf,
+			floatBit.FlushToZero, floatBit.SaturateMax, Bits(PositiveZero), big.Below, floatBit.Underflow},
+		{"UnderflowSaturateMin", parseBigFloat("-1e-400"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(NegativeMinSubnormal), big.Below, floatBit.Underflow},
+		// Gradual underflow rounds, but still underflows
+		{"RoundToSubnormalZero", parseBigFloat("-0x1p-1076"), floatBit.RoundNearestEven,
+			floatBit.RoundToSubnormal, floatBit.SaturateMax, Bits(NegativeZero), big.Above, floatBit.Underflow},
+		{"RoundToSubnormalMin", parseBigFloat("0x1p-1080"), floatBit.RoundAwayFromZero,
+			floatBit.RoundToSubnormal, floatBit.SaturateMax, Bits(PositiveMinSubnormal), big.Above,
+			floatBit.Underflow},
+		// Overflow
+		{"MaxNormalExact", parseBigFloat("0x1.fffffffffffffp1023"), floatBit.RoundTowardsPositiveInf,
+			floatBit.SaturateMin, floatBit.MakeNaN, Bits(PositiveMaxNormal), big.Exact, floatBit.Fits},
+		{"OverflowSaturateMax", parseBigFloat("0x1.fffffffffffff01p1023"), floatBit.RoundTowardsZero,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits(PositiveMaxNormal), big.Below, floatBit.Overflow},
+		{"OverflowSaturateInf", parseBigFloat("-1e400"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateInf, Bits(NegativeInfinity), big.Below, floatBit.Overflow},
+		{"OverflowMakeNaN", parseBigFloat("1e400"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.MakeNaN, Bits(PositiveNaN), big.Above, floatBit.Overflow},
+		// The rounding mode decides the response to overflow, after rounding
+		{"OverflowIEEERoundsDownToMax", parseBigFloat("0x1.fffffffffffff7p1023"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.OverflowIEEE, Bits(PositiveMaxNormal), big.Below, floatBit.Fits},
+		{"OverflowIEEERTZ", parseBigFloat("1e400"), floatBit.RoundTowardsZero,
+			floatBit.SaturateMin, floatBit.OverflowIEEE, Bits(PositiveMaxNormal), big.Below, floatBit.Overflow},
+		{"OverflowIEEERTNegInf", parseBigFloat("-1e400"), floatBit.RoundTowardsNegativeInf,
+			floatBit.SaturateMin, floatBit.OverflowIEEE, Bits(NegativeInfinity), big.Below, floatBit.Overflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessBeforeRounding)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("Name: %s Input: %v", tt.name, tt.input.Text('p', 0))
+				t.Errorf("Expected Result: %0#16x, Got: %0#16x\n", uint64(tt.goldenVal), uint64(resultVal))
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+// Tininess after rounding only underflows when the result would round to zero
+func TestFromBigFloatTininessAfterRounding(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input big.Float
+		rm    floatBit.RoundingMode
+		um    floatBit.UnderflowMode
+		om    floatBit.OverflowMode
+		// Outputs
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{"RoundsToMinSubnormalAfter", parseBigFloat("0x1.8p-1075"), floatBit.RoundNearestEven,
+			floatBit.FlushToZero, floatBit.SaturateMax, Bits(PositiveMinSubnormal),
+			big.Above, floatBit.Fits},
+		{"TieToZeroAfter", parseBigFloat("0x1p-1075"), floatBit.RoundNearestEven,
+			floatBit.FlushToZero, floatBit.SaturateMax, Bits(PositiveZero),
+			big.Below, floatBit.Underflow},
+		{"RoundsAwayAfter", parseBigFloat("-0x1p-1080"), floatBit.RoundTowardsNegativeInf,
+			floatBit.FlushToZero, floatBit.SaturateMax, Bits(NegativeMinSubnormal),
+			big.Below, floatBit.Fits},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessAfterRounding)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("Name: %s Input: %v", tt.name, tt.input.Text('p', 0))
+				t.Errorf("Expected Result: %0#16x, Got: %0#16x\n", uint64(tt.goldenVal), uint64(resultVal))
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+func TestToFloatFormat(t *testing.T) {
+	input := Bits(0xc00c_0000_0000_0001)
+	result := input.ToFloatFormat()
+	if string(result.Sign) != "1" || string(result.Exponent) != "10000000000" ||
+		string(result.Mantissa) != "1100000000000000000000000000000000000000000000000001" {
+		t.Errorf("Expected Sign: 1, Exponent: 10000000000, "+
+			"Mantissa: 1100000000000000000000000000000000000000000000000001. Got: %v", result)
+	}
+}
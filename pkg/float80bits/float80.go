@@ -0,0 +1,319 @@
+package F80
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+// Bits represents the bits which make up an Intel x87 80-bit extended
+// precision number. SignExponent holds the sign bit and the 15 exponent bits,
+// and Mantissa holds the 64 mantissa bits. Unlike the IEEE-754 formats, the
+// integer bit of the mantissa (bit 63) is stored explicitly, which allows
+// some encodings that the IEEE formats don't have. See [Class] for how they
+// are classified.
+//
+// There is no native Go type for this format, so all the conversions are done
+// directly on [big.Float]
+type Bits struct {
+	SignExponent uint16
+	Mantissa     uint64
+}
+
+// Class is the classification of the encoding of [Bits]. The x87 FPU only
+// produces the Zero, Denormal, Normal, Infinity and NaN classes. The others
+// are encodings that are left over from the 8087 and 80287, which the later
+// FPUs either reject as invalid operands or accept with a special meaning.
+type Class uint8
+
+// Class
+//
+// Zero: Exponent and mantissa bits are all 0
+//
+// Denormal: Exponent bits are 0, integer bit is 0 and the fraction is not 0
+//
+// PseudoDenormal: Exponent bits are 0 and the integer bit is 1. The value is
+// the same as the exponent bits being 1. The 80387 and later accept these as
+// operands, but never produce them
+//
+// Normal: Exponent bits are neither all 0 nor all 1, and the integer bit is 1
+//
+// Unnormal: Exponent bits are neither all 0 nor all 1, and the integer bit is
+// 0. The 80387 and later reject these as invalid operands
+//
+// Infinity: Exponent bits are all 1, integer bit is 1 and the fraction is 0
+//
+// PseudoInfinity: Exponent bits are all 1, and the integer bit and fraction
+// are 0. The 8087 and 80287 treat these as infinities, while the 80387 and
+// later reject them as invalid operands
+//
+// NaN: Exponent bits are all 1, integer bit is 1 and the fraction is not 0
+//
+// PseudoNaN: Exponent bits are all 1, integer bit is 0 and the fraction is
+// not 0. The 80387 and later reject these as invalid operands
+const (
+	ClassZero           Class = 0
+	ClassDenormal       Class = 1
+	ClassPseudoDenormal Class = 2
+	ClassNormal         Class = 3
+	ClassUnnormal       Class = 4
+	ClassInfinity       Class = 5
+	ClassPseudoInfinity Class = 6
+	ClassNaN            Class = 7
+	ClassPseudoNaN      Class = 8
+)
+
+// Stringer interface for Class
+func (c Class) String() string {
+	switch c {
+	case ClassZero:
+		return "zero"
+	case ClassDenormal:
+		return "denormal"
+	case ClassPseudoDenormal:
+		return "pseudo-denormal"
+	case ClassNormal:
+		return "normal"
+	case ClassUnnormal:
+		return "unnormal"
+	case ClassInfinity:
+		return "infinity"
+	case ClassPseudoInfinity:
+		return "pseudo-infinity"
+	case ClassNaN:
+		return "NaN"
+	case ClassPseudoNaN:
+		return "pseudo-NaN"
+	default:
+		return ""
+	}
+}
+
+// Returns the [Class] of the encoding of the given [Bits]
+func (input Bits) Classify() Class {
+	exponentBits := input.SignExponent & ExponentMask
+	integerBit := input.Mantissa & IntegerBitMask
+	fraction := input.Mantissa & FractionMask
+
+	switch exponentBits {
+	case 0:
+		if integerBit != 0 {
+			return ClassPseudoDenormal
+		}
+		if fraction != 0 {
+			return ClassDenormal
+		}
+		return ClassZero
+	case ExponentMask:
+		switch {
+		case integerBit == 0 && fraction == 0:
+			return ClassPseudoInfinity
+		case integerBit == 0:
+			return ClassPseudoNaN
+		case fraction == 0:
+			return ClassInfinity
+		default:
+			return ClassNaN
+		}
+	default:
+		if integerBit == 0 {
+			return ClassUnnormal
+		}
+		return ClassNormal
+	}
+}
+
+// Returns true if the given [Bits] represent a NaN or a pseudo-NaN
+func (input Bits) IsNaN() bool {
+	class := input.Classify()
+	return class == ClassNaN || class == ClassPseudoNaN
+}
+
+// Convert the given [Bits] type to a [big.Float] arbitrary precision
+// floating-point number. The result has 64 bits of precision, so the
+// conversion is always exact. Pseudo-denormals and unnormals are converted
+// to the value of their bits, and pseudo-infinities to infinities, which is
+// how the 8087 and 80287 interpret them. Panics if the receiver is a NaN or a
+// pseudo-NaN, since [big.Float] cannot represent NaNs
+func (input Bits) ToBigFloat() big.Float {
+	if input.IsNaN() {
+		panic("NaN cannot be represented as a big.Float")
+	}
+
+	var result big.Float
+	result.SetPrec(uint(MantissaBits))
+
+	signBit := input.SignExponent >> 15
+	exponentBits := int(input.SignExponent & ExponentMask)
+
+	switch exponentBits {
+	case int(ExponentMask):
+		result.SetInf(signBit != 0)
+		return result
+	case 0:
+		// Denormals and pseudo-denormals have the same exponent as the
+		// minimum normal
+		exponentBits = 1
+	}
+
+	// The value is the mantissa (as an integer) times 2 to the power of the
+	// exponent of its LSB. The integer bit is explicit, so there is nothing
+	// to add
+	result.SetUint64(input.Mantissa)
+	result.SetMantExp(&result, exponentBits-ExponentBias-(MantissaBits-1))
+	if signBit != 0 {
+		result.Neg(&result)
+	}
+	return result
+}
+
+// Convert the given [big.Float] arbitrary precision floating-point number
+// to a [Bits] type representing the bits of an x87 80-bit number. If the
+// number cannot be represented in the 80-bit format exactly, then the rounding
+// mode, overflow mode and underflow mode decide the result. Returns the result
+// [Bits], a [big.Accuracy] which encodes whether the result value was the same,
+// larger or smaller than the input, and a [floatBit.Status] which encodes,
+// whether the result fit in the [Bits], caused overflow or underflow. The
+// result is always one of the classes the x87 FPU produces.
+// The [floatBit.Tininess] decides when the result underflows.
+// [floatBit.RoundStochastic] needs the optional rb argument.
+func FromBigFloat(input big.Float, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+	resultBits, resultAcc, resultStatus := floatBit.Encode(input,
+		FormatWithoutIntegerBit, rm, om, um, t, rb...)
+	return withIntegerBit(resultBits), resultAcc, resultStatus
+}
+
+// The sign, exponent and fraction bits of the 80-bit format, without the
+// integer bit, have the same layout as an IEEE format with 63 mantissa bits,
+// and the same precision and range. The numbers it encodes are the ones the
+// x87 FPU produces, and so are its NaNs. See [withIntegerBit] for how its
+// encodings map to [Bits]
+var FormatWithoutIntegerBit = floatBit.Format{ExponentBits: 15, MantissaBits: 63,
+	Bias: ExponentBias, HasInfinity: true, NaN: floatBit.NaNIEEE, HasNegativeZero: true}
+
+// Returns the [floatBit.NaN] the given [Bits] encode, and false if they
+// aren't a NaN. Pseudo-NaNs are decoded just like NaNs. See
+// [floatBit.Format.DecodeNaN]
+func (input Bits) DecodeNaN() (floatBit.NaN, bool) {
+	if !input.IsNaN() {
+		return floatBit.NaN{}, false
+	}
+	asBigInt := new(big.Int).SetUint64(uint64(input.SignExponent))
+	asBigInt.Lsh(asBigInt, uint(MantissaBits-1))
+	asBigInt.Or(asBigInt, new(big.Int).SetUint64(input.Mantissa&FractionMask))
+	return FormatWithoutIntegerBit.DecodeNaN(asBigInt)
+}
+
+// Convert the given [floatBit.NaN] to a [Bits] type representing the bits of
+// an x87 80-bit NaN, with the given [floatBit.NaNPolicy]. The payload is made
+// up of the 62 fraction bits below the quiet bit, and the integer bit is
+// always set, so the result is never a pseudo-NaN. Returns the result [Bits],
+// a [floatBit.Status] and the [floatBit.Exceptions] raised by the conversion.
+// See [floatBit.Format.EncodeNaN]
+func FromNaN(input floatBit.NaN, p floatBit.NaNPolicy) (Bits, floatBit.Status,
+	floatBit.Exceptions) {
+	resultBits, resultStatus, resultExceptions := FormatWithoutIntegerBit.EncodeNaN(input, p)
+	return withIntegerBit(resultBits), resultStatus, resultExceptions
+}
+
+// Utility function that converts an encoding of [FormatWithoutIntegerBit]
+// to [Bits], by inserting the integer bit between the exponent and the
+// fraction. The integer bit is set for every encoding with non-zero exponent
+// bits, which makes normals, infinities and NaNs, and cleared for zeros and
+// denormals, so the result is always one of the classes the x87 FPU produces
+func withIntegerBit(input *big.Int) Bits {
+	signExponent := uint16(new(big.Int).Rsh(input, uint(MantissaBits-1)).Uint64())
+	mantissa := new(big.Int).And(input, new(big.Int).SetUint64(FractionMask)).Uint64()
+	if signExponent&ExponentMask != 0 {
+		mantissa |= IntegerBitMask
+	}
+	return Bits{SignExponent: signExponent, Mantissa: mantissa}
+}
+
+// ToFloatFormat converts the given Bits type representing the bits that make
+// up an x87 80-bit number into [floatBit.FloatBitFormat]. The mantissa
+// includes the explicit integer bit. Encodings that the x87 FPU doesn't
+// produce (pseudo-denormals, unnormals, pseudo-infinities and pseudo-NaNs)
+// are labeled with their [Class]
+// Implements the FloatBitFormatter Interface
+func (b *Bits) ToFloatFormat() floatBit.FloatBitFormat {
+	// Iterate over the bits and construct the return Values
+
+	signBits := (b.SignExponent & SignMask) >> 15
+	exponentBits := b.SignExponent & ExponentMask
+	mantissaBits := b.Mantissa
+
+	// 1 Sign Bit
+	signRetVal := make([]byte, 0, 1)
+	if signBits == 0 {
+		signRetVal = append(signRetVal, byte('0'))
+	} else {
+		signRetVal = append(signRetVal, byte('1'))
+	}
+
+	// 15 Exponent Bits
+	exponentRetVal := make([]byte, 0, 15)
+	for i := 0; i < 15; i++ {
+		currentExponentBit := exponentBits & 0x1
+		var valueToAppend byte
+		if currentExponentBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		exponentRetVal = append(exponentRetVal, valueToAppend)
+		exponentBits >>= 1
+	}
+	slices.Reverse(exponentRetVal)
+
+	// 64 Mantissa Bits
+	mantissaRetVal := make([]byte, 0, 64)
+	for i := 0; i < 64; i++ {
+		currentMantissaBit := mantissaBits & 0x1
+		var valueToAppend byte
+		if currentMantissaBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		mantissaRetVal = append(mantissaRetVal, valueToAppend)
+		mantissaBits >>= 1
+	}
+	slices.Reverse(mantissaRetVal)
+
+	var label string
+	switch class := b.Classify(); class {
+	case ClassPseudoDenormal, ClassUnnormal, ClassPseudoInfinity, ClassPseudoNaN:
+		label = class.String()
+	}
+
+	return floatBit.FloatBitFormat{Sign: signRetVal,
+		Exponent: exponentRetVal, Mantissa: mantissaRetVal, Label: label}
+}
+
+// Conversion error returns the difference between the input [big.Float]
+// number and the 80-bit number represented by the bits in the [Bits] receiver
+func (b *Bits) ConversionError(input *big.Float) (big.Float, error) {
+	// If the receiver is a NaN then we return an error
+	if b.IsNaN() {
+		return *big.NewFloat(0.0), errors.New("NaN encountered")
+	}
+
+	asBigFloat := b.ToBigFloat()
+
+	// Infinities are equal to infinities of the same sign
+	if asBigFloat.IsInf() && input.IsInf() &&
+		asBigFloat.Signbit() == input.Signbit() {
+		return *big.NewFloat(0), nil
+	}
+
+	// The input may have more precision than the 80-bit format, so the
+	// difference uses the larger of the two precisions instead of the
+	// receiver's
+	convDiff := new(big.Float).Sub(&asBigFloat, input)
+	return *convDiff, nil
+}
@@ -0,0 +1,43 @@
+package F80
+
+const (
+	// Masks for the upper 16 bits
+	SignMask     uint16 = 0x8000
+	ExponentMask uint16 = 0x7fff
+
+	// Masks for the lower 64 bits. Unlike the IEEE formats, the integer bit
+	// of the mantissa is stored explicitly
+	IntegerBitMask uint64 = 0x8000_0000_0000_0000
+	FractionMask   uint64 = 0x7fff_ffff_ffff_ffff
+
+	ExponentBias int = 16383
+	ExponentMin  int = -16382
+	ExponentMax  int = 16383
+
+	// Number of mantissa bits, including the explicit integer bit
+	MantissaBits int = 64
+)
+
+// The special values are variables, because Go doesn't have struct constants.
+// They must not be modified.
+var (
+	PositiveInfinity = Bits{SignExponent: 0x7fff, Mantissa: 0x8000_0000_0000_0000}
+	NegativeInfinity = Bits{SignExponent: 0xffff, Mantissa: 0x8000_0000_0000_0000}
+
+	PositiveZero = Bits{SignExponent: 0x0000, Mantissa: 0}
+	NegativeZero = Bits{SignExponent: 0x8000, Mantissa: 0}
+
+	PositiveMaxNormal = Bits{SignExponent: 0x7ffe, Mantissa: 0xffff_ffff_ffff_ffff}
+	NegativeMaxNormal = Bits{SignExponent: 0xfffe, Mantissa: 0xffff_ffff_ffff_ffff}
+
+	PositiveMinSubnormal = Bits{SignExponent: 0x0000, Mantissa: 1}
+	NegativeMinSubnormal = Bits{SignExponent: 0x8000, Mantissa: 1}
+
+	// Like the float32 format, the MSB of the fraction is the quiet bit, and
+	// we encode NaN results as quiet NaNs with the payload bits=0. This is the
+	// "real indefinite" the x87 FPU produces, but positive. The integer bit
+	// must be set, otherwise it is a pseudo-NaN
+	NaN         = Bits{SignExponent: 0x7fff, Mantissa: 0xc000_0000_0000_0000}
+	PositiveNaN = Bits{SignExponent: 0x7fff, Mantissa: 0xc000_0000_0000_0000}
+	NegativeNaN = Bits{SignExponent: 0xffff, Mantissa: 0xc000_0000_0000_0000}
+)
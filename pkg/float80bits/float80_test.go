@@ -0,0 +1,263 @@
+package F80
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+// Parse the given string into a [big.Float] with enough precision to hold all
+// the test inputs exactly
+func parseBigFloat(input string) big.Float {
+	result, _, err := big.ParseFloat(input, 0, 200, big.ToNearestEven)
+	if err != nil {
+		panic(err)
+	}
+	return *result
+}
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		input  Bits
+		golden Class
+	}{
+		{PositiveZero, ClassZero},
+		{NegativeMinSubnormal, ClassDenormal},
+		{Bits{SignExponent: 0x0000, Mantissa: 0x8000_0000_0000_0001}, ClassPseudoDenormal},
+		{Bits{SignExponent: 0x3fff, Mantissa: 0x8000_0000_0000_0000}, ClassNormal},
+		{Bits{SignExponent: 0xbfff, Mantissa: 0x4000_0000_0000_0000}, ClassUnnormal},
+		{NegativeInfinity, ClassInfinity},
+		{Bits{SignExponent: 0x7fff, Mantissa: 0}, ClassPseudoInfinity},
+		{PositiveNaN, ClassNaN},
+		{Bits{SignExponent: 0x7fff, Mantissa: 0x4000_0000_0000_0000}, ClassPseudoNaN},
+	}
+
+	for _, tt := range testCases {
+		if result := tt.input.Classify(); result != tt.golden {
+			t.Errorf("Input: %#x, Expected: %v, Got: %v", tt.input, tt.golden, result)
+		}
+	}
+}
+
+func TestNaN(t *testing.T) {
+	testCases := []struct {
+		input       Bits
+		golden      string
+		goldenQuiet Bits
+	}{
+		{PositiveNaN, "nan", PositiveNaN},
+		{Bits{SignExponent: 0xffff, Mantissa: 0x8000_0000_0000_002a}, "-snan(0x2a)",
+			Bits{SignExponent: 0xffff, Mantissa: 0xc000_0000_0000_002a}},
+		// Pseudo-NaNs are quieted to NaNs, with the integer bit set
+		{Bits{SignExponent: 0x7fff, Mantissa: 0x4000_0000_0000_0001}, "nan(0x1)",
+			Bits{SignExponent: 0x7fff, Mantissa: 0xc000_0000_0000_0001}},
+	}
+
+	for _, tt := range testCases {
+		asNaN, ok := tt.input.DecodeNaN()
+		if !ok || asNaN.String() != tt.golden {
+			t.Errorf("Input: %#x Expected: %s, Got: %s %v", tt.input, tt.golden, asNaN, ok)
+		}
+		if result, _, _ := FromNaN(asNaN, floatBit.NaNQuiet); result != tt.goldenQuiet {
+			t.Errorf("Input: %#x Expected: %#x, Got: %#x", tt.input, tt.goldenQuiet, result)
+		}
+	}
+	if _, ok := PositiveInfinity.DecodeNaN(); ok {
+		t.Errorf("Expected infinity not to be a NaN")
+	}
+}
+
+func TestToBigFloat(t *testing.T) {
+	testCases := []struct {
+		input  Bits
+		golden string
+	}{
+		{PositiveZero, "0x0p+00"},
+		{Bits{SignExponent: 0x3fff, Mantissa: 0x8000_0000_0000_0000}, "0x1p+00"},
+		// 1 + 2^-63 needs all 64 bits of precision
+		{Bits{SignExponent: 0xbfff, Mantissa: 0x8000_0000_0000_0001}, "-0x1.0000000000000002p+00"},
+		{PositiveMaxNormal, "0x1.fffffffffffffffep+16383"},
+		{PositiveMinSubnormal, "0x1p-16445"},
+		// The pseudo-denormal has the same value as the minimum normal
+		{Bits{SignExponent: 0x0000, Mantissa: 0x8000_0000_0000_0000}, "0x1p-16382"},
+		// Unnormals are the value of their bits
+		{Bits{SignExponent: 0x3fff, Mantissa: 0x4000_0000_0000_0000}, "0x1p-01"},
+		{Bits{SignExponent: 0xffff, Mantissa: 0}, "-Inf"},
+	}
+
+	for _, tt := range testCases {
+		result := tt.input.ToBigFloat()
+		if result.Text('x', -1) != tt.golden || result.Prec() != 64 {
+			t.Errorf("Input: %#x, Expected: %s, Got: %s (precision %d)",
+				tt.input, tt.golden, result.Text('x', -1), result.Prec())
+		}
+	}
+}
+
+func TestWithIntegerBit(t *testing.T) {
+	testCases := []struct {
+		// The sign and exponent bits, and the 63 fraction bits
+		signExponent uint16
+		fraction     uint64
+		golden       Bits
+	}{
+		{0x0000, 0, PositiveZero},
+		{0x8000, 0, NegativeZero},
+		// Largest denormal
+		{0x0000, 0x7fff_ffff_ffff_ffff, Bits{SignExponent: 0x0000, Mantissa: 0x7fff_ffff_ffff_ffff}},
+		{0x0001, 0, Bits{SignExponent: 0x0001, Mantissa: 0x8000_0000_0000_0000}},
+		{0xbfff, 0x7fff_ffff_ffff_ffff, Bits{SignExponent: 0xbfff, Mantissa: 0xffff_ffff_ffff_ffff}},
+		{0xffff, 0, NegativeInfinity},
+		{0x7fff, 0x4000_0000_0000_0000, NaN},
+	}
+
+	for _, tt := range testCases {
+		input := new(big.Int).Lsh(new(big.Int).SetUint64(uint64(tt.signExponent)), 63)
+		input.Or(input, new(big.Int).SetUint64(tt.fraction))
+		if result := withIntegerBit(input); result != tt.golden {
+			t.Errorf("Input: %#x, Expected: %#x, Got: %#x", input, tt.golden, result)
+		}
+	}
+}
+
+func TestFromBigFloat(t *testing.T) {
+	one := Bits{SignExponent: 0x3fff, Mantissa: 0x8000_0000_0000_0000}
+	oneLSB := Bits{SignExponent: 0x3fff, Mantissa: 0x8000_0000_0000_0001}
+
+	testCases := []struct {
+		name string
+		// Inputs
+		input big.Float
+		rm    floatBit.RoundingMode
+		um    floatBit.UnderflowMode
+		om    floatBit.OverflowMode
+		// Outputs
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{"PosInfInput", *big.NewFloat(math.Inf(1)), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, PositiveInfinity, big.Exact, floatBit.Fits},
+		{"NegZeroInput", *big.NewFloat(math.Copysign(0, -1)), floatBit.RoundTowardsPositiveInf,
+			floatBit.SaturateMin, floatBit.SaturateMax, NegativeZero, big.Exact, floatBit.Fits},
+		// 0.1 in different rounding modes
+		{"TenthRTZ", parseBigFloat("0.1"), floatBit.RoundTowardsZero, floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits{SignExponent: 0x3ffb, Mantissa: 0xcccc_cccc_cccc_cccc}, big.Below, floatBit.Fits},
+		{"TenthRNE", parseBigFloat("0.1"), floatBit.RoundNearestEven, floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits{SignExponent: 0x3ffb, Mantissa: 0xcccc_cccc_cccc_cccd}, big.Above, floatBit.Fits},
+		{"NegTenthRTNegInf", parseBigFloat("-0.1"), floatBit.RoundTowardsNegativeInf, floatBit.SaturateMin,
+			floatBit.SaturateMax, Bits{SignExponent: 0xbffb, Mantissa: 0xcccc_cccc_cccc_cccd}, big.Below, floatBit.Fits},
+		// 1 + 2^-64 is exactly halfway between 1 and the next 80-bit number
+		{"TieRNE", parseBigFloat("0x1.0000000000000001p0"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, one, big.Below, floatBit.Fits},
+		{"TieRNO", parseBigFloat("0x1.0000000000000001p0"), floatBit.RoundNearestOdd,
+			floatBit.SaturateMin, floatBit.SaturateMax, oneLSB, big.Above, floatBit.Fits},
+		{"TieRoundHalfTowardsPositiveInf", parseBigFloat("-0x1.0000000000000001p0"),
+			floatBit.RoundHalfTowardsPositiveInf, floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits{SignExponent: 0xbfff, Mantissa: one.Mantissa}, big.Above, floatBit.Fits},
+		{"TieRoundHalfAwayFromZero", parseBigFloat("-0x1.0000000000000001p0"),
+			floatBit.RoundHalfAwayFromZero, floatBit.SaturateMin, floatBit.SaturateMax,
+			Bits{SignExponent: 0xbfff, Mantissa: oneLSB.Mantissa}, big.Below, floatBit.Fits},
+		{"TenthRoundAwayFromZero", parseBigFloat("0.1"), floatBit.RoundAwayFromZero, floatBit.SaturateMin,
+			floatBit.SaturateMax, Bits{SignExponent: 0x3ffb, Mantissa: 0xcccc_cccc_cccc_cccd}, big.Above, floatBit.Fits},
+		// Denormals
+		{"DenormalTieRNE", parseBigFloat("0x1.8p-16445"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.SaturateMax, Bits{SignExponent: 0, Mantissa: 2}, big.Above, floatBit.Fits},
+		{"DenormalToNormal", parseBigFloat("0x1.ffffffffffffffffp-16383"), floatBit.RoundTowardsPositiveInf,
+			floatBit.FlushToZero, floatBit.SaturateMax,
+			Bits{SignExponent: 0x0001, Mantissa: 0x8000_0000_0000_0000}, big.Above, floatBit.Fits},
+		// Underflow
+		{"UnderflowFlushToZero", parseBigFloat("-0x1p-16446"), floatBit.RoundTowardsNegativeInf,
+			floatBit.FlushToZero, floatBit.SaturateMax, NegativeZero, big.Above, floatBit.Underflow},
+		{"UnderflowSaturateMin", parseBigFloat("0x1.fffp-16446"), floatBit.RoundTowardsZero,
+			floatBit.SaturateMin, floatBit.SaturateMax, PositiveMinSubnormal, big.Above, floatBit.Underflow},
+		// Overflow
+		{"MaxNormalExact", parseBigFloat("0x1.fffffffffffffffep16383"), floatBit.RoundTowardsPositiveInf,
+			floatBit.SaturateMin, floatBit.MakeNaN, PositiveMaxNormal, big.Exact, floatBit.Fits},
+		{"OverflowSaturateMax", parseBigFloat("0x1p16384"), floatBit.RoundTowardsZero,
+			floatBit.SaturateMin, floatBit.SaturateMax, PositiveMaxNormal, big.Below, floatBit.Overflow},
+		{"OverflowMakeNaN", parseBigFloat("-0x1p16384"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.MakeNaN, NegativeNaN, big.Below, floatBit.Overflow},
+		// The rounding mode decides the response to overflow, after rounding
+		{"OverflowIEEERoundsDownToMax", parseBigFloat("0x1.fffffffffffffffe8p16383"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.OverflowIEEE, PositiveMaxNormal, big.Below, floatBit.Fits},
+		{"OverflowIEEERoundsUpPastMax", parseBigFloat("0x1.ffffffffffffffffp16383"), floatBit.RoundNearestEven,
+			floatBit.SaturateMin, floatBit.OverflowIEEE, PositiveInfinity, big.Above, floatBit.Overflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessBeforeRounding)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("Name: %s Input: %v", tt.name, tt.input.Text('p', 0))
+				t.Errorf("Expected Result: %#x, Got: %#x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+// Tininess after rounding only underflows when the result would round to zero
+func TestFromBigFloatTininessAfterRounding(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input big.Float
+		rm    floatBit.RoundingMode
+		um    floatBit.UnderflowMode
+		om    floatBit.OverflowMode
+		// Outputs
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{"RoundsToMinSubnormalAfter", parseBigFloat("0x1.8p-16446"), floatBit.RoundNearestEven,
+			floatBit.FlushToZero, floatBit.SaturateMax, PositiveMinSubnormal,
+			big.Above, floatBit.Fits},
+		{"TieToZeroAfter", parseBigFloat("-0x1p-16446"), floatBit.RoundNearestEven,
+			floatBit.FlushToZero, floatBit.SaturateMax, NegativeZero,
+			big.Above, floatBit.Underflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessAfterRounding)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("Name: %s Input: %v", tt.name, tt.input.Text('p', 0))
+				t.Errorf("Expected Result: %#x, Got: %#x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+func TestToFloatFormat(t *testing.T) {
+	testCases := []struct {
+		input          Bits
+		goldenExponent string
+		goldenMantissa string
+		goldenLabel    string
+	}{
+		{Bits{SignExponent: 0xc000, Mantissa: 0xc000_0000_0000_0001}, "100000000000000",
+			"1100000000000000000000000000000000000000000000000000000000000001", ""},
+		{Bits{SignExponent: 0xc000, Mantissa: 0x4000_0000_0000_0000}, "100000000000000",
+			"0100000000000000000000000000000000000000000000000000000000000000", "unnormal"},
+		{Bits{SignExponent: 0xffff, Mantissa: 0}, "111111111111111",
+			"0000000000000000000000000000000000000000000000000000000000000000", "pseudo-infinity"},
+	}
+
+	for _, tt := range testCases {
+		result := tt.input.ToFloatFormat()
+		if string(result.Sign) != "1" || string(result.Exponent) != tt.goldenExponent ||
+			string(result.Mantissa) != tt.goldenMantissa || result.Label != tt.goldenLabel {
+			t.Errorf("Expected Sign: 1, Exponent: %s, Mantissa: %s, Label: %s. Got: %v",
+				tt.goldenExponent, tt.goldenMantissa, tt.goldenLabel, result)
+		}
+	}
+}
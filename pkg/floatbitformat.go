@@ -13,6 +13,9 @@ type FloatBitFormat struct {
 	Sign     []byte
 	Exponent []byte
 	Mantissa []byte
+	// Optional description of the encoding, for formats where the bits alone can be misleading
+	// (like the x87 unnormals). Empty for most encodings
+	Label string
 }
 
 // FloatBitFormatter interface supports conversion of the input type to a FloatFormat type.
@@ -22,7 +25,11 @@ type FloatBitFormatter interface {
 
 // Default Stringer interface for the FloatBitFormat type pointer, to print out as Sign: <>, Exponent: <>, Mantissa: <>
 func (f FloatBitFormat) String() string {
-	return fmt.Sprintf("Sign: %s, Exponent: %s, Mantissa: %s", string(f.Sign), string(f.Exponent), string(f.Mantissa))
+	str := fmt.Sprintf("Sign: %s, Exponent: %s, Mantissa: %s", string(f.Sign), string(f.Exponent), string(f.Mantissa))
+	if f.Label != "" {
+		str += fmt.Sprintf(", Label: %s", f.Label)
+	}
+	return str
 }
 
 // Return the FloatBitFormat as a string with the bits sequentially printed out as if it were a floating point
@@ -32,7 +39,8 @@ func (f FloatBitFormat) toBitStr() string {
 }
 
 // Returns a string with the FloatBitFormat formatted as a Table inside
-// The Table has Sign, Exponent, and Mantissa as the header row
+// The Table has Sign, Exponent, and Mantissa as the header row. If the FloatBitFormat has a Label, it
+// is printed on its own line after the table
 // Example:
 //
 //	Sign|  Exponent|    Mantissa|
@@ -52,5 +60,9 @@ func (f FloatBitFormat) AsTable() string {
 	fmt.Fprintf(writer, "\t%s\t%s\t%s\t\n", string(f.Sign), string(f.Exponent), string(f.Mantissa))
 	writer.Flush()
 
+	if f.Label != "" {
+		fmt.Fprintf(&sb, "Label: %s\n", f.Label)
+	}
+
 	return sb.String()
 }
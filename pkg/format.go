@@ -0,0 +1,339 @@
+package floatBit
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// NaNEncoding describes which encodings of a [Format] are NaNs
+type NaNEncoding uint8
+
+// NaNEncoding
+//
+// NaNIEEE: Like the IEEE-754 formats, the largest exponent is reserved for
+// infinities (mantissa bits all 0) and NaNs (mantissa bits not all 0). The
+// MSB of the mantissa is the quiet bit, which is 1 for quiet NaNs and 0 for
+// signaling NaNs, and the bits below it are the payload (see [NaN]). NaN
+// results are encoded as quiet NaNs with a zero payload
+//
+// NaNAllOnes: Like OCP FP8 E4M3, only the encodings with the exponent and
+// mantissa bits all 1 are NaNs (one per sign). The rest of the largest
+// exponent are normal numbers
+//
+// NaNNegativeZero: Like the FNUZ formats, the encoding of negative zero is
+// the only NaN. The format must not have a negative zero
+//
+// NoNaN: Like OCP FP6 and FP4, the format has no NaNs, and all the encodings
+// are numbers
+const (
+	NaNIEEE         NaNEncoding = 0
+	NaNAllOnes      NaNEncoding = 1
+	NaNNegativeZero NaNEncoding = 2
+	NoNaN           NaNEncoding = 3
+)
+
+// Stringer interface for NaNEncoding
+func (n NaNEncoding) String() string {
+	switch n {
+	case NaNIEEE:
+		return "ieee"
+	case NaNAllOnes:
+		return "allones"
+	case NaNNegativeZero:
+		return "negzero"
+	case NoNaN:
+		return "none"
+	default:
+		return ""
+	}
+}
+
+// Format describes a radix-2 floating-point format with a sign bit, an
+// exponent and a mantissa with an implicit integer bit, like the IEEE-754
+// formats. Numbers with the exponent bits all 0 are subnormals, and the
+// special values are decided by HasInfinity, NaN and HasNegativeZero. See
+// [Encode] for converting numbers to a Format
+type Format struct {
+	ExponentBits int
+	MantissaBits int
+	Bias         int
+	// Whether the format has infinities. Only supported with [NaNIEEE]
+	HasInfinity bool
+	NaN         NaNEncoding
+	// Whether the format has a negative zero. If not, negative zeros and
+	// negative numbers that are flushed to zero become positive zeros
+	HasNegativeZero bool
+}
+
+// Descriptors of some of the formats supported by the other packages
+var (
+	FormatFloat128 = Format{15, 112, 16383, true, NaNIEEE, true}
+	FormatFloat64  = Format{11, 52, 1023, true, NaNIEEE, true}
+	FormatFloat32  = Format{8, 23, 127, true, NaNIEEE, true}
+	FormatBFloat16 = Format{8, 7, 127, true, NaNIEEE, true}
+	FormatTF32     = Format{8, 10, 127, true, NaNIEEE, true}
+	FormatFloat16  = Format{5, 10, 15, true, NaNIEEE, true}
+	FormatE5M2     = Format{5, 2, 15, true, NaNIEEE, true}
+	FormatE4M3     = Format{4, 3, 7, false, NaNAllOnes, true}
+	FormatE5M2FNUZ = Format{5, 2, 16, false, NaNNegativeZero, false}
+	FormatE4M3FNUZ = Format{4, 3, 8, false, NaNNegativeZero, false}
+	FormatE3M2     = Format{3, 2, 3, false, NoNaN, true}
+	FormatE2M3     = Format{2, 3, 1, false, NoNaN, true}
+	FormatE2M1     = Format{2, 1, 1, false, NoNaN, true}
+)
+
+// Largest number of exponent bits supported by [Format]
+const maxExponentBits = 30
+
+// Stringer interface for Format
+func (f Format) String() string {
+	return fmt.Sprintf("E%dM%d (bias=%d, inf=%t, nan=%s, negzero=%t)", f.ExponentBits,
+		f.MantissaBits, f.Bias, f.HasInfinity, f.NaN, f.HasNegativeZero)
+}
+
+// Returns an error if the combination of parameters of the [Format] isn't
+// supported
+func (f Format) Validate() error {
+	if f.ExponentBits < 1 || f.ExponentBits > maxExponentBits {
+		return fmt.Errorf("exponent bits must be between 1 and %d", maxExponentBits)
+	}
+	if f.MantissaBits < 0 {
+		return errors.New("mantissa bits must not be negative")
+	}
+	if f.HasInfinity != (f.NaN == NaNIEEE) {
+		return errors.New("infinities are only supported with the ieee NaN encoding, and the ieee NaN encoding " +
+			"requires infinities")
+	}
+	if f.NaN == NaNIEEE && (f.ExponentBits < 2 || f.MantissaBits < 1) {
+		return errors.New("the ieee NaN encoding needs at least 2 exponent bits and 1 mantissa bit")
+	}
+	if f.NaN == NaNNegativeZero && f.HasNegativeZero {
+		return errors.New("the negzero NaN encoding can't be used with a negative zero")
+	}
+	if f.NaN > NoNaN {
+		return errors.New("unsupported NaN encoding")
+	}
+	return nil
+}
+
+// Returns the number of bits in the format
+func (f Format) Width() int {
+	return 1 + f.ExponentBits + f.MantissaBits
+}
+
+// Returns the encoding of the sign bit
+func (f Format) signMask() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(f.ExponentBits+f.MantissaBits))
+}
+
+// Returns the exponent bits and mantissa bits of the largest finite number
+func (f Format) maxFiniteFields() (uint64, *big.Int) {
+	maxExponent := uint64(1)<<f.ExponentBits - 1
+	allOnesMantissa := new(big.Int).Lsh(big.NewInt(1), uint(f.MantissaBits))
+	allOnesMantissa.Sub(allOnesMantissa, big.NewInt(1))
+
+	switch f.NaN {
+	case NaNIEEE:
+		// The largest exponent is reserved for infinities and NaNs
+		return maxExponent - 1, allOnesMantissa
+	case NaNAllOnes:
+		// Only the largest mantissa of the largest exponent is a NaN
+		if f.MantissaBits == 0 {
+			return maxExponent - 1, allOnesMantissa
+		}
+		return maxExponent, allOnesMantissa.Sub(allOnesMantissa, big.NewInt(1))
+	default:
+		return maxExponent, allOnesMantissa
+	}
+}
+
+// Returns the encoding of the largest finite number with the given sign
+func (f Format) maxFinite(signBit uint) *big.Int {
+	exponentBits, mantissaBits := f.maxFiniteFields()
+	result := new(big.Int).SetUint64(exponentBits)
+	result.Lsh(result, uint(f.MantissaBits))
+	result.Or(result, mantissaBits)
+	if signBit != 0 {
+		result.Or(result, f.signMask())
+	}
+	return result
+}
+
+// Returns the encoding of infinity with the given sign. Only valid if the
+// format has infinities
+func (f Format) infinity(signBit uint) *big.Int {
+	result := big.NewInt(1)
+	result.Lsh(result, uint(f.ExponentBits))
+	result.Sub(result, big.NewInt(1))
+	result.Lsh(result, uint(f.MantissaBits))
+	if signBit != 0 {
+		result.Or(result, f.signMask())
+	}
+	return result
+}
+
+// Returns the encoding of NaN with the given sign. This is the quiet NaN with a
+// zero payload for [NaNIEEE] formats. Formats with a single unsigned NaN
+// ignore the sign. Only valid if the format has NaNs
+func (f Format) nan(signBit uint) *big.Int {
+	switch f.NaN {
+	case NaNIEEE:
+		result := f.infinity(signBit)
+		return result.SetBit(result, f.quietBit(), 1)
+	case NaNAllOnes:
+		result := new(big.Int).Lsh(big.NewInt(1), uint(f.ExponentBits+f.MantissaBits))
+		result.Sub(result, big.NewInt(1))
+		if signBit != 0 {
+			result.Or(result, f.signMask())
+		}
+		return result
+	case NaNNegativeZero:
+		return f.signMask()
+	default:
+		panic("Format has no NaN")
+	}
+}
+
+// Returns the encoding of zero with the given sign. Formats without a negative
+// zero ignore the sign
+func (f Format) zero(signBit uint) *big.Int {
+	if signBit != 0 && f.HasNegativeZero {
+		return f.signMask()
+	}
+	return new(big.Int)
+}
+
+// Returns true if the given bits encode a NaN in the format
+func (f Format) IsNaN(bits *big.Int) bool {
+	signBit := bits.Bit(f.ExponentBits + f.MantissaBits)
+	magnitude := new(big.Int).AndNot(bits, f.signMask())
+	switch f.NaN {
+	case NaNIEEE:
+		return magnitude.Cmp(f.infinity(0)) > 0
+	case NaNAllOnes:
+		return magnitude.Cmp(f.nan(0)) == 0
+	case NaNNegativeZero:
+		return signBit != 0 && magnitude.Sign() == 0
+	default:
+		return false
+	}
+}
+
+// Class is the classification of an encoding of a [Format], like the class
+// operation of IEEE-754, except that the sign is not part of it. See
+// [NaN] for telling quiet and signaling NaNs apart
+type Class uint8
+
+// Class
+//
+// Zero: Exponent and mantissa bits are all 0
+//
+// Subnormal: Exponent bits are 0 and the mantissa bits are not all 0
+//
+// Normal: Any other encoding that is a finite number
+//
+// Infinity: Exponent bits are all 1 and the mantissa bits are all 0, in
+// formats with infinities
+//
+// NaN: Any of the encodings [Format.IsNaN] reports
+const (
+	ClassZero      Class = 0
+	ClassSubnormal Class = 1
+	ClassNormal    Class = 2
+	ClassInfinity  Class = 3
+	ClassNaN       Class = 4
+)
+
+// Stringer interface for Class
+func (c Class) String() string {
+	switch c {
+	case ClassZero:
+		return "zero"
+	case ClassSubnormal:
+		return "subnormal"
+	case ClassNormal:
+		return "normal"
+	case ClassInfinity:
+		return "infinity"
+	case ClassNaN:
+		return "NaN"
+	default:
+		return ""
+	}
+}
+
+// Returns the [Class] of the given bits of the format
+func (f Format) Classify(bits *big.Int) Class {
+	if f.IsNaN(bits) {
+		return ClassNaN
+	}
+	magnitude := new(big.Int).AndNot(bits, f.signMask())
+	switch {
+	case f.HasInfinity && magnitude.Cmp(f.infinity(0)) == 0:
+		return ClassInfinity
+	case magnitude.BitLen() > f.MantissaBits:
+		// The exponent bits are not all 0
+		return ClassNormal
+	case magnitude.Sign() != 0:
+		return ClassSubnormal
+	default:
+		return ClassZero
+	}
+}
+
+// Converts the given bits of the format to the [big.Float] number they
+// represent. The result has enough precision to be exact, and at least the
+// precision of a float64, like the ToBigFloat methods of the other packages.
+// Returns an error if the bits are a NaN, since [big.Float] cannot represent
+// NaNs
+func (f Format) Decode(bits *big.Int) (big.Float, error) {
+	var result big.Float
+	result.SetPrec(uint(max(f.MantissaBits+1, 53)))
+	if f.IsNaN(bits) {
+		return result, errors.New("NaN encountered")
+	}
+
+	signBit := bits.Bit(f.ExponentBits + f.MantissaBits)
+	exponentBits := new(big.Int).Rsh(bits, uint(f.MantissaBits))
+	exponentBits.SetBit(exponentBits, f.ExponentBits, 0)
+	mantissa := new(big.Int).Lsh(big.NewInt(1), uint(f.MantissaBits))
+	mantissa.Sub(mantissa, big.NewInt(1))
+	mantissa.And(mantissa, bits)
+
+	biasedExponent := int(exponentBits.Int64())
+	if f.HasInfinity && biasedExponent == 1<<f.ExponentBits-1 {
+		result.SetInf(signBit != 0)
+		return result, nil
+	}
+	if biasedExponent == 0 {
+		// Subnormals have the same exponent as the smallest normal, but
+		// no implicit 1
+		biasedExponent = 1
+	} else {
+		mantissa.SetBit(mantissa, f.MantissaBits, 1)
+	}
+
+	// The value is the mantissa (as an integer) times 2 to the power of the
+	// exponent of its LSB
+	result.SetInt(mantissa)
+	result.SetMantExp(&result, biasedExponent-f.Bias-f.MantissaBits)
+	if signBit != 0 {
+		result.Neg(&result)
+	}
+	return result, nil
+}
+
+// Converts the given bits of the format into [FloatBitFormat]
+func (f Format) ToFloatFormat(bits *big.Int) FloatBitFormat {
+	toBytes := func(start, width int) []byte {
+		retVal := make([]byte, 0, width)
+		for i := start + width - 1; i >= start; i-- {
+			retVal = append(retVal, byte('0'+bits.Bit(i)))
+		}
+		return retVal
+	}
+	return FloatBitFormat{Sign: toBytes(f.ExponentBits+f.MantissaBits, 1),
+		Exponent: toBytes(f.MantissaBits, f.ExponentBits),
+		Mantissa: toBytes(0, f.MantissaBits)}
+}
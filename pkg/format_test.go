@@ -0,0 +1,142 @@
+package floatBit_test
+
+import (
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		format    floatBit.Format
+		goldenErr bool
+	}{
+		{floatBit.FormatFloat64, false},
+		{floatBit.FormatE4M3, false},
+		{floatBit.FormatE4M3FNUZ, false},
+		{floatBit.FormatE2M1, false},
+		{floatBit.Format{3, 4, 3, true, floatBit.NaNIEEE, true}, false},
+		{floatBit.Format{1, 0, 0, false, floatBit.NoNaN, false}, false},
+		{floatBit.Format{0, 4, 3, true, floatBit.NaNIEEE, true}, true},
+		{floatBit.Format{31, 4, 3, true, floatBit.NaNIEEE, true}, true},
+		{floatBit.Format{3, -1, 3, false, floatBit.NoNaN, true}, true},
+		{floatBit.Format{3, 4, 3, false, floatBit.NaNIEEE, true}, true},
+		{floatBit.Format{3, 4, 3, true, floatBit.NaNAllOnes, true}, true},
+		{floatBit.Format{3, 0, 3, true, floatBit.NaNIEEE, true}, true},
+		{floatBit.Format{3, 4, 3, false, floatBit.NaNNegativeZero, true}, true},
+		{floatBit.Format{3, 4, 3, false, 4, true}, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			err := tt.format.Validate()
+			if (err != nil) != tt.goldenErr {
+				t.Errorf("Expected Error: %v, Got: %v\n", tt.goldenErr, err)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		format    floatBit.Format
+		bits      int64
+		golden    string
+		goldenErr bool
+	}{
+		{floatBit.FormatFloat16, 0x3c00, "1", false},
+		{floatBit.FormatFloat16, 0xc000, "-2", false},
+		{floatBit.FormatFloat16, 0x7bff, "65504", false},
+		{floatBit.FormatFloat16, 0x0001, "0x1p-24", false},
+		{floatBit.FormatFloat16, 0x8000, "-0", false},
+		{floatBit.FormatFloat16, 0x7c00, "+Inf", false},
+		{floatBit.FormatFloat16, 0xfc00, "-Inf", false},
+		{floatBit.FormatFloat16, 0x7c01, "", true},
+		{floatBit.FormatE4M3, 0x7e, "448", false},
+		{floatBit.FormatE4M3, 0x7f, "", true},
+		{floatBit.FormatE4M3, 0xff, "", true},
+		{floatBit.FormatE4M3FNUZ, 0x7f, "240", false},
+		{floatBit.FormatE4M3FNUZ, 0x80, "", true},
+		{floatBit.FormatE2M1, 0x7, "6", false},
+		{floatBit.FormatE2M1, 0xf, "-6", false},
+		{floatBit.FormatE2M1, 0x1, "0.5", false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			result, err := tt.format.Decode(big.NewInt(tt.bits))
+			if (err != nil) != tt.goldenErr {
+				t.Fatalf("Bits: %#x, Expected Error: %v, Got: %v\n", tt.bits, tt.goldenErr, err)
+			}
+			if tt.goldenErr {
+				return
+			}
+			golden, _, _ := big.ParseFloat(tt.golden, 0, 64, big.ToNearestEven)
+			if result.Cmp(golden) != 0 || result.Signbit() != golden.Signbit() {
+				t.Errorf("Bits: %#x, Expected: %v, Got: %v\n", tt.bits, golden, &result)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		format floatBit.Format
+		bits   int64
+		golden floatBit.Class
+	}{
+		{floatBit.FormatFloat16, 0x0000, floatBit.ClassZero},
+		{floatBit.FormatFloat16, 0x8000, floatBit.ClassZero},
+		{floatBit.FormatFloat16, 0x83ff, floatBit.ClassSubnormal},
+		{floatBit.FormatFloat16, 0x0400, floatBit.ClassNormal},
+		{floatBit.FormatFloat16, 0xfc00, floatBit.ClassInfinity},
+		{floatBit.FormatFloat16, 0x7c01, floatBit.ClassNaN},
+		// The largest exponent of E4M3 is mostly normal numbers
+		{floatBit.FormatE4M3, 0x7e, floatBit.ClassNormal},
+		{floatBit.FormatE4M3, 0xff, floatBit.ClassNaN},
+		{floatBit.FormatE4M3FNUZ, 0x80, floatBit.ClassNaN},
+		{floatBit.FormatE2M1, 0x1, floatBit.ClassSubnormal},
+		{floatBit.FormatE2M1, 0xf, floatBit.ClassNormal},
+	}
+
+	for _, tt := range testCases {
+		if result := tt.format.Classify(big.NewInt(tt.bits)); result != tt.golden {
+			t.Errorf("Format: %v Bits: %#x, Expected: %v, Got: %v", tt.format, tt.bits, tt.golden, result)
+		}
+	}
+}
+
+// Every encoding that isn't a NaN must decode to a number that encodes back to
+// the same bits exactly
+func TestDecodeEncodeRoundTrip(t *testing.T) {
+	formats := []floatBit.Format{floatBit.FormatFloat16, floatBit.FormatE5M2,
+		floatBit.FormatE4M3, floatBit.FormatE5M2FNUZ, floatBit.FormatE4M3FNUZ,
+		floatBit.FormatE3M2, floatBit.FormatE2M3, floatBit.FormatE2M1,
+		{3, 4, 3, true, floatBit.NaNIEEE, true},
+		{1, 0, 0, false, floatBit.NoNaN, false}}
+
+	for _, f := range formats {
+		t.Run(f.String(), func(t *testing.T) {
+			for bits := int64(0); bits < 1<<f.Width(); bits++ {
+				decoded, err := f.Decode(big.NewInt(bits))
+				if err != nil {
+					continue
+				}
+				resultVal, resultAcc, resultStatus := floatBit.Encode(decoded, f,
+					floatBit.RoundNearestEven, floatBit.SaturateInf, floatBit.FlushToZero,
+					floatBit.TininessBeforeRounding)
+				// Formats without a negative zero encode -0 as +0
+				golden := bits
+				if !f.HasNegativeZero && decoded.Sign() == 0 {
+					golden = 0
+				}
+				if resultVal.Int64() != golden || resultAcc != big.Exact ||
+					resultStatus != floatBit.Fits {
+					t.Errorf("Bits: %#x, Got: %#x %v %v\n", bits, resultVal,
+						resultAcc, resultStatus)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,452 @@
+package E2M1
+
+import (
+	"math"
+	"math/big"
+	"slices"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+)
+
+// Some constants that will help with the bit manipulation we'll need to
+// perform
+const (
+	// Mantissa bits retained in E2M1
+	f32E2M1MantissaMask uint32 = 0b0_00000000_10000000000000000000000
+	// Mantissa bits not retained in E2M1
+	f32E2M1HalfSubnormalMask uint32 = 0b0_00000000_01111111111111111111111
+	// LSB of E2M1 and rest of the extra precision
+	f32E2M1SubnormalMask uint32 = 0b0_00000000_11111111111111111111111
+	// LSB of E2M1
+	f32E2M1SubnormalLSB uint32 = 0b0_00000000_10000000000000000000000
+	// Most significant bit not retained in E2M1
+	f32E2M1HalfSubnormalLSB uint32 = 0b0_00000000_01000000000000000000000
+)
+
+// Alias type for uint8. This is used to represent the bits that make up an
+// OCP FP4 E2M1 number. Only the lowest 4 bits are meaningful, the rest must be
+// zero. This type also comes with utility methods to support Floating point
+// conversions with different Rounding Modes and Out of Bounds responses
+//
+// E2M1 has no encodings for infinities or NaNs, so every exponent value is
+// used for finite numbers. Since there is nothing to overflow to, overflow
+// always saturates to the maximum normal, and the [floatBit.MakeNaN] and
+// [floatBit.SaturateInf] modes report the [floatBit.NoEncoding] status
+type Bits uint8
+
+// Convert the given [Bits] type to the floating point number it represents,
+// inside a float32 value. Every E2M1 number is exactly representable as a
+// float32 normal number, so this method only needs to re-align the bits
+// per the float32 bit representation
+func (input Bits) ToFloat32() float32 {
+	asUint8 := uint8(input)
+	// Extract the Sign, Exponent and Mantissa
+	signBit := (asUint8 & SignMask) >> 3
+	exponentBits := (asUint8 & ExponentMask) >> 1
+	mantissaBits := asUint8 & MantissaMask
+
+	// E2M1 has no infinities or NaNs, the only special values are the zeros
+	if asUint8 == PositiveZero {
+		return math.Float32frombits(F32.PositiveZero)
+	}
+	if asUint8 == NegativeZero {
+		return math.Float32frombits(F32.NegativeZero)
+	}
+
+	// Variables to store the sign, exponent and mantissa bits that will
+	// be used to construct the float32 number
+	var float32SignBit, float32ExponentBits, float32MantissaBits uint32
+
+	float32SignBit = uint32(signBit) << 31
+
+	if exponentBits == 0 {
+		// Subnormals in E2M1 are normals in float32. Just like for float16,
+		// we find the first set bit in the mantissa, which becomes the
+		// implicit precision bit in the float32 value, and decrement the
+		// exponent once for every bit we move past.
+		// (-1)^sign * 2^(0) * (1/2)
+		// = (-1)^sign * 2^(-1) * (1)
+		currMantissaBitMask := uint8(0b0_00_1)
+		resultMantissaBits := mantissaBits
+		resultExponent := ExponentMin
+		extraShift := 0
+		for ; currMantissaBitMask != 0; currMantissaBitMask >>= 1 {
+			currMantissaBit := currMantissaBitMask & mantissaBits
+			resultExponent -= 1
+			extraShift++
+			if currMantissaBit != 0 {
+				// We need to zero out this one bit, since this is what
+				// becomes the implicit bit in the float32
+				resultMantissaBits = mantissaBits & ^currMantissaBitMask
+				break
+			}
+		}
+		// F32 has 23 mantissa bits, and E2M1 has 1. Therefore, to align the
+		// bits, we need to shift to the left by 22 bits, plus the extra shift
+		// for the bits we moved past above.
+		float32MantissaBits = uint32(resultMantissaBits) << (22 + extraShift)
+		float32ExponentBits = uint32(resultExponent+F32.ExponentBias) << 23
+	} else {
+		// For the normal case, all we need to do is correct the exponent to
+		// use the bias of the float32 format
+		float32MantissaBits = uint32(mantissaBits) << 22
+		actualExponent := int(exponentBits) - ExponentBias
+		float32ExponentBits = uint32(actualExponent+F32.ExponentBias) << 23
+	}
+	return math.Float32frombits(float32SignBit | float32ExponentBits |
+		float32MantissaBits)
+}
+
+// Convert the given [Bits] type to a [big.Float] arbitrary precision
+// floating-point number
+func (input Bits) ToBigFloat() big.Float {
+	asFloat32 := input.ToFloat32()
+	asBigFloat := *big.NewFloat(float64(asFloat32))
+	return asBigFloat
+}
+
+// Convert the given [big.Float] arbitrary precision floating-point number
+// to a [Bits] type representing the bits of an E2M1 number. If the number
+// cannot be represented in E2M1 format exactly, then the rounding mode,
+// overflow mode and underflow mode decide the result. Returns the result
+// [Bits], a [big.Accuracy] which encodes whether the result value was the same,
+// larger or smaller than the input, and a [floatBit.Status] which encodes,
+// whether the result fit in the [Bits], caused overflow or underflow.
+// The [floatBit.Tininess] decides when the result underflows.
+// [floatBit.RoundStochastic] needs the optional rb argument.
+func FromBigFloat(input big.Float, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+
+	// Round directly from the [big.Float], so that there is no double
+	// rounding through float32
+	resultBits, resultAcc, resultStatus := floatBit.Encode(input,
+		floatBit.FormatE2M1, rm, om, um, t, rb...)
+	return Bits(resultBits.Uint64()), resultAcc, resultStatus
+}
+
+// Returns the [floatBit.NaN] the given [Bits] encode, and false if they
+// aren't a NaN. See [floatBit.Format.DecodeNaN]
+func (input Bits) DecodeNaN() (floatBit.NaN, bool) {
+	return floatBit.FormatE2M1.DecodeNaN(new(big.Int).SetUint64(uint64(input)))
+}
+
+// Convert the given [floatBit.NaN] to a [Bits] type representing the bits of
+// an E2M1 NaN, with the given [floatBit.NaNPolicy]. Returns the result
+// [Bits], a [floatBit.Status] and the [floatBit.Exceptions] raised by the
+// conversion. E2M1 has no NaNs, so the result is always positive zero, with
+// the [floatBit.NoEncoding] status and [floatBit.ExceptionInvalid]. See
+// [floatBit.Format.EncodeNaN]
+func FromNaN(input floatBit.NaN, p floatBit.NaNPolicy) (Bits, floatBit.Status,
+	floatBit.Exceptions) {
+	resultBits, resultStatus, resultExceptions := floatBit.FormatE2M1.EncodeNaN(input, p)
+	return Bits(resultBits.Uint64()), resultStatus, resultExceptions
+}
+
+// Convert the given float32 number into a [Bits] type which represents the bits
+// of an E2M1 number. Signature and usage is identical to [FromBigFloat] except
+// the parameter input is float32
+func FromFloat32(input float32, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy,
+	floatBit.Status) {
+	// There are some special cases we need to handle, particularly those for
+	// values like Inf, NaN which have special encodings in the two formats
+
+	// Special Case #1: Infinities
+	// E2M1 has no encodings for infinities, so the result saturates to the
+	// maximum normal, and is reported with the [floatBit.NoEncoding] status
+	if math.IsInf(float64(input), 1) {
+		return handleInfinity(0, om.Resolve(rm, false))
+	}
+	if math.IsInf(float64(input), -1) {
+		return handleInfinity(1, om.Resolve(rm, true))
+	}
+
+	// Special Case #2: NaNs
+	// E2M1 has no NaNs either. There is no sensible value to return, so we
+	// return positive zero and flag that the result has no encoding.
+	if math.IsNaN(float64(input)) {
+		return Bits(PositiveZero), big.Exact, floatBit.NoEncoding
+	}
+
+	// Stochastic rounding needs all of the discarded bits, not just the
+	// round and sticky bits, which [FromBigFloat] extracts. [FromBigFloat]
+	// also takes care of rounding the numbers below the minimum subnormal
+	// (see [floatBit.UnderflowMode.RoundsBelowMinSubnormal]), and of detecting
+	// overflow after rounding for [floatBit.OverflowIEEE]
+	if rm == floatBit.RoundStochastic || um.RoundsBelowMinSubnormal(t) ||
+		om == floatBit.OverflowIEEE {
+		return FromBigFloat(*big.NewFloat(float64(input)), rm, om, um, t, rb...)
+	}
+
+	// For the remaining cases, we need access to the underlying bits of the
+	// float32 number.
+	asUint32 := math.Float32bits(input)
+
+	// Special Case #3: Zeros
+	if asUint32 == F32.PositiveZero {
+		return Bits(PositiveZero), big.Exact, floatBit.Fits
+	}
+	if asUint32 == F32.NegativeZero {
+		return Bits(NegativeZero), big.Exact, floatBit.Fits
+	}
+
+	// With the number interpreted as uint32, we can now extract the underlying
+	// sign, exponent and mantissa bits.
+	signBit := (asUint32 & F32.SignMask) >> 31
+	exponentBits := (asUint32 & F32.ExponentMask) >> 23
+	mantissaBits := asUint32 & F32.MantissaMask
+
+	// Special Case #4: Input is subnormal (exponent bits == 0 && mantissa != 0)
+	// float32 subnormals are far smaller than the smallest E2M1 subnormal, so
+	// they always underflow. For these cases the input um
+	// [floatBit.UnderflowMode] determines the result
+	if exponentBits == 0 {
+		return handleUnderflow(signBit, um)
+	}
+
+	// Special Case #5: Input exceeds the maximum normal value (in magnitude)
+	// that can be represented in the E2M1 format. In this case, the input om
+	// [floatBit.OverflowMode] determines the response.
+	actualExponent := int(exponentBits) - F32.ExponentBias
+
+	if checkOverflow(actualExponent, mantissaBits) {
+		return handleOverflow(signBit, om)
+	}
+
+	// Variables to store return values in
+	var resultVal Bits
+	var resultAcc big.Accuracy
+
+	// Just like for float16, we calculate the aligned mantissa and the
+	// adjusted exponent. For normal numbers these are the float32 mantissa
+	// and the exponent with the E2M1 bias applied. For subnormals the
+	// mantissa needs to be shifted and the exponent bits are 0
+	alignedMantissa := mantissaBits
+	adjustedExponent := uint32(actualExponent + ExponentBias)
+
+	// Value that indicates whether any precision was lost when preprocessing
+	// the mantissa before passing it down to the rounding routines
+	lostPrecision := false
+
+	if actualExponent < ExponentMin {
+		// The number can only be represented by an E2M1 subnormal, so we
+		// add the implicit 1 back into the mantissa, and shift right until
+		// the mantissa bits line up with the powers of two of the E2M1
+		// subnormals (2^0 * 0.m)
+		const (
+			float32ExponentLSB uint32 = 0b0_00000001_00000000000000000000000
+		)
+
+		alignedMantissa = mantissaBits | float32ExponentLSB
+		adjustedExponent = 0
+
+		shiftAmount := uint32(ExponentMin - actualExponent)
+		for ; shiftAmount > 0; shiftAmount-- {
+			lastDigit := alignedMantissa & 0x1
+			if lastDigit == 1 {
+				lostPrecision = true
+			}
+			alignedMantissa >>= 1
+		}
+
+		// If none of the mantissa bits that E2M1 can hold are set, but there
+		// was extra precision, the number is smaller than the minimum
+		// subnormal, and the input um [floatBit.UnderflowMode] determines
+		// the result
+		if checkUnderflow(alignedMantissa, lostPrecision) {
+			return handleUnderflow(signBit, um)
+		}
+	}
+
+	switch rm {
+	case floatBit.RoundTowardsZero:
+		resultVal, resultAcc = roundTowardsZero(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundTowardsNegativeInf:
+		resultVal, resultAcc = roundTowardsNegativeInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundTowardsPositiveInf:
+		resultVal, resultAcc = roundTowardsPositiveInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfTowardsZero:
+		resultVal, resultAcc = roundHalfTowardsZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfTowardsNegativeInf:
+		resultVal, resultAcc = roundHalfTowardsNegativeInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfTowardsPositiveInf:
+		resultVal, resultAcc = roundHalfTowardsPositiveInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundNearestEven:
+		resultVal, resultAcc = roundNearestEven(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundNearestOdd:
+		resultVal, resultAcc = roundNearestOdd(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundAwayFromZero:
+		resultVal, resultAcc = roundAwayFromZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfAwayFromZero:
+		resultVal, resultAcc = roundHalfAwayFromZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	}
+
+	return resultVal, resultAcc, floatBit.Fits
+}
+
+// Utility function to check if the number with the given exponent and mantissa
+// bits would overflow when trying to represent it in an E2M1 value.
+// mantissaBits should occupy the bits with the float32 format in mind.
+func checkOverflow(actualExponent int, mantissaBits uint32) bool {
+	// If the exponent is larger than the max, then it's overflow
+	if actualExponent > ExponentMax {
+		return true
+	}
+
+	// If the exponent is equal to the maximum exponent, all the
+	// E2M1 mantissa bits are set, but there is additional precision in the
+	// number than can be represented in E2M1, then it exceeds the maximum
+	// normal and overflows.
+	if (actualExponent == ExponentMax) &&
+		(mantissaBits&f32E2M1MantissaMask == f32E2M1MantissaMask) &&
+		(mantissaBits&f32E2M1HalfSubnormalMask > 0) {
+		return true
+	}
+	return false
+}
+
+// Utility function to check if the number would underflow when trying to
+// represent it in an E2M1 value. mantissaBits must already be aligned for the
+// subnormal exponent. The lostPrecision parameter indicates whether any set
+// bits were shifted out during that alignment
+func checkUnderflow(mantissaBits uint32, lostPrecision bool) bool {
+	// This assumes that the exponent is 0, so any extra precision in the
+	// mantissa means underflow.
+	e2m1PrecisionMantissa := mantissaBits & f32E2M1MantissaMask
+	e2m1ExtraPrecisionMantissa := mantissaBits & f32E2M1HalfSubnormalMask
+	if (e2m1PrecisionMantissa == 0) && (e2m1ExtraPrecisionMantissa != 0 || lostPrecision) {
+		return true
+	}
+	return false
+}
+
+// Utility function that returns the result for the case when
+// the converison results in underflow
+func handleUnderflow(signBit uint32, um floatBit.UnderflowMode) (Bits,
+	big.Accuracy, floatBit.Status) {
+	switch um {
+	case floatBit.FlushToZero:
+		if signBit == 0 {
+			// Zero is less than any positive subnormal
+			return Bits(PositiveZero), big.Below, floatBit.Underflow
+		}
+		return Bits(NegativeZero), big.Above, floatBit.Underflow
+	case floatBit.SaturateMin:
+		if signBit == 0 {
+			// Min subnormal of E2M1 is larger than any number this function
+			// will be invoked for
+			return Bits(PositiveMinSubnormal), big.Above, floatBit.Underflow
+		}
+		return Bits(NegativeMinSubnormal), big.Below, floatBit.Underflow
+	default:
+		panic("Unsupported UnderflowMode encountered")
+	}
+}
+
+// Utility function that returns the result for the case when the conversion
+// results in overflow. E2M1 has neither infinities nor NaNs, so the result
+// always saturates to the maximum normal. For the overflow modes that ask for
+// an infinity or a NaN, the status is [floatBit.NoEncoding]
+func handleOverflow(signBit uint32, om floatBit.OverflowMode) (Bits,
+	big.Accuracy, floatBit.Status) {
+	var status floatBit.Status
+	switch om {
+	case floatBit.SaturateInf, floatBit.MakeNaN:
+		status = floatBit.NoEncoding
+	case floatBit.SaturateMax:
+		status = floatBit.Overflow
+	default:
+		panic("Unsupported OverflowMode encountered")
+	}
+	if signBit == 0 {
+		// The maximum normal in E2M1 is smaller than any number
+		// this function will be invoked for
+		return Bits(PositiveMaxNormal), big.Below, status
+	}
+	return Bits(NegativeMaxNormal), big.Above, status
+}
+
+// Utility function that returns the result for the case when the input is an
+// infinity. Since E2M1 cannot encode infinities, the result saturates just
+// like for overflow, but the status is always [floatBit.NoEncoding]
+func handleInfinity(signBit uint32, om floatBit.OverflowMode) (Bits,
+	big.Accuracy, floatBit.Status) {
+	resultVal, resultAcc, _ := handleOverflow(signBit, om)
+	return resultVal, resultAcc, floatBit.NoEncoding
+}
+
+// ToFloatFormat converts the given Bits type representing the bits that make
+// up an E2M1 number into [floatBit.FloatBitFormat]
+// Implements the FloatBitFormatter Interface
+func (b *Bits) ToFloatFormat() floatBit.FloatBitFormat {
+	// Iterate over the bits and construct the return Values
+
+	asUint := uint8(*b)
+	signBits := (asUint & SignMask) >> 3
+	exponentBits := (asUint & ExponentMask) >> 1
+	mantissaBits := asUint & MantissaMask
+
+	// 1 Sign Bit
+	signRetVal := make([]byte, 0, 1)
+	if signBits == 0 {
+		signRetVal = append(signRetVal, byte('0'))
+	} else {
+		signRetVal = append(signRetVal, byte('1'))
+	}
+
+	// 2 Exponent Bits
+	exponentRetVal := make([]byte, 0, 2)
+	for i := 0; i < 2; i++ {
+		currentExponentBit := exponentBits & 0x1
+		var valueToAppend byte
+		if currentExponentBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		exponentRetVal = append(exponentRetVal, valueToAppend)
+		exponentBits >>= 1
+	}
+	slices.Reverse(exponentRetVal)
+
+	// 1 Mantissa Bits
+	mantissaRetVal := make([]byte, 0, 1)
+	for i := 0; i < 1; i++ {
+		currentMantissaBit := mantissaBits & 0x1
+		var valueToAppend byte
+		if currentMantissaBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		mantissaRetVal = append(mantissaRetVal, valueToAppend)
+		mantissaBits >>= 1
+	}
+	slices.Reverse(mantissaRetVal)
+
+	return floatBit.FloatBitFormat{Sign: signRetVal,
+		Exponent: exponentRetVal, Mantissa: mantissaRetVal}
+}
+
+// Conversion error returns the difference between the input [big.Float]
+// number and the E2M1 number represented by the bits in the [Bits] receiver
+func (b *Bits) ConversionError(input *big.Float) (big.Float, error) {
+	// E2M1 has no NaNs, so unlike the other formats, this never returns an
+	// error
+	asBigFloat := b.ToBigFloat()
+	convDiff := asBigFloat.Sub(&asBigFloat, input)
+	return *convDiff, nil
+}
@@ -0,0 +1,20 @@
+package E2M1
+
+const (
+	SignMask     uint8 = 0b1_00_0
+	ExponentMask uint8 = 0b0_11_0
+	MantissaMask uint8 = 0b0_00_1
+
+	PositiveMaxNormal uint8 = 0b0_11_1
+	NegativeMaxNormal uint8 = 0b1_11_1
+
+	PositiveZero uint8 = 0b0_00_0
+	NegativeZero uint8 = 0b1_00_0
+
+	PositiveMinSubnormal uint8 = 0b0_00_1
+	NegativeMinSubnormal uint8 = 0b1_00_1
+
+	ExponentBias int = 1
+	ExponentMin  int = 0
+	ExponentMax  int = 2
+)
@@ -0,0 +1,18 @@
+package E2M1
+
+import "math/big"
+
+// Utility function that returns the number rounded to the closest E2M1
+// value. Ties are broken by rounding away from zero.
+// The parameters are the same as for [roundHalfTowardsPositiveInf].
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundHalfAwayFromZero(signBit, exponentBits,
+	mantissaBits uint32, lostPrecision bool) (Bits, big.Accuracy) {
+	// Ties round up for positive numbers, and down for negative numbers
+	if signBit == 0 {
+		return roundHalfTowardsPositiveInf(signBit, exponentBits, mantissaBits,
+			lostPrecision)
+	}
+	return roundHalfTowardsNegativeInf(signBit, exponentBits, mantissaBits,
+		lostPrecision)
+}
@@ -0,0 +1,80 @@
+package E2M1
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number rounded to the closest E2M1
+// value. Ties are broken by rounding to the odd value (the LSB mantissa
+// bit is 1)
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E2M1 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+// The parameter lostPrecision indicates whether the mantissa passed had already
+// lost precision during any preprocessing
+func roundNearestOdd(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits,
+	big.Accuracy) {
+
+	// For rounding to nearest odd, we round to the number that is closest and
+	// break ties by rounding towards the number that is odd (LSB is 1)
+
+	// LSB  |  Extra Precision Bits
+	//  m22    m21 m20 m19
+	// 1. if m21 m20 m19 ... > 1 0 0 0 ... (more than half) we round up
+	// 2. if m21 m20 m19 ... < 1 0 0 0 ... (less than half) we truncate
+	// 3. if m21 m20 m19 ... == 1 0 0 0 ... (exactly half), then
+	// 	  3.1 m22 == 1, we truncate
+	//    3.2 m22 == 0, we round up
+
+	mantissaE2M1Precision := mantissaBits & f32E2M1MantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E2M1HalfSubnormalMask
+
+	e2m1Sign := uint8(signBit << 3)
+	e2m1Exponent := uint8(exponentBits << 1)
+	e2m1Mantissa := uint8(mantissaE2M1Precision >> 22)
+
+	exponentMantissaComposite := e2m1Exponent | e2m1Mantissa
+
+	addedOne := false
+	// We definitely add 1, if we're greater than the mid-point
+	if mantissaExtraPrecision > f32E2M1HalfSubnormalLSB {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// If extra precision was lost before, then we need to add one if we're
+	// halfway through in the adjusted mantissa (because this means we're
+	// actually greater than the midpoint)
+	if mantissaExtraPrecision == f32E2M1HalfSubnormalLSB && lostPrecision {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	mantissaE2M1LSB := mantissaBits & f32E2M1SubnormalLSB
+	// In the case we're at the mid-point, we only add 1, if the LSB of the
+	// E2M1 retained mantissa is 0
+	if (mantissaE2M1LSB == 0) && (mantissaExtraPrecision ==
+		f32E2M1HalfSubnormalLSB) && !lostPrecision {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// For all other case we truncate, so now we can construct the result
+	// by attaching the sign
+	resultVal := Bits(e2m1Sign | exponentMantissaComposite)
+	resultAcc := big.Exact
+
+	// Result is larger if the input was positive and we added 1, or
+	// if the input was negative and we truncated.
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		resultAcc = big.Below
+		if (e2m1Sign == 0) == addedOne {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,44 @@
+package E2M1
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number truncated to a number that can
+// be represented as a E2M1 number.
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E2M1 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundTowardsZero(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits, big.Accuracy) {
+	return truncate(signBit, exponentBits, mantissaBits, lostPrecision)
+}
+
+// truncation is the same as rounding towards zero
+func truncate(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits, big.Accuracy) {
+	mantissaE2M1Precision := mantissaBits & f32E2M1MantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E2M1HalfSubnormalMask
+
+	e2m1Sign := uint8(signBit << 3)
+	e2m1Exponent := uint8(exponentBits << 1)
+	// we need to move the mantissa bits to the right, so they align with the
+	// mantissa bits in the E2M1 format.
+	e2m1Mantissa := uint8(mantissaE2M1Precision >> 22)
+	resultVal := Bits(e2m1Sign | e2m1Exponent | e2m1Mantissa)
+	resultAcc := big.Exact
+
+	// If there was extra precision, then the number did not fit in the
+	// E2M1 format, so we need to report the status appropriately
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		if signBit == 0 {
+			resultAcc = big.Below
+		} else {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,20 @@
+package E2M3
+
+const (
+	SignMask     uint8 = 0b1_00_000
+	ExponentMask uint8 = 0b0_11_000
+	MantissaMask uint8 = 0b0_00_111
+
+	PositiveMaxNormal uint8 = 0b0_11_111
+	NegativeMaxNormal uint8 = 0b1_11_111
+
+	PositiveZero uint8 = 0b0_00_000
+	NegativeZero uint8 = 0b1_00_000
+
+	PositiveMinSubnormal uint8 = 0b0_00_001
+	NegativeMinSubnormal uint8 = 0b1_00_001
+
+	ExponentBias int = 1
+	ExponentMin  int = 0
+	ExponentMax  int = 2
+)
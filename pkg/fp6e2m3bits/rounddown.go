@@ -0,0 +1,51 @@
+package E2M3
+
+import "math/big"
+
+// Utility function that returns the number rounded to a number that is
+// representable in E2M3. If y is the input number and x < y < x + 1ULP
+// where x is a E2M3 number. Then this rounding mode picks up x
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E2M3 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+// The parameter lostPrecision indicates whether the mantissa passed had already
+// lost precision during any preprocessing
+func roundTowardsNegativeInf(signBit, exponentBits,
+	mantissaBits uint32, lostPrecision bool) (Bits, big.Accuracy) {
+	return roundDown(signBit, exponentBits, mantissaBits, lostPrecision)
+}
+
+func roundDown(signBit, exponentBits,
+	mantissaBits uint32, lostPrecision bool) (Bits, big.Accuracy) {
+
+	mantissaE2M3Precision := mantissaBits & f32E2M3MantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E2M3HalfSubnormalMask
+
+	e2m3Sign := uint8(signBit << 5)
+	e2m3Exponent := uint8(exponentBits << 3)
+	e2m3Mantissa := uint8(mantissaE2M3Precision >> 20)
+
+	// For this rounding mode, we only need to add 1 to the Least-precision
+	// mantissa, if the input was negative, to bring it closer to -inf.
+	// For positive numbers, this is achieved by simply truncating.
+
+	exponentMantissaComposite := (e2m3Exponent | e2m3Mantissa)
+
+	// If negative and there is extra precision, then add 1
+	if (e2m3Sign != 0) && (mantissaExtraPrecision != 0 || lostPrecision) {
+		exponentMantissaComposite += 1
+	}
+	// Since, we don't handle overflow, all we need to do now is attach the sign
+	resultVal := Bits(e2m3Sign | exponentMantissaComposite)
+
+	resultAcc := big.Exact
+	// If there was extra precision bits set, then we need to
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		// We always round to a smaller value
+		resultAcc = big.Below
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,20 @@
+package E3M2
+
+const (
+	SignMask     uint8 = 0b1_000_00
+	ExponentMask uint8 = 0b0_111_00
+	MantissaMask uint8 = 0b0_000_11
+
+	PositiveMaxNormal uint8 = 0b0_111_11
+	NegativeMaxNormal uint8 = 0b1_111_11
+
+	PositiveZero uint8 = 0b0_000_00
+	NegativeZero uint8 = 0b1_000_00
+
+	PositiveMinSubnormal uint8 = 0b0_000_01
+	NegativeMinSubnormal uint8 = 0b1_000_01
+
+	ExponentBias int = 3
+	ExponentMin  int = -2
+	ExponentMax  int = 4
+)
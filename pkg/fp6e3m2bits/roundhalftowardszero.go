@@ -0,0 +1,57 @@
+package E3M2
+
+import "math/big"
+
+// Utility function that returns the number rounded to the closest E3M2
+// value. Ties are broken by rounding to the value closest to zero (truncation)
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E3M2 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+// The parameter lostPrecision indicates whether the mantissa passed had already
+// lost precision during any preprocessing
+func roundHalfTowardsZero(signBit, exponentBits,
+	mantissaBits uint32, lostPrecision bool) (Bits, big.Accuracy) {
+
+	mantissaE3M2Precision := mantissaBits & f32E3M2MantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E3M2HalfSubnormalMask
+
+	e3m2Sign := uint8(signBit << 5)
+	e3m2Exponent := uint8(exponentBits << 2)
+	e3m2Mantissa := uint8(mantissaE3M2Precision >> 21)
+
+	exponentMantissaComposite := e3m2Exponent | e3m2Mantissa
+
+	// If the extra precision bits exceed 1 0 0 0 0....
+	// we need to add 1 to LSB of F32 mantissa, otherwise truncate
+	// For all other cases we truncate
+	addedOne := false
+	if mantissaExtraPrecision > f32E3M2HalfSubnormalLSB {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// If extra precision was lost before, then we need to add one if we're
+	// halfway through in the adjusted mantissa (because this means we're
+	// actually greater than the midpoint)
+	if mantissaExtraPrecision == f32E3M2HalfSubnormalLSB && lostPrecision {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// All we need to do now is attach the sign
+	resultVal := Bits(e3m2Sign | exponentMantissaComposite)
+	resultAcc := big.Exact
+
+	// Result is larger if the input was positive and we added 1, or
+	// if the input was negative and we truncated.
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		resultAcc = big.Below
+		if (e3m2Sign == 0) == addedOne {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,80 @@
+package E3M2
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number rounded to the closest E3M2
+// value. Ties are broken by rounding to the odd value (the LSB mantissa
+// bit is 1)
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E3M2 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+// The parameter lostPrecision indicates whether the mantissa passed had already
+// lost precision during any preprocessing
+func roundNearestOdd(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits,
+	big.Accuracy) {
+
+	// For rounding to nearest odd, we round to the number that is closest and
+	// break ties by rounding towards the number that is odd (LSB is 1)
+
+	// LSB  |  Extra Precision Bits
+	//  m21    m20 m19 m18
+	// 1. if m20 m19 m18 ... > 1 0 0 0 ... (more than half) we round up
+	// 2. if m20 m19 m18 ... < 1 0 0 0 ... (less than half) we truncate
+	// 3. if m20 m19 m18 ... == 1 0 0 0 ... (exactly half), then
+	// 	  3.1 m21 == 1, we truncate
+	//    3.2 m21 == 0, we round up
+
+	mantissaE3M2Precision := mantissaBits & f32E3M2MantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E3M2HalfSubnormalMask
+
+	e3m2Sign := uint8(signBit << 5)
+	e3m2Exponent := uint8(exponentBits << 2)
+	e3m2Mantissa := uint8(mantissaE3M2Precision >> 21)
+
+	exponentMantissaComposite := e3m2Exponent | e3m2Mantissa
+
+	addedOne := false
+	// We definitely add 1, if we're greater than the mid-point
+	if mantissaExtraPrecision > f32E3M2HalfSubnormalLSB {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// If extra precision was lost before, then we need to add one if we're
+	// halfway through in the adjusted mantissa (because this means we're
+	// actually greater than the midpoint)
+	if mantissaExtraPrecision == f32E3M2HalfSubnormalLSB && lostPrecision {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	mantissaE3M2LSB := mantissaBits & f32E3M2SubnormalLSB
+	// In the case we're at the mid-point, we only add 1, if the LSB of the
+	// E3M2 retained mantissa is 0
+	if (mantissaE3M2LSB == 0) && (mantissaExtraPrecision ==
+		f32E3M2HalfSubnormalLSB) && !lostPrecision {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// For all other case we truncate, so now we can construct the result
+	// by attaching the sign
+	resultVal := Bits(e3m2Sign | exponentMantissaComposite)
+	resultAcc := big.Exact
+
+	// Result is larger if the input was positive and we added 1, or
+	// if the input was negative and we truncated.
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		resultAcc = big.Below
+		if (e3m2Sign == 0) == addedOne {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,44 @@
+package E3M2
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number truncated to a number that can
+// be represented as a E3M2 number.
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E3M2 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundTowardsZero(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits, big.Accuracy) {
+	return truncate(signBit, exponentBits, mantissaBits, lostPrecision)
+}
+
+// truncation is the same as rounding towards zero
+func truncate(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits, big.Accuracy) {
+	mantissaE3M2Precision := mantissaBits & f32E3M2MantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E3M2HalfSubnormalMask
+
+	e3m2Sign := uint8(signBit << 5)
+	e3m2Exponent := uint8(exponentBits << 2)
+	// we need to move the mantissa bits to the right, so they align with the
+	// mantissa bits in the E3M2 format.
+	e3m2Mantissa := uint8(mantissaE3M2Precision >> 21)
+	resultVal := Bits(e3m2Sign | e3m2Exponent | e3m2Mantissa)
+	resultAcc := big.Exact
+
+	// If there was extra precision, then the number did not fit in the
+	// E3M2 format, so we need to report the status appropriately
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		if signBit == 0 {
+			resultAcc = big.Below
+		} else {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
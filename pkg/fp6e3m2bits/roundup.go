@@ -0,0 +1,51 @@
+package E3M2
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number rounded to a number that is
+// representable in E3M2. If y is the input number and x < y < x + 1ULP
+// where x is a E3M2 number. Then this rounding mode picks up x + 1ULP
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E3M2 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundTowardsPositiveInf(signBit, exponentBits,
+	mantissaBits uint32, lostPrecision bool) (Bits, big.Accuracy) {
+	return roundUp(signBit, exponentBits, mantissaBits, lostPrecision)
+}
+
+func roundUp(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits, big.Accuracy) {
+
+	mantissaE3M2Precision := mantissaBits & f32E3M2MantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E3M2HalfSubnormalMask
+
+	e3m2Sign := uint32(signBit << 5)
+	e3m2Exponent := uint32(exponentBits << 2)
+	e3m2Mantissa := uint32(mantissaE3M2Precision >> 21)
+
+	// For this rounding mode, we only need to add 1 to the Least-precision
+	// mantissa, if the input was positive, to bring it closer to +inf.
+	// For negative numbers, this is achieved by simply truncating.
+
+	exponentMantissaComposite := (e3m2Exponent | e3m2Mantissa)
+
+	// If positive and there is extra precision, then add 1
+	if (e3m2Sign == 0) && ((mantissaExtraPrecision != 0) || lostPrecision) {
+		exponentMantissaComposite += 1
+	}
+	// Since, we don't handle overflow, all we need to do now is attach the sign
+	resultVal := Bits(e3m2Sign | exponentMantissaComposite)
+
+	resultAcc := big.Exact
+	// If there was extra precision bits set, then we need to
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		// We always round to a larger value
+		resultAcc = big.Above
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,28 @@
+package E4M3
+
+const (
+	SignMask     uint8 = 0b1_0000_000
+	ExponentMask uint8 = 0b0_1111_000
+	MantissaMask uint8 = 0b0_0000_111
+
+	PositiveMaxNormal uint8 = 0b0_1111_110
+	NegativeMaxNormal uint8 = 0b1_1111_110
+
+	PositiveZero uint8 = 0b0_0000_000
+	NegativeZero uint8 = 0b1_0000_000
+
+	PositiveMinSubnormal uint8 = 0b0_0000_001
+	NegativeMinSubnormal uint8 = 0b1_0000_001
+
+	// The E4M3 (FN) format has no encodings for infinities. Out of the
+	// exponent bits = 1111 range, only the values with all the mantissa bits
+	// set are reserved for NaN, the rest are normal numbers. So, unlike the
+	// IEEE formats, there is exactly one NaN encoding per sign
+	NaN         uint8 = 0b0_1111_111
+	PositiveNaN uint8 = 0b0_1111_111
+	NegativeNaN uint8 = 0b1_1111_111
+
+	ExponentBias int = 7
+	ExponentMin  int = -6
+	ExponentMax  int = 8
+)
@@ -0,0 +1,552 @@
+package E4M3
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+)
+
+func TestToFloat32(t *testing.T) {
+	testCases := []struct {
+		// Input
+		input Bits
+		// Output
+		golden float32
+	}{
+		{
+			input:  0b0_0111_000,
+			golden: 1.0,
+		},
+		{
+			input:  0b1_0111_000,
+			golden: -1.0,
+		},
+		{
+			input:  0b0_1111_110,
+			golden: 448.0,
+		},
+		{
+			input:  0b1_1111_110,
+			golden: -448.0,
+		},
+		{
+			input:  0b0_0101_010,
+			golden: 0.3125,
+		},
+		{
+			input:  0,
+			golden: 0.0,
+		},
+		{
+			input:  0b1_0000_000,
+			golden: math.Float32frombits(F32.NegativeZero),
+		},
+		{
+			input:  0b0_0000_001,
+			golden: math.Float32frombits(0x3b000000),
+		},
+		{
+			input:  0b0_0000_111,
+			golden: math.Float32frombits(0x3c600000),
+		},
+		{
+			input:  0b1_0000_110,
+			golden: math.Float32frombits(0xbc400000),
+		},
+		{
+			input:  0b0_0001_000,
+			golden: math.Float32frombits(0x3c800000),
+		},
+	}
+	for _, tt := range testCases {
+		t.Run("ToFloat32", func(t *testing.T) {
+			result := tt.input.ToFloat32()
+			if math.Float32bits(result) != math.Float32bits(tt.golden) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("Input: %0#8b (%0#2x)", tt.input, tt.input)
+				t.Errorf("Expected Output: %f (%0#8x). Got: %f (%0#8x)", tt.golden, math.Float32bits(tt.golden), result, math.Float32bits(result))
+			}
+		})
+	}
+
+	// NaNs don't compare equal, so they're checked separately
+	for _, input := range []Bits{Bits(PositiveNaN), Bits(NegativeNaN)} {
+		if result := input.ToFloat32(); !math.IsNaN(float64(result)) {
+			t.Errorf("Expected NaN for %0#2x, Got: %f", input, result)
+		}
+	}
+}
+
+func TestHandleOverflow(t *testing.T) {
+	testCases := []struct {
+		// In
+		signBit uint32
+		om      floatBit.OverflowMode
+		// Out
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{0, floatBit.SaturateInf, Bits(PositiveNaN), big.Above, floatBit.NoEncoding},
+		{1, floatBit.SaturateInf, Bits(NegativeNaN), big.Below, floatBit.NoEncoding},
+		{0, floatBit.MakeNaN, Bits(PositiveNaN), big.Above, floatBit.Overflow},
+		{1, floatBit.MakeNaN, Bits(NegativeNaN), big.Below, floatBit.Overflow},
+		{0, floatBit.SaturateMax, Bits(PositiveMaxNormal), big.Below, floatBit.Overflow},
+		{1, floatBit.SaturateMax, Bits(NegativeMaxNormal), big.Above, floatBit.Overflow},
+		{200, floatBit.SaturateMax, Bits(NegativeMaxNormal), big.Above, floatBit.Overflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run("HandleOverflow", func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := handleOverflow(tt.signBit, tt.om)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("SignBit: %v\tOverflowMode: %v\n", tt.signBit, tt.om)
+				t.Errorf("Expected Result: %0#2x, Got: %0#2x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+func TestHandleUnderflow(t *testing.T) {
+	testCases := []struct {
+		// In
+		signBit uint32
+		um      floatBit.UnderflowMode
+		// Out
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{0, floatBit.FlushToZero, Bits(PositiveZero), big.Below, floatBit.Underflow},
+		{1, floatBit.FlushToZero, Bits(NegativeZero), big.Above, floatBit.Underflow},
+		{200, floatBit.FlushToZero, Bits(NegativeZero), big.Above, floatBit.Underflow},
+		{0, floatBit.SaturateMin, Bits(PositiveMinSubnormal), big.Above, floatBit.Underflow},
+		{1, floatBit.SaturateMin, Bits(NegativeMinSubnormal), big.Below, floatBit.Underflow},
+		{200, floatBit.SaturateMin, Bits(NegativeMinSubnormal), big.Below, floatBit.Underflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run("HandleUnderflow", func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := handleUnderflow(tt.signBit, tt.um)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("SignBit: %v\tUnderflowMode: %v\n", tt.signBit, tt.um)
+				t.Errorf("Expected Result: %0#2x, Got: %0#2x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+func TestCheckOverflow(t *testing.T) {
+	testCases := []struct {
+		// Inputs
+		actualExponent int
+		mantissaBits   uint32
+		// Outputs
+		golden bool
+	}{
+		{
+			actualExponent: 7,
+			mantissaBits:   0b0_00000000_111_00000000000000000001,
+			golden:         false,
+		},
+		{
+			actualExponent: 9,
+			mantissaBits:   0b0_00000000_000_00000000000000000000,
+			golden:         true,
+		},
+		{
+			actualExponent: 8,
+			mantissaBits:   0b0_00000000_110_00000000000000000000,
+			golden:         false,
+		},
+		{
+			actualExponent: 8,
+			mantissaBits:   0b0_00000000_110_00000000000000000001,
+			golden:         true,
+		},
+		{
+			actualExponent: 8,
+			mantissaBits:   0b0_00000000_111_00000000000000000000,
+			golden:         true,
+		},
+		{
+			actualExponent: 8,
+			mantissaBits:   0b0_00000000_101_11111111111111111111,
+			golden:         false,
+		},
+	}
+
+	for _, tt := range testCases {
+		result := checkOverflow(tt.actualExponent, tt.mantissaBits)
+		if result != tt.golden {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Exponent: %d Mantissa Bits: %0#8x", tt.actualExponent, tt.mantissaBits)
+			t.Errorf("Expected: %v, Got: %v", tt.golden, result)
+		}
+	}
+}
+
+func TestCheckUnderflow(t *testing.T) {
+	testCases := []struct {
+		// Inputs
+		mantissaBits  uint32
+		lostPrecision bool
+		// Outputs
+		golden bool
+	}{
+		{
+			mantissaBits:  0b0_00000000_000_10000000000000000001,
+			lostPrecision: false,
+			golden:        true,
+		},
+		{
+			mantissaBits:  0b0_00000000_001_00000000000000000001,
+			lostPrecision: false,
+			golden:        false,
+		},
+		{
+			mantissaBits:  0b0_00000000_000_00000000000000000000,
+			lostPrecision: false,
+			golden:        false,
+		},
+		{
+			mantissaBits:  0b0_00000000_000_00000000000000000000,
+			lostPrecision: true,
+			golden:        true,
+		},
+		{
+			mantissaBits:  0b0_00000000_011_00000000000000000000,
+			lostPrecision: true,
+			golden:        false,
+		},
+	}
+
+	for _, tt := range testCases {
+		result := checkUnderflow(tt.mantissaBits, tt.lostPrecision)
+		if result != tt.golden {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Mantissa Bits: %0#8x", tt.mantissaBits)
+			t.Logf("Lost Precision?: %v", tt.lostPrecision)
+			t.Errorf("Expected: %v, Got: %v", tt.golden, result)
+		}
+	}
+}
+
+// Test case shared by all the rounding function tests
+type roundTestCase struct {
+	// Inputs
+	signBit       uint32
+	exponentBits  uint32
+	mantissaBits  uint32
+	lostPrecision bool
+	// Outputs
+	goldenVal Bits
+	goldenAcc big.Accuracy
+}
+
+func runRoundTests(t *testing.T, name string,
+	roundFunc func(uint32, uint32, uint32, bool) (Bits, big.Accuracy),
+	testCases []roundTestCase) {
+	for _, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resultVal, resultAcc := roundFunc(tt.signBit, tt.exponentBits, tt.mantissaBits, tt.lostPrecision)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("signBit: %#08x, exponentBits: %#08x, mantissaBits: %#08x", tt.signBit, tt.exponentBits, tt.mantissaBits)
+				t.Logf("lostPrecision: %v", tt.lostPrecision)
+				t.Errorf("Expected Result: %0#2x, Got: %0#2x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+			}
+		})
+	}
+}
+
+func TestRoundTowardsZero(t *testing.T) {
+	runRoundTests(t, "RoundTowardsZero", roundTowardsZero, []roundTestCase{
+		// +Zero -> +Zero
+		{0, 0, 0, false, Bits(0x0), big.Exact},
+		// Exact
+		{0, 7, 0b0_00000000_001_00000000000000000000, false, Bits(0b0_0111_001), big.Exact},
+		// Positive RTZ to below
+		{0, 7, 0b0_00000000_001_11000000000000000000, false, Bits(0b0_0111_001), big.Below},
+		// Negative RTZ to above
+		{1, 1, 0b0_00000000_101_10000000000000000001, false, Bits(0b1_0001_101), big.Above},
+		// Lost Precision before got passed into func
+		{0, 0, 0b0_00000000_011_00000000000000000000, true, Bits(0b0_0000_011), big.Below},
+	})
+}
+
+func TestRoundTowardsPositiveInf(t *testing.T) {
+	runRoundTests(t, "RoundTowardsPositiveInf", roundTowardsPositiveInf, []roundTestCase{
+		{0, 7, 0b0_00000000_001_00000000000000000000, false, Bits(0b0_0111_001), big.Exact},
+		// Positive rounds up
+		{0, 7, 0b0_00000000_001_00000000000000000001, false, Bits(0b0_0111_010), big.Above},
+		// Negative truncates
+		{1, 7, 0b0_00000000_001_11111111111111111111, false, Bits(0b1_0111_001), big.Above},
+		// Carry into the exponent
+		{0, 7, 0b0_00000000_111_00000000000000000000, true, Bits(0b0_1000_000), big.Above},
+	})
+}
+
+func TestRoundTowardsNegativeInf(t *testing.T) {
+	runRoundTests(t, "RoundTowardsNegativeInf", roundTowardsNegativeInf, []roundTestCase{
+		{1, 7, 0b0_00000000_001_00000000000000000000, false, Bits(0b1_0111_001), big.Exact},
+		// Positive truncates
+		{0, 7, 0b0_00000000_001_11111111111111111111, false, Bits(0b0_0111_001), big.Below},
+		// Negative rounds up in magnitude
+		{1, 7, 0b0_00000000_001_00000000000000000001, false, Bits(0b1_0111_010), big.Below},
+		// Subnormal rounding up to the smallest normal
+		{1, 0, 0b0_00000000_111_00000000000000000000, true, Bits(0b1_0001_000), big.Below},
+	})
+}
+
+func TestRoundHalfTowardsZero(t *testing.T) {
+	runRoundTests(t, "RoundHalfTowardsZero", roundHalfTowardsZero, []roundTestCase{
+		// Below half truncates
+		{0, 7, 0b0_00000000_001_01111111111111111111, false, Bits(0b0_0111_001), big.Below},
+		// Above half rounds up
+		{0, 7, 0b0_00000000_001_10000000000000000001, false, Bits(0b0_0111_010), big.Above},
+		// Ties truncate
+		{0, 7, 0b0_00000000_001_10000000000000000000, false, Bits(0b0_0111_001), big.Below},
+		{1, 7, 0b0_00000000_001_10000000000000000000, false, Bits(0b1_0111_001), big.Above},
+		// Not a tie, if precision was lost before
+		{1, 0, 0b0_00000000_001_10000000000000000000, true, Bits(0b1_0000_010), big.Below},
+	})
+}
+
+func TestRoundHalfTowardsPositiveInf(t *testing.T) {
+	runRoundTests(t, "RoundHalfTowardsPositiveInf", roundHalfTowardsPositiveInf, []roundTestCase{
+		{0, 7, 0b0_00000000_001_01111111111111111111, false, Bits(0b0_0111_001), big.Below},
+		{1, 7, 0b0_00000000_001_10000000000000000001, false, Bits(0b1_0111_010), big.Below},
+		// Ties round towards +inf
+		{0, 7, 0b0_00000000_001_10000000000000000000, false, Bits(0b0_0111_010), big.Above},
+		{1, 7, 0b0_00000000_001_10000000000000000000, false, Bits(0b1_0111_001), big.Above},
+	})
+}
+
+func TestRoundHalfTowardsNegativeInf(t *testing.T) {
+	runRoundTests(t, "RoundHalfTowardsNegativeInf", roundHalfTowardsNegativeInf, []roundTestCase{
+		{0, 7, 0b0_00000000_001_01111111111111111111, false, Bits(0b0_0111_001), big.Below},
+		{0, 7, 0b0_00000000_001_10000000000000000001, false, Bits(0b0_0111_010), big.Above},
+		// Ties round towards -inf
+		{0, 7, 0b0_00000000_001_10000000000000000000, false, Bits(0b0_0111_001), big.Below},
+		{1, 7, 0b0_00000000_001_10000000000000000000, false, Bits(0b1_0111_010), big.Below},
+	})
+}
+
+func TestRoundNearestEven(t *testing.T) {
+	runRoundTests(t, "RoundNearestEven", roundNearestEven, []roundTestCase{
+		{0, 7, 0b0_00000000_001_01111111111111111111, false, Bits(0b0_0111_001), big.Below},
+		{1, 7, 0b0_00000000_000_10000000000000000001, false, Bits(0b1_0111_001), big.Below},
+		// Ties round to the even value
+		{0, 7, 0b0_00000000_001_10000000000000000000, false, Bits(0b0_0111_010), big.Above},
+		{0, 7, 0b0_00000000_000_10000000000000000000, false, Bits(0b0_0111_000), big.Below},
+		{1, 7, 0b0_00000000_010_10000000000000000000, false, Bits(0b1_0111_010), big.Above},
+		// Not a tie, if precision was lost before
+		{0, 7, 0b0_00000000_000_10000000000000000000, true, Bits(0b0_0111_001), big.Above},
+		// Carry into the exponent
+		{0, 7, 0b0_00000000_111_10000000000000000000, false, Bits(0b0_1000_000), big.Above},
+	})
+}
+
+func TestRoundNearestOdd(t *testing.T) {
+	runRoundTests(t, "RoundNearestOdd", roundNearestOdd, []roundTestCase{
+		{0, 7, 0b0_00000000_001_01111111111111111111, false, Bits(0b0_0111_001), big.Below},
+		{1, 7, 0b0_00000000_000_10000000000000000001, false, Bits(0b1_0111_001), big.Below},
+		// Ties round to the odd value
+		{0, 7, 0b0_00000000_001_10000000000000000000, false, Bits(0b0_0111_001), big.Below},
+		{0, 7, 0b0_00000000_000_10000000000000000000, false, Bits(0b0_0111_001), big.Above},
+		{1, 7, 0b0_00000000_010_10000000000000000000, false, Bits(0b1_0111_011), big.Below},
+		// Not a tie, if precision was lost before
+		{0, 7, 0b0_00000000_001_10000000000000000000, true, Bits(0b0_0111_010), big.Above},
+	})
+}
+
+func TestFromBigFloat(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input big.Float
+		rm    floatBit.RoundingMode
+		um    floatBit.UnderflowMode
+		om    floatBit.OverflowMode
+		// Outputs
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{
+			name:         "PosInfInputSatMax",
+			input:        *big.NewFloat(math.Inf(1)),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMaxNormal),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.NoEncoding,
+		},
+		{
+			name:         "NegInfInputSatInf",
+			input:        *big.NewFloat(math.Inf(-1)),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateInf,
+			goldenVal:    Bits(NegativeNaN),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.NoEncoding,
+		},
+		{
+			name:         "PosZeroInput",
+			input:        *big.NewFloat(0.0),
+			rm:           floatBit.RoundHalfTowardsNegativeInf,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveZero),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegZeroInput",
+			input:        *big.NewFloat(float64(math.Float32frombits(F32.NegativeZero))),
+			rm:           floatBit.RoundHalfTowardsNegativeInf,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateInf,
+			goldenVal:    Bits(NegativeZero),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "MaxNormalExact",
+			input:        *big.NewFloat(448),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.MakeNaN,
+			goldenVal:    Bits(PositiveMaxNormal),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NormalNumberRNE",
+			input:        *big.NewFloat(0.3),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b0_0101_010),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NormalNumberRTZ",
+			input:        *big.NewFloat(-0.3),
+			rm:           floatBit.RoundTowardsZero,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b1_0101_001),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "SubnormalRNE",
+			input:        *big.NewFloat(0.005),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b0_0000_011),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "SubnormalRTNegInf",
+			input:        *big.NewFloat(0.005),
+			rm:           floatBit.RoundTowardsNegativeInf,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b0_0000_010),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "PositiveOverflowToMax",
+			input:        *big.NewFloat(464),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMaxNormal),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Overflow,
+		},
+		{
+			name:         "NegativeOverflowToNaN",
+			input:        *big.NewFloat(-1000),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.MakeNaN,
+			goldenVal:    Bits(NegativeNaN),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Overflow,
+		},
+		{
+			name:         "PositiveOverflowSatInf",
+			input:        *big.NewFloat(1000),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateInf,
+			goldenVal:    Bits(PositiveNaN),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.NoEncoding,
+		},
+		{
+			name:         "PositiveUnderflowSatMin",
+			input:        *big.NewFloat(0.0005),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMinSubnormal),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
+		{
+			name:         "NegativeUnderflowFlushZero",
+			input:        *big.NewFloat(-1e-46),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(NegativeZero),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
+	}
+
+	for _, tt := range testCases {
+		resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessBeforeRounding)
+		if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Name: %s", tt.name)
+			t.Logf("Value: %s", tt.input.String())
+			t.Logf("Rounding Mode: %v", tt.rm)
+			t.Logf("Overflow Mode: %v", tt.om)
+			t.Logf("Underflow Mode: %v", tt.um)
+			t.Errorf("Expected result: %.10e (%0#2x), Got: %.10e (%0#2x)", tt.goldenVal.ToFloat32(), tt.goldenVal, resultVal.ToFloat32(), resultVal)
+			t.Errorf("Expect accuracy: %v, Got: %v", tt.goldenAcc, resultAcc)
+			t.Errorf("Expected status: %v, Got: %v", tt.goldenStatus, resultStatus)
+		}
+	}
+}
+
+func TestToFloatFormat(t *testing.T) {
+	input := Bits(0b1_0110_011)
+	result := input.ToFloatFormat()
+	if string(result.Sign) != "1" || string(result.Exponent) != "0110" ||
+		string(result.Mantissa) != "011" {
+		t.Errorf("Expected Sign: 1, Exponent: 0110, Mantissa: 011. Got: %v", result)
+	}
+}
@@ -0,0 +1,44 @@
+package E4M3
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number truncated to a number that can
+// be represented as a E4M3 number.
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E4M3 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundTowardsZero(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits, big.Accuracy) {
+	return truncate(signBit, exponentBits, mantissaBits, lostPrecision)
+}
+
+// truncation is the same as rounding towards zero
+func truncate(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits, big.Accuracy) {
+	mantissaE4M3Precision := mantissaBits & f32E4M3MantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E4M3HalfSubnormalMask
+
+	e4m3Sign := uint8(signBit << 7)
+	e4m3Exponent := uint8(exponentBits << 3)
+	// we need to move the mantissa bits to the right, so they align with the
+	// mantissa bits in the E4M3 format.
+	e4m3Mantissa := uint8(mantissaE4M3Precision >> 20)
+	resultVal := Bits(e4m3Sign | e4m3Exponent | e4m3Mantissa)
+	resultAcc := big.Exact
+
+	// If there was extra precision, then the number did not fit in the
+	// E4M3 format, so we need to report the status appropriately
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		if signBit == 0 {
+			resultAcc = big.Below
+		} else {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
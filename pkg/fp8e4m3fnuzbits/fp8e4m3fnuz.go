@@ -0,0 +1,474 @@
+package E4M3FNUZ
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+)
+
+// Some constants that will help with the bit manipulation we'll need to
+// perform
+const (
+	// Mantissa bits retained in E4M3FNUZ
+	f32E4M3FNUZMantissaMask uint32 = 0b0_00000000_11100000000000000000000
+	// Mantissa bits not retained in E4M3FNUZ
+	f32E4M3FNUZHalfSubnormalMask uint32 = 0b0_00000000_00011111111111111111111
+	// LSB of E4M3FNUZ and rest of the extra precision
+	f32E4M3FNUZSubnormalMask uint32 = 0b0_00000000_00111111111111111111111
+	// LSB of E4M3FNUZ
+	f32E4M3FNUZSubnormalLSB uint32 = 0b0_00000000_00100000000000000000000
+	// Most significant bit not retained in E4M3FNUZ
+	f32E4M3FNUZHalfSubnormalLSB uint32 = 0b0_00000000_00010000000000000000000
+)
+
+// Alias type for uint8. This is used to represent the bits that make up an
+// FP8 E4M3FNUZ number, the "finite, no negative zero" variant of E4M3 used by
+// AMD and Graphcore hardware. This type also comes with utility methods to
+// support Floating point conversions with different Rounding Modes and Out of
+// Bounds responses
+//
+// Compared to OCP E4M3, the exponent bias is 8 instead of 7, there is no
+// negative zero, and its encoding (0x80) is the only NaN. This means that the
+// out of bounds responses differ as well:
+//   - [floatBit.MakeNaN] always returns the unsigned [NaN]
+//   - [floatBit.SaturateInf] returns [NaN] with the [floatBit.NoEncoding]
+//     status, since there are no infinities
+//   - [floatBit.FlushToZero] always returns [PositiveZero]
+type Bits uint8
+
+// Convert the given [Bits] type to the floating point number it represents,
+// inside a float32 value. Every E4M3FNUZ number is exactly representable as a
+// float32 normal number, so this method only needs to re-align the bits
+// per the float32 bit representation
+func (input Bits) ToFloat32() float32 {
+	asUint8 := uint8(input)
+	// Extract the Sign, Exponent and Mantissa
+	signBit := (asUint8 & SignMask) >> 7
+	exponentBits := (asUint8 & ExponentMask) >> 3
+	mantissaBits := asUint8 & MantissaMask
+
+	// E4M3FNUZ has no infinities, the only special value is NaN, which takes
+	// the encoding of negative zero. This needs to be handled before applying
+	// the general algorithm to calculate the number
+	if asUint8 == NaN {
+		return math.Float32frombits(F32.NaN)
+	}
+	if asUint8 == PositiveZero {
+		return math.Float32frombits(F32.PositiveZero)
+	}
+
+	// Variables to store the sign, exponent and mantissa bits that will
+	// be used to construct the float32 number
+	var float32SignBit, float32ExponentBits, float32MantissaBits uint32
+
+	float32SignBit = uint32(signBit) << 31
+
+	if exponentBits == 0 {
+		// Subnormals in E4M3FNUZ are normals in float32. Just like for float16,
+		// we find the first set bit in the mantissa, which becomes the
+		// implicit precision bit in the float32 value, and decrement the
+		// exponent once for every bit we move past.
+		// (-1)^sign * 2^(-7) * (0/2 + 1/4 + m2/8)
+		// = (-1)^sign * 2^(-9) * (1 + m2/2)
+		currMantissaBitMask := uint8(0b0_0000_100)
+		resultMantissaBits := mantissaBits
+		resultExponent := ExponentMin
+		extraShift := 0
+		for ; currMantissaBitMask != 0; currMantissaBitMask >>= 1 {
+			currMantissaBit := currMantissaBitMask & mantissaBits
+			resultExponent -= 1
+			extraShift++
+			if currMantissaBit != 0 {
+				// We need to zero out this one bit, since this is what
+				// becomes the implicit bit in the float32
+				resultMantissaBits = mantissaBits & ^currMantissaBitMask
+				break
+			}
+		}
+		// F32 has 23 mantissa bits, and E4M3FNUZ has 3. Therefore, to align the
+		// bits, we need to shift to the left by 20 bits, plus the extra shift
+		// for the bits we moved past above.
+		float32MantissaBits = uint32(resultMantissaBits) << (20 + extraShift)
+		float32ExponentBits = uint32(resultExponent+F32.ExponentBias) << 23
+	} else {
+		// For the normal case, all we need to do is correct the exponent to
+		// use the bias of the float32 format
+		float32MantissaBits = uint32(mantissaBits) << 20
+		actualExponent := int(exponentBits) - ExponentBias
+		float32ExponentBits = uint32(actualExponent+F32.ExponentBias) << 23
+	}
+	return math.Float32frombits(float32SignBit | float32ExponentBits |
+		float32MantissaBits)
+}
+
+// Convert the given [Bits] type to a [big.Float] arbitrary precision
+// floating-point number
+func (input Bits) ToBigFloat() big.Float {
+	asFloat32 := input.ToFloat32()
+	asBigFloat := *big.NewFloat(float64(asFloat32))
+	return asBigFloat
+}
+
+// Convert the given [big.Float] arbitrary precision floating-point number
+// to a [Bits] type representing the bits of an E4M3FNUZ number. If the number
+// cannot be represented in E4M3FNUZ format exactly, then the rounding mode,
+// overflow mode and underflow mode decide the result. Returns the result
+// [Bits], a [big.Accuracy] which encodes whether the result value was the same,
+// larger or smaller than the input, and a [floatBit.Status] which encodes,
+// whether the result fit in the [Bits], caused overflow or underflow.
+// The [floatBit.Tininess] decides when the result underflows.
+// [floatBit.RoundStochastic] needs the optional rb argument.
+func FromBigFloat(input big.Float, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+
+	// Round directly from the [big.Float], so that there is no double
+	// rounding through float32
+	resultBits, resultAcc, resultStatus := floatBit.Encode(input,
+		floatBit.FormatE4M3FNUZ, rm, om, um, t, rb...)
+	return Bits(resultBits.Uint64()), resultAcc, resultStatus
+}
+
+// Returns the [floatBit.NaN] the given [Bits] encode, and false if they
+// aren't a NaN. See [floatBit.Format.DecodeNaN]
+func (input Bits) DecodeNaN() (floatBit.NaN, bool) {
+	return floatBit.FormatE4M3FNUZ.DecodeNaN(new(big.Int).SetUint64(uint64(input)))
+}
+
+// Convert the given [floatBit.NaN] to a [Bits] type representing the bits of
+// an E4M3FNUZ NaN, with the given [floatBit.NaNPolicy]. Returns the result
+// [Bits], a [floatBit.Status] and the [floatBit.Exceptions] raised by the
+// conversion. See [floatBit.Format.EncodeNaN]
+func FromNaN(input floatBit.NaN, p floatBit.NaNPolicy) (Bits, floatBit.Status,
+	floatBit.Exceptions) {
+	resultBits, resultStatus, resultExceptions := floatBit.FormatE4M3FNUZ.EncodeNaN(input, p)
+	return Bits(resultBits.Uint64()), resultStatus, resultExceptions
+}
+
+// Convert the given float32 number into a [Bits] type which represents the bits
+// of an E4M3FNUZ number. Signature and usage is identical to [FromBigFloat] except
+// the parameter input is float32
+func FromFloat32(input float32, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy,
+	floatBit.Status) {
+	// There are some special cases we need to handle, particularly those for
+	// values like Inf, NaN which have special encodings in the two formats
+
+	// Special Case #1: Infinities
+	// E4M3FNUZ has no encodings for infinities, so the result is decided by the
+	// overflow mode, and reported with the [floatBit.NoEncoding] status
+	if math.IsInf(float64(input), 1) {
+		return handleInfinity(0, om.Resolve(rm, false))
+	}
+	if math.IsInf(float64(input), -1) {
+		return handleInfinity(1, om.Resolve(rm, true))
+	}
+
+	// Special Case #2: NaNs
+	// NaNs always convert to NaNs. For our case, we consider the converison
+	// to be exact.
+	if math.IsNaN(float64(input)) {
+		return Bits(NaN), big.Exact, floatBit.Fits
+	}
+
+	// Stochastic rounding needs all of the discarded bits, not just the
+	// round and sticky bits, which [FromBigFloat] extracts. [FromBigFloat]
+	// also takes care of rounding the numbers below the minimum subnormal
+	// (see [floatBit.UnderflowMode.RoundsBelowMinSubnormal]), and of detecting
+	// overflow after rounding for [floatBit.OverflowIEEE]
+	if rm == floatBit.RoundStochastic || um.RoundsBelowMinSubnormal(t) ||
+		om == floatBit.OverflowIEEE {
+		return FromBigFloat(*big.NewFloat(float64(input)), rm, om, um, t, rb...)
+	}
+
+	// For the remaining cases, we need access to the underlying bits of the
+	// float32 number.
+	asUint32 := math.Float32bits(input)
+
+	// Special Case #3: Zeros
+	// E4M3FNUZ has no negative zero, so both the zeros convert to the
+	// positive zero. The values are the same, so this is exact.
+	if asUint32 == F32.PositiveZero || asUint32 == F32.NegativeZero {
+		return Bits(PositiveZero), big.Exact, floatBit.Fits
+	}
+
+	// With the number interpreted as uint32, we can now extract the underlying
+	// sign, exponent and mantissa bits.
+	signBit := (asUint32 & F32.SignMask) >> 31
+	exponentBits := (asUint32 & F32.ExponentMask) >> 23
+	mantissaBits := asUint32 & F32.MantissaMask
+
+	// Special Case #4: Input is subnormal (exponent bits == 0 && mantissa != 0)
+	// float32 subnormals are far smaller than the smallest E4M3FNUZ subnormal, so
+	// they always underflow. For these cases the input um
+	// [floatBit.UnderflowMode] determines the result
+	if exponentBits == 0 {
+		return handleUnderflow(signBit, um)
+	}
+
+	// Special Case #5: Input exceeds the maximum normal value (in magnitude)
+	// that can be represented in the E4M3FNUZ format. In this case, the input om
+	// [floatBit.OverflowMode] determines the response.
+	actualExponent := int(exponentBits) - F32.ExponentBias
+
+	if checkOverflow(actualExponent, mantissaBits) {
+		return handleOverflow(signBit, om)
+	}
+
+	// Variables to store return values in
+	var resultVal Bits
+	var resultAcc big.Accuracy
+
+	// Just like for float16, we calculate the aligned mantissa and the
+	// adjusted exponent. For normal numbers these are the float32 mantissa
+	// and the exponent with the E4M3FNUZ bias applied. For subnormals the
+	// mantissa needs to be shifted and the exponent bits are 0
+	alignedMantissa := mantissaBits
+	adjustedExponent := uint32(actualExponent + ExponentBias)
+
+	// Value that indicates whether any precision was lost when preprocessing
+	// the mantissa before passing it down to the rounding routines
+	lostPrecision := false
+
+	if actualExponent < ExponentMin {
+		// The number can only be represented by an E4M3FNUZ subnormal, so we
+		// add the implicit 1 back into the mantissa, and shift right until
+		// the mantissa bits line up with the powers of two of the E4M3FNUZ
+		// subnormals (2^-7 * 0.m)
+		const (
+			float32ExponentLSB uint32 = 0b0_00000001_00000000000000000000000
+		)
+
+		alignedMantissa = mantissaBits | float32ExponentLSB
+		adjustedExponent = 0
+
+		shiftAmount := uint32(ExponentMin - actualExponent)
+		for ; shiftAmount > 0; shiftAmount-- {
+			lastDigit := alignedMantissa & 0x1
+			if lastDigit == 1 {
+				lostPrecision = true
+			}
+			alignedMantissa >>= 1
+		}
+
+		// If none of the mantissa bits that E4M3FNUZ can hold are set, but there
+		// was extra precision, the number is smaller than the minimum
+		// subnormal, and the input um [floatBit.UnderflowMode] determines
+		// the result
+		if checkUnderflow(alignedMantissa, lostPrecision) {
+			return handleUnderflow(signBit, um)
+		}
+	}
+
+	switch rm {
+	case floatBit.RoundTowardsZero:
+		resultVal, resultAcc = roundTowardsZero(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundTowardsNegativeInf:
+		resultVal, resultAcc = roundTowardsNegativeInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundTowardsPositiveInf:
+		resultVal, resultAcc = roundTowardsPositiveInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfTowardsZero:
+		resultVal, resultAcc = roundHalfTowardsZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfTowardsNegativeInf:
+		resultVal, resultAcc = roundHalfTowardsNegativeInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfTowardsPositiveInf:
+		resultVal, resultAcc = roundHalfTowardsPositiveInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundNearestEven:
+		resultVal, resultAcc = roundNearestEven(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundNearestOdd:
+		resultVal, resultAcc = roundNearestOdd(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundAwayFromZero:
+		resultVal, resultAcc = roundAwayFromZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfAwayFromZero:
+		resultVal, resultAcc = roundHalfAwayFromZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	}
+
+	return resultVal, resultAcc, floatBit.Fits
+}
+
+// Utility function to check if the number with the given exponent and mantissa
+// bits would overflow when trying to represent it in an E4M3FNUZ value.
+// mantissaBits should occupy the bits with the float32 format in mind.
+// Since NaN is encoded with the negative zero bits, the largest exponent is
+// not reserved at all, and the largest normal is 2^7 * 1.111
+func checkOverflow(actualExponent int, mantissaBits uint32) bool {
+	// If the exponent is larger than the max, then it's overflow
+	if actualExponent > ExponentMax {
+		return true
+	}
+
+	// If the exponent is equal to the maximum exponent, all the
+	// E4M3FNUZ mantissa bits are set, but there is additional precision in
+	// the number than can be represented in E4M3FNUZ, then it exceeds the
+	// maximum normal and overflows.
+	if (actualExponent == ExponentMax) &&
+		(mantissaBits&f32E4M3FNUZMantissaMask == f32E4M3FNUZMantissaMask) &&
+		(mantissaBits&f32E4M3FNUZHalfSubnormalMask > 0) {
+		return true
+	}
+	return false
+}
+
+// Utility function to check if the number would underflow when trying to
+// represent it in an E4M3FNUZ value. mantissaBits must already be aligned for the
+// subnormal exponent. The lostPrecision parameter indicates whether any set
+// bits were shifted out during that alignment
+func checkUnderflow(mantissaBits uint32, lostPrecision bool) bool {
+	// This assumes that the exponent is 0, so any extra precision in the
+	// mantissa means underflow.
+	e4m3fnuzPrecisionMantissa := mantissaBits & f32E4M3FNUZMantissaMask
+	e4m3fnuzExtraPrecisionMantissa := mantissaBits & f32E4M3FNUZHalfSubnormalMask
+	if (e4m3fnuzPrecisionMantissa == 0) && (e4m3fnuzExtraPrecisionMantissa != 0 || lostPrecision) {
+		return true
+	}
+	return false
+}
+
+// Utility function that returns the result for the case when
+// the converison results in underflow
+func handleUnderflow(signBit uint32, um floatBit.UnderflowMode) (Bits,
+	big.Accuracy, floatBit.Status) {
+	switch um {
+	case floatBit.FlushToZero:
+		// There is no negative zero, so both signs flush to the positive
+		// zero.
+		if signBit == 0 {
+			// Zero is less than any positive subnormal
+			return Bits(PositiveZero), big.Below, floatBit.Underflow
+		}
+		return Bits(PositiveZero), big.Above, floatBit.Underflow
+	case floatBit.SaturateMin:
+		if signBit == 0 {
+			// Min subnormal of E4M3FNUZ is larger than any number this function
+			// will be invoked for
+			return Bits(PositiveMinSubnormal), big.Above, floatBit.Underflow
+		}
+		return Bits(NegativeMinSubnormal), big.Below, floatBit.Underflow
+	default:
+		panic("Unsupported UnderflowMode encountered")
+	}
+}
+
+// Utility function that returns the result for the case when the conversion
+// results in overflow
+func handleOverflow(signBit uint32, om floatBit.OverflowMode) (Bits,
+	big.Accuracy, floatBit.Status) {
+	switch om {
+	case floatBit.SaturateInf:
+		// E4M3FNUZ does not have infinities. The closest thing to an infinity
+		// is the NaN, so we return that, but flag that the requested result
+		// has no encoding.
+		if signBit == 0 {
+			return Bits(NaN), big.Above, floatBit.NoEncoding
+		}
+		return Bits(NaN), big.Below, floatBit.NoEncoding
+	case floatBit.MakeNaN:
+		// The accuracy and status don't matter for this case. There's only
+		// one NaN, so the sign is dropped.
+		if signBit == 0 {
+			return Bits(NaN), big.Above, floatBit.Overflow
+		}
+		return Bits(NaN), big.Below, floatBit.Overflow
+	case floatBit.SaturateMax:
+		if signBit == 0 {
+			// The maximum normal in E4M3FNUZ is smaller than any number
+			// this function will be invoked for
+			return Bits(PositiveMaxNormal), big.Below, floatBit.Overflow
+		}
+		return Bits(NegativeMaxNormal), big.Above, floatBit.Overflow
+	default:
+		panic("Unsupported OverflowMode encountered")
+	}
+}
+
+// Utility function that returns the result for the case when the input is an
+// infinity. Since E4M3FNUZ cannot encode infinities, the overflow mode decides
+// the result just like for overflow, but the status is always
+// [floatBit.NoEncoding]
+func handleInfinity(signBit uint32, om floatBit.OverflowMode) (Bits,
+	big.Accuracy, floatBit.Status) {
+	resultVal, resultAcc, _ := handleOverflow(signBit, om)
+	return resultVal, resultAcc, floatBit.NoEncoding
+}
+
+// ToFloatFormat converts the given Bits type representing the bits that make
+// up an E4M3FNUZ number into [floatBit.FloatBitFormat]
+// Implements the FloatBitFormatter Interface
+func (b *Bits) ToFloatFormat() floatBit.FloatBitFormat {
+	// Iterate over the bits and construct the return Values
+
+	asUint := uint8(*b)
+	signBits := (asUint & SignMask) >> 7
+	exponentBits := (asUint & ExponentMask) >> 3
+	mantissaBits := asUint & MantissaMask
+
+	// 1 Sign Bit
+	signRetVal := make([]byte, 0, 1)
+	if signBits == 0 {
+		signRetVal = append(signRetVal, byte('0'))
+	} else {
+		signRetVal = append(signRetVal, byte('1'))
+	}
+
+	// 4 Exponent Bits
+	exponentRetVal := make([]byte, 0, 4)
+	for i := 0; i < 4; i++ {
+		currentExponentBit := exponentBits & 0x1
+		var valueToAppend byte
+		if currentExponentBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		exponentRetVal = append(exponentRetVal, valueToAppend)
+		exponentBits >>= 1
+	}
+	slices.Reverse(exponentRetVal)
+
+	// 3 Mantissa Bits
+	mantissaRetVal := make([]byte, 0, 3)
+	for i := 0; i < 3; i++ {
+		currentMantissaBit := mantissaBits & 0x1
+		var valueToAppend byte
+		if currentMantissaBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		mantissaRetVal = append(mantissaRetVal, valueToAppend)
+		mantissaBits >>= 1
+	}
+	slices.Reverse(mantissaRetVal)
+
+	return floatBit.FloatBitFormat{Sign: signRetVal,
+		Exponent: exponentRetVal, Mantissa: mantissaRetVal}
+}
+
+// Conversion error returns the difference between the input [big.Float]
+// number and the E4M3FNUZ number represented by the bits in the [Bits] receiver
+func (b *Bits) ConversionError(input *big.Float) (big.Float, error) {
+	// If the receiver is a NaN then we return an error
+	asFloat32 := b.ToFloat32()
+	if math.IsNaN(float64(asFloat32)) {
+		return *big.NewFloat(0.0), errors.New("NaN encountered")
+	}
+
+	asBigFloat := b.ToBigFloat()
+	convDiff := asBigFloat.Sub(&asBigFloat, input)
+	return *convDiff, nil
+}
@@ -0,0 +1,26 @@
+package E4M3FNUZ
+
+const (
+	SignMask     uint8 = 0b1_0000_000
+	ExponentMask uint8 = 0b0_1111_000
+	MantissaMask uint8 = 0b0_0000_111
+
+	PositiveMaxNormal uint8 = 0b0_1111_111
+	NegativeMaxNormal uint8 = 0b1_1111_111
+
+	// There is no negative zero in E4M3FNUZ. Its encoding is used for NaN
+	PositiveZero uint8 = 0b0_0000_000
+
+	PositiveMinSubnormal uint8 = 0b0_0000_001
+	NegativeMinSubnormal uint8 = 0b1_0000_001
+
+	// The E4M3FNUZ format has no encodings for infinities, and exactly one
+	// NaN encoding, which is the bit pattern that would otherwise be negative
+	// zero. Unlike the other formats, NaNs here are unsigned, so there are
+	// no separate PositiveNaN and NegativeNaN values
+	NaN uint8 = 0b1_0000_000
+
+	ExponentBias int = 8
+	ExponentMin  int = -7
+	ExponentMax  int = 7
+)
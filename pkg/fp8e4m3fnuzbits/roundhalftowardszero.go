@@ -0,0 +1,57 @@
+package E4M3FNUZ
+
+import "math/big"
+
+// Utility function that returns the number rounded to the closest E4M3FNUZ
+// value. Ties are broken by rounding to the value closest to zero (truncation)
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E4M3FNUZ bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+// The parameter lostPrecision indicates whether the mantissa passed had already
+// lost precision during any preprocessing
+func roundHalfTowardsZero(signBit, exponentBits,
+	mantissaBits uint32, lostPrecision bool) (Bits, big.Accuracy) {
+
+	mantissaE4M3FNUZPrecision := mantissaBits & f32E4M3FNUZMantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E4M3FNUZHalfSubnormalMask
+
+	e4m3fnuzSign := uint8(signBit << 7)
+	e4m3fnuzExponent := uint8(exponentBits << 3)
+	e4m3fnuzMantissa := uint8(mantissaE4M3FNUZPrecision >> 20)
+
+	exponentMantissaComposite := e4m3fnuzExponent | e4m3fnuzMantissa
+
+	// If the extra precision bits exceed 1 0 0 0 0....
+	// we need to add 1 to LSB of F32 mantissa, otherwise truncate
+	// For all other cases we truncate
+	addedOne := false
+	if mantissaExtraPrecision > f32E4M3FNUZHalfSubnormalLSB {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// If extra precision was lost before, then we need to add one if we're
+	// halfway through in the adjusted mantissa (because this means we're
+	// actually greater than the midpoint)
+	if mantissaExtraPrecision == f32E4M3FNUZHalfSubnormalLSB && lostPrecision {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// All we need to do now is attach the sign
+	resultVal := Bits(e4m3fnuzSign | exponentMantissaComposite)
+	resultAcc := big.Exact
+
+	// Result is larger if the input was positive and we added 1, or
+	// if the input was negative and we truncated.
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		resultAcc = big.Below
+		if (e4m3fnuzSign == 0) == addedOne {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
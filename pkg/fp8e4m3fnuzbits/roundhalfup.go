@@ -0,0 +1,64 @@
+package E4M3FNUZ
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number rounded to the closest E4M3FNUZ
+// value. Ties are broken by rounding towards the value closer to +Infinity.
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E4M3FNUZ bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundHalfTowardsPositiveInf(signBit, exponentBits,
+	mantissaBits uint32, lostPrecision bool) (Bits, big.Accuracy) {
+
+	mantissaE4M3FNUZPrecision := mantissaBits & f32E4M3FNUZMantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E4M3FNUZHalfSubnormalMask
+
+	e4m3fnuzSign := uint8(signBit << 7)
+	e4m3fnuzExponent := uint8(exponentBits << 3)
+	e4m3fnuzMantissa := uint8(mantissaE4M3FNUZPrecision >> 20)
+
+	exponentMantissaComposite := e4m3fnuzExponent | e4m3fnuzMantissa
+
+	addedOne := false
+	// We definitely add 1, if we're greater than the mid-point
+	if mantissaExtraPrecision > f32E4M3FNUZHalfSubnormalLSB {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// If extra precision was lost before, then we need to add one if we're
+	// halfway through in the adjusted mantissa (because this means we're
+	// actually greater than the midpoint)
+	if mantissaExtraPrecision == f32E4M3FNUZHalfSubnormalLSB && lostPrecision {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// In the case that we're halfway through,
+	// We add 1, only if the sign was positive, otherwise we truncate
+	if (mantissaExtraPrecision ==
+		f32E4M3FNUZHalfSubnormalLSB) && (e4m3fnuzSign == 0) && !lostPrecision {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// For all other case we truncate, so now we can construct the result
+	// by attaching the sign
+	resultVal := Bits(e4m3fnuzSign | exponentMantissaComposite)
+	resultAcc := big.Exact
+
+	// Result is larger if the input was positive and we added 1, or
+	// if the input was negative and we truncated.
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		resultAcc = big.Below
+		if (e4m3fnuzSign == 0) == addedOne {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,51 @@
+package E4M3FNUZ
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number rounded to a number that is
+// representable in E4M3FNUZ. If y is the input number and x < y < x + 1ULP
+// where x is a E4M3FNUZ number. Then this rounding mode picks up x + 1ULP
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E4M3FNUZ bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundTowardsPositiveInf(signBit, exponentBits,
+	mantissaBits uint32, lostPrecision bool) (Bits, big.Accuracy) {
+	return roundUp(signBit, exponentBits, mantissaBits, lostPrecision)
+}
+
+func roundUp(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits, big.Accuracy) {
+
+	mantissaE4M3FNUZPrecision := mantissaBits & f32E4M3FNUZMantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E4M3FNUZHalfSubnormalMask
+
+	e4m3fnuzSign := uint32(signBit << 7)
+	e4m3fnuzExponent := uint32(exponentBits << 3)
+	e4m3fnuzMantissa := uint32(mantissaE4M3FNUZPrecision >> 20)
+
+	// For this rounding mode, we only need to add 1 to the Least-precision
+	// mantissa, if the input was positive, to bring it closer to +inf.
+	// For negative numbers, this is achieved by simply truncating.
+
+	exponentMantissaComposite := (e4m3fnuzExponent | e4m3fnuzMantissa)
+
+	// If positive and there is extra precision, then add 1
+	if (e4m3fnuzSign == 0) && ((mantissaExtraPrecision != 0) || lostPrecision) {
+		exponentMantissaComposite += 1
+	}
+	// Since, we don't handle overflow, all we need to do now is attach the sign
+	resultVal := Bits(e4m3fnuzSign | exponentMantissaComposite)
+
+	resultAcc := big.Exact
+	// If there was extra precision bits set, then we need to
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		// We always round to a larger value
+		resultAcc = big.Above
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,525 @@
+package E5M2
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	F16 "github.com/shantanu-gontia/float-conv/pkg/float16bits"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+)
+
+// Some constants that will help with the bit manipulation we'll need to
+// perform
+const (
+	// Mantissa bits retained in E5M2
+	f32E5M2MantissaMask uint32 = 0b0_00000000_11000000000000000000000
+	// Mantissa bits not retained in E5M2
+	f32E5M2HalfSubnormalMask uint32 = 0b0_00000000_00111111111111111111111
+	// LSB of E5M2 and rest of the extra precision
+	f32E5M2SubnormalMask uint32 = 0b0_00000000_01111111111111111111111
+	// LSB of E5M2
+	f32E5M2SubnormalLSB uint32 = 0b0_00000000_01000000000000000000000
+	// Most significant bit not retained in E5M2
+	f32E5M2HalfSubnormalLSB uint32 = 0b0_00000000_00100000000000000000000
+
+	// Mantissa bits of a float16 that are retained in E5M2
+	f16E5M2MantissaMask uint16 = 0b0_00000_1100000000
+	// Mantissa bits of a float16 that are not retained in E5M2
+	f16E5M2ExtraPrecisionMask uint16 = 0b0_00000_0011111111
+)
+
+// Alias type for uint8. This is used to represent the bits that make up an
+// OCP FP8 E5M2 number. This type also comes with utility methods to support
+// Floating point conversions with different Rounding Modes and Out of Bounds
+// responses
+type Bits uint8
+
+// Convert the given [Bits] type to the floating point number it represents,
+// inside a float32 value. E5M2 has the same exponent range and special values
+// as float16, with 8 fewer mantissa bits. So, the bits of an E5M2 number are
+// exactly the upper byte of the float16 number it represents, and we let
+// [F16.Bits] perform the up-cast. NaNs keep their sign, but always give the
+// quiet NaN with a zero payload. Use [Bits.DecodeNaN] to get the payload
+func (input Bits) ToFloat32() float32 {
+	asFloat16 := F16.Bits(uint16(input) << 8)
+	return asFloat16.ToFloat32()
+}
+
+// Convert the given [Bits] type to a [big.Float] arbitrary precision
+// floating-point number
+func (input Bits) ToBigFloat() big.Float {
+	asFloat32 := input.ToFloat32()
+	asBigFloat := *big.NewFloat(float64(asFloat32))
+	return asBigFloat
+}
+
+// Convert the given [big.Float] arbitrary precision floating-point number
+// to a [Bits] type representing the bits of an E5M2 number. If the number
+// cannot be represented in E5M2 format exactly, then the rounding mode,
+// overflow mode and underflow mode decide the result. Returns the result
+// [Bits], a [big.Accuracy] which encodes whether the result value was the same,
+// larger or smaller than the input, and a [floatBit.Status] which encodes,
+// whether the result fit in the [Bits], caused overflow or underflow.
+// The [floatBit.Tininess] decides when the result underflows.
+// [floatBit.RoundStochastic] needs the optional rb argument.
+func FromBigFloat(input big.Float, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+
+	// Round directly from the [big.Float], so that there is no double
+	// rounding through float32
+	resultBits, resultAcc, resultStatus := floatBit.Encode(input,
+		floatBit.FormatE5M2, rm, om, um, t, rb...)
+	return Bits(resultBits.Uint64()), resultAcc, resultStatus
+}
+
+// Returns the [floatBit.NaN] the given [Bits] encode, and false if they
+// aren't a NaN. See [floatBit.Format.DecodeNaN]
+func (input Bits) DecodeNaN() (floatBit.NaN, bool) {
+	return floatBit.FormatE5M2.DecodeNaN(new(big.Int).SetUint64(uint64(input)))
+}
+
+// Convert the given [floatBit.NaN] to a [Bits] type representing the bits of
+// an E5M2 NaN, with the given [floatBit.NaNPolicy]. Returns the result
+// [Bits], a [floatBit.Status] and the [floatBit.Exceptions] raised by the
+// conversion. See [floatBit.Format.EncodeNaN]
+func FromNaN(input floatBit.NaN, p floatBit.NaNPolicy) (Bits, floatBit.Status,
+	floatBit.Exceptions) {
+	resultBits, resultStatus, resultExceptions := floatBit.FormatE5M2.EncodeNaN(input, p)
+	return Bits(resultBits.Uint64()), resultStatus, resultExceptions
+}
+
+// Convert the given float32 number into a [Bits] type which represents the bits
+// of an E5M2 number. Signature and usage is identical to [FromBigFloat] except
+// the parameter input is float32. NaNs are always canonicalized to [NaN],
+// which drops the sign and the payload, like [floatBit.NaNCanonicalize]. Use
+// [FromNaN] to choose the [floatBit.NaNPolicy]
+func FromFloat32(input float32, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy,
+	floatBit.Status) {
+	// There are some special cases we need to handle, particularly those for
+	// values like Inf, NaN which have special encodings in the two formats
+
+	// Special Case #1: Infinities
+	// Both float32 and E5M2 have representations for positive and negative
+	// infinities. Both of them convert to their counterparts exactly.
+	if math.IsInf(float64(input), 1) {
+		return Bits(PositiveInfinity), big.Exact, floatBit.Fits
+	}
+	if math.IsInf(float64(input), -1) {
+		return Bits(NegativeInfinity), big.Exact, floatBit.Fits
+	}
+
+	// Special Case #2: NaNs
+	// NaNs always convert to NaNs. For our case, we consider the converison
+	// to be exact.
+	if math.IsNaN(float64(input)) {
+		return Bits(NaN), big.Exact, floatBit.Fits
+	}
+
+	// Stochastic rounding needs all of the discarded bits, not just the
+	// round and sticky bits, which [FromBigFloat] extracts. [FromBigFloat]
+	// also takes care of rounding the numbers below the minimum subnormal
+	// (see [floatBit.UnderflowMode.RoundsBelowMinSubnormal]), and of detecting
+	// overflow after rounding for [floatBit.OverflowIEEE]
+	if rm == floatBit.RoundStochastic || um.RoundsBelowMinSubnormal(t) ||
+		om == floatBit.OverflowIEEE {
+		return FromBigFloat(*big.NewFloat(float64(input)), rm, om, um, t, rb...)
+	}
+
+	// For the remaining cases, we need access to the underlying bits of the
+	// float32 number.
+	asUint32 := math.Float32bits(input)
+
+	// Special Case #3: Zeros
+	if asUint32 == F32.PositiveZero {
+		return Bits(PositiveZero), big.Exact, floatBit.Fits
+	}
+	if asUint32 == F32.NegativeZero {
+		return Bits(NegativeZero), big.Exact, floatBit.Fits
+	}
+
+	// With the number interpreted as uint32, we can now extract the underlying
+	// sign, exponent and mantissa bits.
+	signBit := (asUint32 & F32.SignMask) >> 31
+	exponentBits := (asUint32 & F32.ExponentMask) >> 23
+	mantissaBits := asUint32 & F32.MantissaMask
+
+	// Special Case #4: Input is subnormal (exponent bits == 0 && mantissa != 0)
+	// float32 subnormals are far smaller than the smallest E5M2 subnormal, so
+	// they always underflow. For these cases the input um
+	// [floatBit.UnderflowMode] determines the result
+	if exponentBits == 0 {
+		return handleUnderflow(signBit, um)
+	}
+
+	// Special Case #5: Input exceeds the maximum normal value (in magnitude)
+	// that can be represented in the E5M2 format. In this case, the input om
+	// [floatBit.OverflowMode] determines the response.
+	actualExponent := int(exponentBits) - F32.ExponentBias
+
+	if checkOverflow(actualExponent, mantissaBits) {
+		return handleOverflow(signBit, om)
+	}
+
+	// Variables to store return values in
+	var resultVal Bits
+	var resultAcc big.Accuracy
+
+	// Just like for float16, we calculate the aligned mantissa and the
+	// adjusted exponent. For normal numbers these are the float32 mantissa
+	// and the exponent with the E5M2 bias applied. For subnormals the
+	// mantissa needs to be shifted and the exponent bits are 0
+	alignedMantissa := mantissaBits
+	adjustedExponent := uint32(actualExponent + ExponentBias)
+
+	// Value that indicates whether any precision was lost when preprocessing
+	// the mantissa before passing it down to the rounding routines
+	lostPrecision := false
+
+	if actualExponent < ExponentMin {
+		// The number can only be represented by an E5M2 subnormal, so we
+		// add the implicit 1 back into the mantissa, and shift right until
+		// the mantissa bits line up with the powers of two of the E5M2
+		// subnormals (2^-14 * 0.m)
+		const (
+			float32ExponentLSB uint32 = 0b0_00000001_00000000000000000000000
+		)
+
+		alignedMantissa = mantissaBits | float32ExponentLSB
+		adjustedExponent = 0
+
+		shiftAmount := uint32(ExponentMin - actualExponent)
+		for ; shiftAmount > 0; shiftAmount-- {
+			lastDigit := alignedMantissa & 0x1
+			if lastDigit == 1 {
+				lostPrecision = true
+			}
+			alignedMantissa >>= 1
+		}
+
+		// If none of the mantissa bits that E5M2 can hold are set, but there
+		// was extra precision, the number is smaller than the minimum
+		// subnormal, and the input um [floatBit.UnderflowMode] determines
+		// the result
+		if checkUnderflow(alignedMantissa, lostPrecision) {
+			return handleUnderflow(signBit, um)
+		}
+	}
+
+	switch rm {
+	case floatBit.RoundTowardsZero:
+		resultVal, resultAcc = roundTowardsZero(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundTowardsNegativeInf:
+		resultVal, resultAcc = roundTowardsNegativeInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundTowardsPositiveInf:
+		resultVal, resultAcc = roundTowardsPositiveInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfTowardsZero:
+		resultVal, resultAcc = roundHalfTowardsZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfTowardsNegativeInf:
+		resultVal, resultAcc = roundHalfTowardsNegativeInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfTowardsPositiveInf:
+		resultVal, resultAcc = roundHalfTowardsPositiveInf(signBit,
+			adjustedExponent, alignedMantissa, lostPrecision)
+	case floatBit.RoundNearestEven:
+		resultVal, resultAcc = roundNearestEven(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundNearestOdd:
+		resultVal, resultAcc = roundNearestOdd(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundAwayFromZero:
+		resultVal, resultAcc = roundAwayFromZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	case floatBit.RoundHalfAwayFromZero:
+		resultVal, resultAcc = roundHalfAwayFromZero(signBit, adjustedExponent,
+			alignedMantissa, lostPrecision)
+	}
+
+	return resultVal, resultAcc, floatBit.Fits
+}
+
+// Convert the given float16 number, represented by its [F16.Bits], into a
+// [Bits] type which represents the bits of an E5M2 number. Signature and usage
+// is identical to [FromBigFloat] except the parameter input is [F16.Bits].
+// Since E5M2 and float16 share the sign and exponent layout, this is just
+// a matter of rounding away the lower 8 mantissa bits of the float16 number,
+// and doesn't require any exponent re-alignment. NaNs keep their sign, but
+// always give the quiet NaN with a zero payload. Use [F16.Bits.DecodeNaN] and
+// [FromNaN] to keep the payload.
+// [floatBit.RoundStochastic] needs the optional rb argument.
+func FromFloat16Bits(input F16.Bits, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+
+	asUint16 := uint16(input)
+	signBit := uint32(asUint16&F16.SignMask) >> 15
+	exponentBits := uint32(asUint16&F16.ExponentMask) >> 10
+	mantissaBits := asUint16 & F16.MantissaMask
+
+	// Special Case #1: Infinities and NaNs. These have the same exponent bits
+	// in both the formats. NaNs keep their sign, like the infinities
+	if exponentBits == 0b11111 {
+		if mantissaBits != 0 {
+			if signBit == 0 {
+				return Bits(NaN), big.Exact, floatBit.Fits
+			}
+			return Bits(NegativeNaN), big.Exact, floatBit.Fits
+		}
+		if signBit == 0 {
+			return Bits(PositiveInfinity), big.Exact, floatBit.Fits
+		}
+		return Bits(NegativeInfinity), big.Exact, floatBit.Fits
+	}
+
+	// Stochastic rounding needs all of the discarded bits, not just the
+	// round and sticky bits, which [FromBigFloat] extracts. [FromBigFloat]
+	// also takes care of rounding the numbers below the minimum subnormal
+	// (see [floatBit.UnderflowMode.RoundsBelowMinSubnormal]), and of detecting
+	// overflow after rounding for [floatBit.OverflowIEEE]
+	if rm == floatBit.RoundStochastic || um.RoundsBelowMinSubnormal(t) ||
+		om == floatBit.OverflowIEEE {
+		return FromBigFloat(input.ToBigFloat(), rm, om, um, t, rb...)
+	}
+
+	// Special Case #2: Subnormals, where the only mantissa bits set are the
+	// ones that E5M2 cannot hold. This constitutes underflow. Zeros also end
+	// up here, but those convert exactly
+	if exponentBits == 0 && (mantissaBits&f16E5M2MantissaMask) == 0 {
+		if mantissaBits == 0 {
+			return Bits(asUint16 >> 8), big.Exact, floatBit.Fits
+		}
+		return handleUnderflow(signBit, um)
+	}
+
+	// Special Case #3: Input exceeds the maximum normal value (in magnitude)
+	// that can be represented in E5M2. The maximum exponent is the same as
+	// for float16, so this only happens when the E5M2 mantissa bits are all
+	// set and there's extra precision
+	if (exponentBits == uint32(ExponentMax+ExponentBias)) &&
+		(mantissaBits&f16E5M2MantissaMask == f16E5M2MantissaMask) &&
+		(mantissaBits&f16E5M2ExtraPrecisionMask != 0) {
+		return handleOverflow(signBit, om)
+	}
+
+	// The rounding routines expect the mantissa in its float32 location. The
+	// exponent bits already have the E5M2 bias applied, and float16
+	// subnormals line up with the E5M2 subnormals, so no more alignment is
+	// needed
+	alignedMantissa := uint32(mantissaBits) << 13
+
+	var resultVal Bits
+	var resultAcc big.Accuracy
+
+	switch rm {
+	case floatBit.RoundTowardsZero:
+		resultVal, resultAcc = roundTowardsZero(signBit,
+			exponentBits, alignedMantissa, false)
+	case floatBit.RoundTowardsNegativeInf:
+		resultVal, resultAcc = roundTowardsNegativeInf(signBit,
+			exponentBits, alignedMantissa, false)
+	case floatBit.RoundTowardsPositiveInf:
+		resultVal, resultAcc = roundTowardsPositiveInf(signBit,
+			exponentBits, alignedMantissa, false)
+	case floatBit.RoundHalfTowardsZero:
+		resultVal, resultAcc = roundHalfTowardsZero(signBit, exponentBits,
+			alignedMantissa, false)
+	case floatBit.RoundHalfTowardsNegativeInf:
+		resultVal, resultAcc = roundHalfTowardsNegativeInf(signBit,
+			exponentBits, alignedMantissa, false)
+	case floatBit.RoundHalfTowardsPositiveInf:
+		resultVal, resultAcc = roundHalfTowardsPositiveInf(signBit,
+			exponentBits, alignedMantissa, false)
+	case floatBit.RoundNearestEven:
+		resultVal, resultAcc = roundNearestEven(signBit, exponentBits,
+			alignedMantissa, false)
+	case floatBit.RoundNearestOdd:
+		resultVal, resultAcc = roundNearestOdd(signBit, exponentBits,
+			alignedMantissa, false)
+	case floatBit.RoundAwayFromZero:
+		resultVal, resultAcc = roundAwayFromZero(signBit, exponentBits,
+			alignedMantissa, false)
+	case floatBit.RoundHalfAwayFromZero:
+		resultVal, resultAcc = roundHalfAwayFromZero(signBit, exponentBits,
+			alignedMantissa, false)
+	default:
+		panic("Unsupported RoundingMode encountered")
+	}
+
+	return resultVal, resultAcc, floatBit.Fits
+}
+
+// Utility function to check if the number with the given exponent and mantissa
+// bits would overflow when trying to represent it in an E5M2 value.
+// mantissaBits should occupy the bits with the float32 format in mind.
+func checkOverflow(actualExponent int, mantissaBits uint32) bool {
+	// If the exponent is larger than the max, then it's overflow
+	if actualExponent > ExponentMax {
+		return true
+	}
+
+	// If the exponent is equal to the maximum exponent, all the
+	// E5M2 mantissa bits are set, but there is additional precision in the
+	// number than can be represented in E5M2, then it exceeds the maximum
+	// normal and overflows.
+	if (actualExponent == ExponentMax) &&
+		(mantissaBits&f32E5M2MantissaMask == f32E5M2MantissaMask) &&
+		(mantissaBits&f32E5M2HalfSubnormalMask > 0) {
+		return true
+	}
+	return false
+}
+
+// Utility function to check if the number would underflow when trying to
+// represent it in an E5M2 value. mantissaBits must already be aligned for the
+// subnormal exponent. The lostPrecision parameter indicates whether any set
+// bits were shifted out during that alignment
+func checkUnderflow(mantissaBits uint32, lostPrecision bool) bool {
+	// This assumes that the exponent is 0, so any extra precision in the
+	// mantissa means underflow.
+	e5m2PrecisionMantissa := mantissaBits & f32E5M2MantissaMask
+	e5m2ExtraPrecisionMantissa := mantissaBits & f32E5M2HalfSubnormalMask
+	if (e5m2PrecisionMantissa == 0) && (e5m2ExtraPrecisionMantissa != 0 || lostPrecision) {
+		return true
+	}
+	return false
+}
+
+// Utility function that returns the result for the case when
+// the converison results in underflow
+func handleUnderflow(signBit uint32, um floatBit.UnderflowMode) (Bits,
+	big.Accuracy, floatBit.Status) {
+	switch um {
+	case floatBit.FlushToZero:
+		if signBit == 0 {
+			// Zero is less than any positive subnormal
+			return Bits(PositiveZero), big.Below, floatBit.Underflow
+		}
+		return Bits(NegativeZero), big.Above, floatBit.Underflow
+	case floatBit.SaturateMin:
+		if signBit == 0 {
+			// Min subnormal of E5M2 is larger than any number this function
+			// will be invoked for
+			return Bits(PositiveMinSubnormal), big.Above, floatBit.Underflow
+		}
+		return Bits(NegativeMinSubnormal), big.Below, floatBit.Underflow
+	default:
+		panic("Unsupported UnderflowMode encountered")
+	}
+}
+
+// Utility function that returns the result for the case when the conversion
+// results in overflow
+func handleOverflow(signBit uint32, om floatBit.OverflowMode) (Bits,
+	big.Accuracy, floatBit.Status) {
+	switch om {
+	case floatBit.SaturateInf:
+		if signBit == 0 {
+			// +Inf is greater than any other normal number
+			return Bits(PositiveInfinity), big.Above, floatBit.Overflow
+		}
+		return Bits(NegativeInfinity), big.Below, floatBit.Overflow
+	case floatBit.MakeNaN:
+		// The accuracy and status don't matter for this case.
+		if signBit == 0 {
+			return Bits(PositiveNaN), big.Above, floatBit.Overflow
+		}
+		return Bits(NegativeNaN), big.Below, floatBit.Overflow
+	case floatBit.SaturateMax:
+		if signBit == 0 {
+			// The maximum normal in E5M2 is smaller than any number
+			// this function will be invoked for
+			return Bits(PositiveMaxNormal), big.Below, floatBit.Overflow
+		}
+		return Bits(NegativeMaxNormal), big.Above, floatBit.Overflow
+	default:
+		panic("Unsupported OverflowMode encountered")
+	}
+}
+
+// ToFloatFormat converts the given Bits type representing the bits that make
+// up an E5M2 number into [floatBit.FloatBitFormat]
+// Implements the FloatBitFormatter Interface
+func (b *Bits) ToFloatFormat() floatBit.FloatBitFormat {
+	// Iterate over the bits and construct the return Values
+
+	asUint := uint8(*b)
+	signBits := (asUint & SignMask) >> 7
+	exponentBits := (asUint & ExponentMask) >> 2
+	mantissaBits := asUint & MantissaMask
+
+	// 1 Sign Bit
+	signRetVal := make([]byte, 0, 1)
+	if signBits == 0 {
+		signRetVal = append(signRetVal, byte('0'))
+	} else {
+		signRetVal = append(signRetVal, byte('1'))
+	}
+
+	// 5 Exponent Bits
+	exponentRetVal := make([]byte, 0, 5)
+	for i := 0; i < 5; i++ {
+		currentExponentBit := exponentBits & 0x1
+		var valueToAppend byte
+		if currentExponentBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		exponentRetVal = append(exponentRetVal, valueToAppend)
+		exponentBits >>= 1
+	}
+	slices.Reverse(exponentRetVal)
+
+	// 2 Mantissa Bits
+	mantissaRetVal := make([]byte, 0, 2)
+	for i := 0; i < 2; i++ {
+		currentMantissaBit := mantissaBits & 0x1
+		var valueToAppend byte
+		if currentMantissaBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		mantissaRetVal = append(mantissaRetVal, valueToAppend)
+		mantissaBits >>= 1
+	}
+	slices.Reverse(mantissaRetVal)
+
+	return floatBit.FloatBitFormat{Sign: signRetVal,
+		Exponent: exponentRetVal, Mantissa: mantissaRetVal}
+}
+
+// Conversion error returns the difference between the input [big.Float]
+// number and the E5M2 number represented by the bits in the [Bits] receiver
+func (b *Bits) ConversionError(input *big.Float) (big.Float, error) {
+	// If the receiver is a NaN then we return an error
+	asFloat32 := b.ToFloat32()
+	if math.IsNaN(float64(asFloat32)) {
+		return *big.NewFloat(0.0), errors.New("NaN encountered")
+	}
+
+	// Positive Infinity == Positive Infinity
+	if math.IsInf(float64(asFloat32), 1) &&
+		(input.IsInf() && (input.Sign() > 0)) {
+		return *big.NewFloat(0), nil
+	}
+
+	// Negative Infinity == Negative Infinity
+	if math.IsInf(float64(asFloat32), -1) &&
+		(input.IsInf() && (input.Sign() < 0)) {
+		return *big.NewFloat(0), nil
+	}
+
+	asBigFloat := b.ToBigFloat()
+	convDiff := asBigFloat.Sub(&asBigFloat, input)
+	return *convDiff, nil
+}
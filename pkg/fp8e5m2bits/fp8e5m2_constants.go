@@ -0,0 +1,32 @@
+package E5M2
+
+const (
+	SignMask     uint8 = 0b1_00000_00
+	ExponentMask uint8 = 0b0_11111_00
+	MantissaMask uint8 = 0b0_00000_11
+
+	PositiveInfinity uint8 = 0b0_11111_00
+	NegativeInfinity uint8 = 0b1_11111_00
+
+	PositiveMaxNormal uint8 = 0b0_11110_11
+	NegativeMaxNormal uint8 = 0b1_11110_11
+
+	PositiveZero uint8 = 0b0_00000_00
+	NegativeZero uint8 = 0b1_00000_00
+
+	PositiveMinSubnormal uint8 = 0b0_00000_01
+	NegativeMinSubnormal uint8 = 0b1_00000_01
+
+	// Just like in float16, all numbers with the exponent bits = 11111
+	// and mantissa bits not all zero, constitute the special NaN value
+	// The mantissa MSB is the quiet bit, and we use the quiet NaN with
+	// payload 0 as the flag NaN value, whenever we want to return one. When
+	// parsing, all these values will be treated as NaN
+	NaN         uint8 = 0b0_11111_10
+	PositiveNaN uint8 = 0b0_11111_10
+	NegativeNaN uint8 = 0b1_11111_10
+
+	ExponentBias int = 15
+	ExponentMin  int = -14
+	ExponentMax  int = 15
+)
@@ -0,0 +1,680 @@
+package E5M2
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	F16 "github.com/shantanu-gontia/float-conv/pkg/float16bits"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+)
+
+func TestToFloat32(t *testing.T) {
+	testCases := []struct {
+		// Input
+		input Bits
+		// Output
+		golden float32
+	}{
+		{
+			input:  0b0_01111_00,
+			golden: 1.0,
+		},
+		{
+			input:  0b1_01111_00,
+			golden: -1.0,
+		},
+		{
+			input:  0b0_11110_11,
+			golden: 57344.0,
+		},
+		{
+			input:  0b1_11110_11,
+			golden: -57344.0,
+		},
+		{
+			input:  0b0_01101_01,
+			golden: 0.3125,
+		},
+		{
+			input:  0,
+			golden: 0.0,
+		},
+		{
+			input:  0b1_00000_00,
+			golden: math.Float32frombits(F32.NegativeZero),
+		},
+		{
+			input:  0b0_00000_01,
+			golden: math.Float32frombits(0x37800000),
+		},
+		{
+			input:  0b0_00000_11,
+			golden: math.Float32frombits(0x38400000),
+		},
+		{
+			input:  0b1_00000_10,
+			golden: math.Float32frombits(0xb8000000),
+		},
+		{
+			input:  0b0_00001_00,
+			golden: math.Float32frombits(0x38800000),
+		},
+		{
+			input:  Bits(PositiveInfinity),
+			golden: float32(math.Inf(1)),
+		},
+		{
+			input:  Bits(NegativeInfinity),
+			golden: float32(math.Inf(-1)),
+		},
+	}
+	for _, tt := range testCases {
+		t.Run("ToFloat32", func(t *testing.T) {
+			result := tt.input.ToFloat32()
+			if math.Float32bits(result) != math.Float32bits(tt.golden) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("Input: %0#8b (%0#2x)", tt.input, tt.input)
+				t.Errorf("Expected Output: %f (%0#8x). Got: %f (%0#8x)", tt.golden, math.Float32bits(tt.golden), result, math.Float32bits(result))
+			}
+		})
+	}
+
+	// NaNs don't compare equal, so they're checked separately
+	for _, input := range []Bits{Bits(PositiveNaN), Bits(NegativeNaN), 0b0_11111_11} {
+		if result := input.ToFloat32(); !math.IsNaN(float64(result)) {
+			t.Errorf("Expected NaN for %0#2x, Got: %f", input, result)
+		}
+	}
+}
+
+func TestHandleOverflow(t *testing.T) {
+	testCases := []struct {
+		// In
+		signBit uint32
+		om      floatBit.OverflowMode
+		// Out
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{0, floatBit.SaturateInf, Bits(PositiveInfinity), big.Above, floatBit.Overflow},
+		{1, floatBit.SaturateInf, Bits(NegativeInfinity), big.Below, floatBit.Overflow},
+		{0, floatBit.MakeNaN, Bits(PositiveNaN), big.Above, floatBit.Overflow},
+		{1, floatBit.MakeNaN, Bits(NegativeNaN), big.Below, floatBit.Overflow},
+		{0, floatBit.SaturateMax, Bits(PositiveMaxNormal), big.Below, floatBit.Overflow},
+		{1, floatBit.SaturateMax, Bits(NegativeMaxNormal), big.Above, floatBit.Overflow},
+		{200, floatBit.SaturateMax, Bits(NegativeMaxNormal), big.Above, floatBit.Overflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run("HandleOverflow", func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := handleOverflow(tt.signBit, tt.om)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("SignBit: %v\tOverflowMode: %v\n", tt.signBit, tt.om)
+				t.Errorf("Expected Result: %0#2x, Got: %0#2x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+func TestHandleUnderflow(t *testing.T) {
+	testCases := []struct {
+		// In
+		signBit uint32
+		um      floatBit.UnderflowMode
+		// Out
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{0, floatBit.FlushToZero, Bits(PositiveZero), big.Below, floatBit.Underflow},
+		{1, floatBit.FlushToZero, Bits(NegativeZero), big.Above, floatBit.Underflow},
+		{0, floatBit.SaturateMin, Bits(PositiveMinSubnormal), big.Above, floatBit.Underflow},
+		{1, floatBit.SaturateMin, Bits(NegativeMinSubnormal), big.Below, floatBit.Underflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run("HandleUnderflow", func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := handleUnderflow(tt.signBit, tt.um)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("SignBit: %v\tUnderflowMode: %v\n", tt.signBit, tt.um)
+				t.Errorf("Expected Result: %0#2x, Got: %0#2x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+func TestCheckOverflow(t *testing.T) {
+	testCases := []struct {
+		// Inputs
+		actualExponent int
+		mantissaBits   uint32
+		// Outputs
+		golden bool
+	}{
+		{
+			actualExponent: 14,
+			mantissaBits:   0b0_00000000_11_000000000000000000001,
+			golden:         false,
+		},
+		{
+			actualExponent: 16,
+			mantissaBits:   0b0_00000000_00_000000000000000000000,
+			golden:         true,
+		},
+		{
+			actualExponent: 15,
+			mantissaBits:   0b0_00000000_11_000000000000000000000,
+			golden:         false,
+		},
+		{
+			actualExponent: 15,
+			mantissaBits:   0b0_00000000_11_000000000000000000001,
+			golden:         true,
+		},
+		{
+			actualExponent: 15,
+			mantissaBits:   0b0_00000000_10_111111111111111111111,
+			golden:         false,
+		},
+	}
+
+	for _, tt := range testCases {
+		result := checkOverflow(tt.actualExponent, tt.mantissaBits)
+		if result != tt.golden {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Exponent: %d Mantissa Bits: %0#8x", tt.actualExponent, tt.mantissaBits)
+			t.Errorf("Expected: %v, Got: %v", tt.golden, result)
+		}
+	}
+}
+
+func TestCheckUnderflow(t *testing.T) {
+	testCases := []struct {
+		// Inputs
+		mantissaBits  uint32
+		lostPrecision bool
+		// Outputs
+		golden bool
+	}{
+		{
+			mantissaBits:  0b0_00000000_00_100000000000000000001,
+			lostPrecision: false,
+			golden:        true,
+		},
+		{
+			mantissaBits:  0b0_00000000_01_000000000000000000001,
+			lostPrecision: false,
+			golden:        false,
+		},
+		{
+			mantissaBits:  0b0_00000000_00_000000000000000000000,
+			lostPrecision: true,
+			golden:        true,
+		},
+		{
+			mantissaBits:  0b0_00000000_11_000000000000000000000,
+			lostPrecision: true,
+			golden:        false,
+		},
+	}
+
+	for _, tt := range testCases {
+		result := checkUnderflow(tt.mantissaBits, tt.lostPrecision)
+		if result != tt.golden {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Mantissa Bits: %0#8x", tt.mantissaBits)
+			t.Logf("Lost Precision?: %v", tt.lostPrecision)
+			t.Errorf("Expected: %v, Got: %v", tt.golden, result)
+		}
+	}
+}
+
+// Test case shared by all the rounding function tests
+type roundTestCase struct {
+	// Inputs
+	signBit       uint32
+	exponentBits  uint32
+	mantissaBits  uint32
+	lostPrecision bool
+	// Outputs
+	goldenVal Bits
+	goldenAcc big.Accuracy
+}
+
+func runRoundTests(t *testing.T, name string,
+	roundFunc func(uint32, uint32, uint32, bool) (Bits, big.Accuracy),
+	testCases []roundTestCase) {
+	for _, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resultVal, resultAcc := roundFunc(tt.signBit, tt.exponentBits, tt.mantissaBits, tt.lostPrecision)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("signBit: %#08x, exponentBits: %#08x, mantissaBits: %#08x", tt.signBit, tt.exponentBits, tt.mantissaBits)
+				t.Logf("lostPrecision: %v", tt.lostPrecision)
+				t.Errorf("Expected Result: %0#2x, Got: %0#2x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+			}
+		})
+	}
+}
+
+func TestRoundTowardsZero(t *testing.T) {
+	runRoundTests(t, "RoundTowardsZero", roundTowardsZero, []roundTestCase{
+		// +Zero -> +Zero
+		{0, 0, 0, false, Bits(0x0), big.Exact},
+		// Exact
+		{0, 15, 0b0_00000000_01_000000000000000000000, false, Bits(0b0_01111_01), big.Exact},
+		// Positive RTZ to below
+		{0, 15, 0b0_00000000_01_110000000000000000000, false, Bits(0b0_01111_01), big.Below},
+		// Negative RTZ to above
+		{1, 1, 0b0_00000000_10_100000000000000000001, false, Bits(0b1_00001_10), big.Above},
+		// Lost Precision before got passed into func
+		{0, 0, 0b0_00000000_11_000000000000000000000, true, Bits(0b0_00000_11), big.Below},
+	})
+}
+
+func TestRoundTowardsPositiveInf(t *testing.T) {
+	runRoundTests(t, "RoundTowardsPositiveInf", roundTowardsPositiveInf, []roundTestCase{
+		{0, 15, 0b0_00000000_01_000000000000000000000, false, Bits(0b0_01111_01), big.Exact},
+		// Positive rounds up
+		{0, 15, 0b0_00000000_01_000000000000000000001, false, Bits(0b0_01111_10), big.Above},
+		// Negative truncates
+		{1, 15, 0b0_00000000_01_111111111111111111111, false, Bits(0b1_01111_01), big.Above},
+		// Carry into the exponent
+		{0, 15, 0b0_00000000_11_000000000000000000000, true, Bits(0b0_10000_00), big.Above},
+	})
+}
+
+func TestRoundTowardsNegativeInf(t *testing.T) {
+	runRoundTests(t, "RoundTowardsNegativeInf", roundTowardsNegativeInf, []roundTestCase{
+		{1, 15, 0b0_00000000_01_000000000000000000000, false, Bits(0b1_01111_01), big.Exact},
+		// Positive truncates
+		{0, 15, 0b0_00000000_01_111111111111111111111, false, Bits(0b0_01111_01), big.Below},
+		// Negative rounds up in magnitude
+		{1, 15, 0b0_00000000_01_000000000000000000001, false, Bits(0b1_01111_10), big.Below},
+		// Subnormal rounding up to the smallest normal
+		{1, 0, 0b0_00000000_11_000000000000000000000, true, Bits(0b1_00001_00), big.Below},
+	})
+}
+
+func TestRoundHalfTowardsZero(t *testing.T) {
+	runRoundTests(t, "RoundHalfTowardsZero", roundHalfTowardsZero, []roundTestCase{
+		// Below half truncates
+		{0, 15, 0b0_00000000_01_011111111111111111111, false, Bits(0b0_01111_01), big.Below},
+		// Above half rounds up
+		{0, 15, 0b0_00000000_01_100000000000000000001, false, Bits(0b0_01111_10), big.Above},
+		// Ties truncate
+		{0, 15, 0b0_00000000_01_100000000000000000000, false, Bits(0b0_01111_01), big.Below},
+		{1, 15, 0b0_00000000_01_100000000000000000000, false, Bits(0b1_01111_01), big.Above},
+		// Not a tie, if precision was lost before
+		{1, 0, 0b0_00000000_01_100000000000000000000, true, Bits(0b1_00000_10), big.Below},
+	})
+}
+
+func TestRoundHalfTowardsPositiveInf(t *testing.T) {
+	runRoundTests(t, "RoundHalfTowardsPositiveInf", roundHalfTowardsPositiveInf, []roundTestCase{
+		{0, 15, 0b0_00000000_01_011111111111111111111, false, Bits(0b0_01111_01), big.Below},
+		{1, 15, 0b0_00000000_01_100000000000000000001, false, Bits(0b1_01111_10), big.Below},
+		// Ties round towards +inf
+		{0, 15, 0b0_00000000_01_100000000000000000000, false, Bits(0b0_01111_10), big.Above},
+		{1, 15, 0b0_00000000_01_100000000000000000000, false, Bits(0b1_01111_01), big.Above},
+	})
+}
+
+func TestRoundHalfTowardsNegativeInf(t *testing.T) {
+	runRoundTests(t, "RoundHalfTowardsNegativeInf", roundHalfTowardsNegativeInf, []roundTestCase{
+		{0, 15, 0b0_00000000_01_011111111111111111111, false, Bits(0b0_01111_01), big.Below},
+		{0, 15, 0b0_00000000_01_100000000000000000001, false, Bits(0b0_01111_10), big.Above},
+		// Ties round towards -inf
+		{0, 15, 0b0_00000000_01_100000000000000000000, false, Bits(0b0_01111_01), big.Below},
+		{1, 15, 0b0_00000000_01_100000000000000000000, false, Bits(0b1_01111_10), big.Below},
+	})
+}
+
+func TestRoundNearestEven(t *testing.T) {
+	runRoundTests(t, "RoundNearestEven", roundNearestEven, []roundTestCase{
+		{0, 15, 0b0_00000000_01_011111111111111111111, false, Bits(0b0_01111_01), big.Below},
+		{1, 15, 0b0_00000000_00_100000000000000000001, false, Bits(0b1_01111_01), big.Below},
+		// Ties round to the even value
+		{0, 15, 0b0_00000000_01_100000000000000000000, false, Bits(0b0_01111_10), big.Above},
+		{0, 15, 0b0_00000000_00_100000000000000000000, false, Bits(0b0_01111_00), big.Below},
+		{1, 15, 0b0_00000000_10_100000000000000000000, false, Bits(0b1_01111_10), big.Above},
+		// Not a tie, if precision was lost before
+		{0, 15, 0b0_00000000_00_100000000000000000000, true, Bits(0b0_01111_01), big.Above},
+		// Carry into the exponent
+		{0, 15, 0b0_00000000_11_100000000000000000000, false, Bits(0b0_10000_00), big.Above},
+	})
+}
+
+func TestRoundNearestOdd(t *testing.T) {
+	runRoundTests(t, "RoundNearestOdd", roundNearestOdd, []roundTestCase{
+		{0, 15, 0b0_00000000_01_011111111111111111111, false, Bits(0b0_01111_01), big.Below},
+		{1, 15, 0b0_00000000_00_100000000000000000001, false, Bits(0b1_01111_01), big.Below},
+		// Ties round to the odd value
+		{0, 15, 0b0_00000000_01_100000000000000000000, false, Bits(0b0_01111_01), big.Below},
+		{0, 15, 0b0_00000000_00_100000000000000000000, false, Bits(0b0_01111_01), big.Above},
+		{1, 15, 0b0_00000000_10_100000000000000000000, false, Bits(0b1_01111_11), big.Below},
+		// Not a tie, if precision was lost before
+		{0, 15, 0b0_00000000_01_100000000000000000000, true, Bits(0b0_01111_10), big.Above},
+	})
+}
+
+func TestFromBigFloat(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input big.Float
+		rm    floatBit.RoundingMode
+		um    floatBit.UnderflowMode
+		om    floatBit.OverflowMode
+		// Outputs
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{
+			name:         "PosInfInput",
+			input:        *big.NewFloat(math.Inf(1)),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveInfinity),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegInfInput",
+			input:        *big.NewFloat(math.Inf(-1)),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(NegativeInfinity),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegZeroInput",
+			input:        *big.NewFloat(float64(math.Float32frombits(F32.NegativeZero))),
+			rm:           floatBit.RoundHalfTowardsNegativeInf,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateInf,
+			goldenVal:    Bits(NegativeZero),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "MaxNormalExact",
+			input:        *big.NewFloat(57344),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.MakeNaN,
+			goldenVal:    Bits(PositiveMaxNormal),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NormalNumberRNE",
+			input:        *big.NewFloat(0.3),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b0_01101_01),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NormalNumberRTZ",
+			input:        *big.NewFloat(-0.3),
+			rm:           floatBit.RoundTowardsZero,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b1_01101_00),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "SubnormalRNE",
+			input:        *big.NewFloat(0.00005),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b0_00000_11),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "SubnormalRTPosInf",
+			input:        *big.NewFloat(0.00005),
+			rm:           floatBit.RoundTowardsPositiveInf,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b0_00001_00),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "PositiveOverflowToMax",
+			input:        *big.NewFloat(60000),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMaxNormal),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Overflow,
+		},
+		{
+			name:         "NegativeOverflowToNaN",
+			input:        *big.NewFloat(-1e5),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.MakeNaN,
+			goldenVal:    Bits(NegativeNaN),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Overflow,
+		},
+		{
+			name:         "PositiveOverflowSatInf",
+			input:        *big.NewFloat(1e5),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateInf,
+			goldenVal:    Bits(PositiveInfinity),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Overflow,
+		},
+		{
+			name:         "PositiveUnderflowSatMin",
+			input:        *big.NewFloat(1e-6),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMinSubnormal),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
+		{
+			name:         "NegativeUnderflowFlushZero",
+			input:        *big.NewFloat(-1e-46),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(NegativeZero),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
+	}
+
+	for _, tt := range testCases {
+		resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessBeforeRounding)
+		if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Name: %s", tt.name)
+			t.Logf("Value: %s", tt.input.String())
+			t.Logf("Rounding Mode: %v", tt.rm)
+			t.Logf("Overflow Mode: %v", tt.om)
+			t.Logf("Underflow Mode: %v", tt.um)
+			t.Errorf("Expected result: %.10e (%0#2x), Got: %.10e (%0#2x)", tt.goldenVal.ToFloat32(), tt.goldenVal, resultVal.ToFloat32(), resultVal)
+			t.Errorf("Expect accuracy: %v, Got: %v", tt.goldenAcc, resultAcc)
+			t.Errorf("Expected status: %v, Got: %v", tt.goldenStatus, resultStatus)
+		}
+	}
+}
+
+func TestFromFloat16Bits(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input F16.Bits
+		rm    floatBit.RoundingMode
+		um    floatBit.UnderflowMode
+		om    floatBit.OverflowMode
+		// Outputs
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{
+			name:         "Exact",
+			input:        F16.Bits(0b0_01111_0100000000),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b0_01111_01),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegInf",
+			input:        F16.Bits(F16.NegativeInfinity),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(NegativeInfinity),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "TieRNE",
+			input:        F16.Bits(0b0_01111_0110000000),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b0_01111_10),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegativeRTZ",
+			input:        F16.Bits(0b1_00010_1011111111),
+			rm:           floatBit.RoundTowardsZero,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b1_00010_10),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "SubnormalRTPosInf",
+			input:        F16.Bits(0b0_00000_1100000001),
+			rm:           floatBit.RoundTowardsPositiveInf,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0b0_00001_00),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "Underflow",
+			input:        F16.Bits(0b1_00000_0011111111),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(NegativeMinSubnormal),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Underflow,
+		},
+		{
+			name:         "Overflow",
+			input:        F16.Bits(0b0_11110_1100000001),
+			rm:           floatBit.RoundTowardsZero,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateInf,
+			goldenVal:    Bits(PositiveInfinity),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Overflow,
+		},
+	}
+
+	for _, tt := range testCases {
+		resultVal, resultAcc, resultStatus := FromFloat16Bits(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessBeforeRounding)
+		if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Name: %s", tt.name)
+			t.Logf("Value: %0#4x", tt.input)
+			t.Logf("Rounding Mode: %v", tt.rm)
+			t.Logf("Overflow Mode: %v", tt.om)
+			t.Logf("Underflow Mode: %v", tt.um)
+			t.Errorf("Expected result: %0#2x, Got: %0#2x", tt.goldenVal, resultVal)
+			t.Errorf("Expect accuracy: %v, Got: %v", tt.goldenAcc, resultAcc)
+			t.Errorf("Expected status: %v, Got: %v", tt.goldenStatus, resultStatus)
+		}
+	}
+
+	// NaNs stay NaNs
+	if result, _, _ := FromFloat16Bits(F16.Bits(F16.NaN), floatBit.RoundNearestEven,
+		floatBit.SaturateMax, floatBit.FlushToZero, floatBit.TininessBeforeRounding); !math.IsNaN(float64(result.ToFloat32())) {
+		t.Errorf("Expected NaN, Got: %0#2x", result)
+	}
+	if result, _, _ := FromFloat16Bits(F16.Bits(0xfe00), floatBit.RoundNearestEven,
+		floatBit.SaturateMax, floatBit.FlushToZero, floatBit.TininessBeforeRounding); result != Bits(NegativeNaN) {
+		t.Errorf("Expected: %0#2x, Got: %0#2x", NegativeNaN, result)
+	}
+	// The payload of a signaling NaN is dropped, and the result is quiet
+	if result, _, _ := FromFloat16Bits(F16.Bits(0xfc2a), floatBit.RoundNearestEven,
+		floatBit.SaturateMax, floatBit.FlushToZero, floatBit.TininessBeforeRounding); result != Bits(NegativeNaN) {
+		t.Errorf("Expected: %0#2x, Got: %0#2x", NegativeNaN, result)
+	}
+	// FromFloat32 canonicalizes NaNs, dropping the sign too
+	if result, _, _ := FromFloat32(math.Float32frombits(0xff80_002a), floatBit.RoundNearestEven,
+		floatBit.SaturateMax, floatBit.FlushToZero, floatBit.TininessBeforeRounding); result != Bits(NaN) {
+		t.Errorf("Expected: %0#2x, Got: %0#2x", NaN, result)
+	}
+
+	// 1.3125 is a quarter of the way from 1.25 to 1.5, so stochastic rounding
+	// rounds up if the random bits are below 2^62
+	for _, tt := range []struct {
+		rb        floatBit.ExplicitRandomBits
+		goldenVal Bits
+		goldenAcc big.Accuracy
+	}{
+		{0, Bits(0b0_01111_10), big.Above},
+		{1<<62 - 1, Bits(0b0_01111_10), big.Above},
+		{1 << 62, Bits(0b0_01111_01), big.Below},
+	} {
+		resultVal, resultAcc, resultStatus := FromFloat16Bits(F16.Bits(0b0_01111_0101000000),
+			floatBit.RoundStochastic, floatBit.SaturateMax, floatBit.SaturateMin,
+			floatBit.TininessBeforeRounding, tt.rb)
+		if resultVal != tt.goldenVal || resultAcc != tt.goldenAcc || resultStatus != floatBit.Fits {
+			t.Errorf("Random bits: %#x Expected: %0#2x (%v, fits), Got: %0#2x (%v, %v)", uint64(tt.rb),
+				tt.goldenVal, tt.goldenAcc, resultVal, resultAcc, resultStatus)
+		}
+	}
+}
+
+func TestToFloatFormat(t *testing.T) {
+	input := Bits(0b1_01101_01)
+	result := input.ToFloatFormat()
+	if string(result.Sign) != "1" || string(result.Exponent) != "01101" ||
+		string(result.Mantissa) != "01" {
+		t.Errorf("Expected Sign: 1, Exponent: 01101, Mantissa: 01. Got: %v", result)
+	}
+}
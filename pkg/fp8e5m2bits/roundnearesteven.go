@@ -0,0 +1,77 @@
+package E5M2
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number rounded to the closest E5M2
+// value. Ties are broken by rounding to the even value (the LSB mantissa
+// bit is 0)
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E5M2 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundNearestEven(signBit, exponentBits, mantissaBits uint32,
+	lostPrecision bool) (Bits, big.Accuracy) {
+
+	// For rounding to nearest even, we round to the number that is closest and
+	// break ties by rounding towards the number that is even (LSB is 0)
+
+	// LSB  |  Extra Precision Bits
+	//  m21    m20 m19 m18
+	// 1. if m20 m19 m18 ... > 1 0 0 0 ... (more than half) we round up
+	// 2. if m20 m19 m18 ... < 1 0 0 0 ... (less than half) we truncate
+	// 3. if m20 m19 m18 ... == 1 0 0 0 ... (exactly half), then
+	// 	  3.1 m21 == 0, we truncate
+	//    3.2 m21 == 1, we round up
+
+	mantissaE5M2Precision := mantissaBits & f32E5M2MantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E5M2HalfSubnormalMask
+
+	e5m2Sign := uint8(signBit << 7)
+	e5m2Exponent := uint8(exponentBits << 2)
+	e5m2Mantissa := uint8(mantissaE5M2Precision >> 21)
+
+	exponentMantissaComposite := e5m2Exponent | e5m2Mantissa
+
+	addedOne := false
+	// We definitely add 1, if we're greater than the mid-point
+	if mantissaExtraPrecision > f32E5M2HalfSubnormalLSB {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// If extra precision was lost before, then we need to add one if we're
+	// halfway through in the adjusted mantissa (because this means we're
+	// actually greater than the midpoint)
+	if mantissaExtraPrecision == f32E5M2HalfSubnormalLSB && lostPrecision {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	mantissaE5M2LSB := mantissaBits & f32E5M2SubnormalLSB
+	// In the case we're at the mid-point, we only add 1, if the LSB of the
+	// E5M2 retained mantissa is 1
+	if (mantissaE5M2LSB != 0) && (mantissaExtraPrecision ==
+		f32E5M2HalfSubnormalLSB) && !lostPrecision {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// For all other case we truncate, so now we can construct the result
+	// by attaching the sign
+	resultVal := Bits(e5m2Sign | exponentMantissaComposite)
+	resultAcc := big.Exact
+
+	// Result is larger if the input was positive and we added 1, or
+	// if the input was negative and we truncated.
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		resultAcc = big.Below
+		if (e5m2Sign == 0) == addedOne {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,26 @@
+package E5M2FNUZ
+
+const (
+	SignMask     uint8 = 0b1_00000_00
+	ExponentMask uint8 = 0b0_11111_00
+	MantissaMask uint8 = 0b0_00000_11
+
+	PositiveMaxNormal uint8 = 0b0_11111_11
+	NegativeMaxNormal uint8 = 0b1_11111_11
+
+	// There is no negative zero in E5M2FNUZ. Its encoding is used for NaN
+	PositiveZero uint8 = 0b0_00000_00
+
+	PositiveMinSubnormal uint8 = 0b0_00000_01
+	NegativeMinSubnormal uint8 = 0b1_00000_01
+
+	// The E5M2FNUZ format has no encodings for infinities, and exactly one
+	// NaN encoding, which is the bit pattern that would otherwise be negative
+	// zero. Unlike the other formats, NaNs here are unsigned, so there are
+	// no separate PositiveNaN and NegativeNaN values
+	NaN uint8 = 0b1_00000_00
+
+	ExponentBias int = 16
+	ExponentMin  int = -15
+	ExponentMax  int = 15
+)
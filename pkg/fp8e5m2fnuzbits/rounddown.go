@@ -0,0 +1,51 @@
+package E5M2FNUZ
+
+import "math/big"
+
+// Utility function that returns the number rounded to a number that is
+// representable in E5M2FNUZ. If y is the input number and x < y < x + 1ULP
+// where x is a E5M2FNUZ number. Then this rounding mode picks up x
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the E5M2FNUZ bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+// The parameter lostPrecision indicates whether the mantissa passed had already
+// lost precision during any preprocessing
+func roundTowardsNegativeInf(signBit, exponentBits,
+	mantissaBits uint32, lostPrecision bool) (Bits, big.Accuracy) {
+	return roundDown(signBit, exponentBits, mantissaBits, lostPrecision)
+}
+
+func roundDown(signBit, exponentBits,
+	mantissaBits uint32, lostPrecision bool) (Bits, big.Accuracy) {
+
+	mantissaE5M2FNUZPrecision := mantissaBits & f32E5M2FNUZMantissaMask
+	mantissaExtraPrecision := mantissaBits & f32E5M2FNUZHalfSubnormalMask
+
+	e5m2fnuzSign := uint8(signBit << 7)
+	e5m2fnuzExponent := uint8(exponentBits << 2)
+	e5m2fnuzMantissa := uint8(mantissaE5M2FNUZPrecision >> 21)
+
+	// For this rounding mode, we only need to add 1 to the Least-precision
+	// mantissa, if the input was negative, to bring it closer to -inf.
+	// For positive numbers, this is achieved by simply truncating.
+
+	exponentMantissaComposite := (e5m2fnuzExponent | e5m2fnuzMantissa)
+
+	// If negative and there is extra precision, then add 1
+	if (e5m2fnuzSign != 0) && (mantissaExtraPrecision != 0 || lostPrecision) {
+		exponentMantissaComposite += 1
+	}
+	// Since, we don't handle overflow, all we need to do now is attach the sign
+	resultVal := Bits(e5m2fnuzSign | exponentMantissaComposite)
+
+	resultAcc := big.Exact
+	// If there was extra precision bits set, then we need to
+	if mantissaExtraPrecision != 0 || lostPrecision {
+		// We always round to a smaller value
+		resultAcc = big.Below
+	}
+
+	return resultVal, resultAcc
+}
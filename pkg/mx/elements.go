@@ -0,0 +1,267 @@
+package MX
+
+import (
+	"errors"
+	"math"
+	"math/big"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	E2M1 "github.com/shantanu-gontia/float-conv/pkg/fp4e2m1bits"
+	E2M3 "github.com/shantanu-gontia/float-conv/pkg/fp6e2m3bits"
+	E3M2 "github.com/shantanu-gontia/float-conv/pkg/fp6e3m2bits"
+	E4M3 "github.com/shantanu-gontia/float-conv/pkg/fp8e4m3bits"
+	E5M2 "github.com/shantanu-gontia/float-conv/pkg/fp8e5m2bits"
+)
+
+// ElementFormat is an enum that encodes the format of the per-element payloads
+// of an MX block, as defined in the OCP Microscaling Formats (MX) v1.0 spec
+type ElementFormat uint8
+
+const (
+	MXFP8E4M3 ElementFormat = 0
+	MXFP8E5M2 ElementFormat = 1
+	MXFP6E2M3 ElementFormat = 2
+	MXFP6E3M2 ElementFormat = 3
+	MXFP4E2M1 ElementFormat = 4
+	MXINT8    ElementFormat = 5
+)
+
+// Stringer interface for ElementFormat
+func (f ElementFormat) String() string {
+	switch f {
+	case MXFP8E4M3:
+		return "MXFP8 E4M3"
+	case MXFP8E5M2:
+		return "MXFP8 E5M2"
+	case MXFP6E2M3:
+		return "MXFP6 E2M3"
+	case MXFP6E3M2:
+		return "MXFP6 E3M2"
+	case MXFP4E2M1:
+		return "MXFP4 E2M1"
+	case MXINT8:
+		return "MXINT8"
+	default:
+		return ""
+	}
+}
+
+// Returns the number of bits in each element of the format
+func (f ElementFormat) Width() int {
+	switch f {
+	case MXFP8E4M3, MXFP8E5M2, MXINT8:
+		return 8
+	case MXFP6E2M3, MXFP6E3M2:
+		return 6
+	case MXFP4E2M1:
+		return 4
+	default:
+		panic("Unsupported ElementFormat encountered")
+	}
+}
+
+// Returns the exponent of the largest normal number of the element format.
+// This is the emax_elem used by the scale selection rule. For MXINT8, the
+// elements are fixed-point numbers in [-2, 2), so this is 0
+func (f ElementFormat) emax() int {
+	switch f {
+	case MXFP8E4M3:
+		return 8
+	case MXFP8E5M2:
+		return 15
+	case MXFP6E2M3:
+		return 2
+	case MXFP6E3M2:
+		return 4
+	case MXFP4E2M1:
+		return 2
+	case MXINT8:
+		return 0
+	default:
+		panic("Unsupported ElementFormat encountered")
+	}
+}
+
+const (
+	// MXINT8 elements are two's complement integers with an implicit scale of
+	// 2^-6
+	int8ScaleExponent int = -6
+	// The MX spec allows implementations to keep MXINT8 symmetric, which we
+	// do, so -128 (-2.0) is never produced
+	int8Max int64 = 127
+)
+
+// Quantize the given value (already divided by the shared scale) to the bits
+// of an element of the format f
+func (f ElementFormat) encode(input *big.Float, rm floatBit.RoundingMode,
+	rb ...floatBit.RandomBits) (uint8, big.Accuracy, floatBit.Status) {
+	// MX requires that elements which don't fit are clamped to the maximum
+	// normal, so the overflow mode is always [floatBit.SaturateMax]. Elements
+	// below the minimum subnormal are rounded with rm like any other element,
+	// so the underflow mode is always [floatBit.RoundToSubnormal]
+	switch f {
+	case MXFP8E4M3:
+		result, acc, status := E4M3.FromBigFloat(*input, rm, floatBit.SaturateMax,
+			floatBit.RoundToSubnormal, floatBit.TininessBeforeRounding, rb...)
+		return uint8(result), acc, status
+	case MXFP8E5M2:
+		result, acc, status := E5M2.FromBigFloat(*input, rm, floatBit.SaturateMax,
+			floatBit.RoundToSubnormal, floatBit.TininessBeforeRounding, rb...)
+		return uint8(result), acc, status
+	case MXFP6E2M3:
+		result, acc, status := E2M3.FromBigFloat(*input, rm, floatBit.SaturateMax,
+			floatBit.RoundToSubnormal, floatBit.TininessBeforeRounding, rb...)
+		return uint8(result), acc, status
+	case MXFP6E3M2:
+		result, acc, status := E3M2.FromBigFloat(*input, rm, floatBit.SaturateMax,
+			floatBit.RoundToSubnormal, floatBit.TininessBeforeRounding, rb...)
+		return uint8(result), acc, status
+	case MXFP4E2M1:
+		result, acc, status := E2M1.FromBigFloat(*input, rm, floatBit.SaturateMax,
+			floatBit.RoundToSubnormal, floatBit.TininessBeforeRounding, rb...)
+		return uint8(result), acc, status
+	case MXINT8:
+		return encodeInt8(input, rm, rb...)
+	default:
+		panic("Unsupported ElementFormat encountered")
+	}
+}
+
+// Convert the element bits of the format f to the number they represent
+// (without the shared scale). Returns an error if the bits represent a NaN or
+// an infinity
+func (f ElementFormat) decode(bits uint8) (big.Float, error) {
+	switch f {
+	case MXFP8E4M3:
+		asFloat32 := E4M3.Bits(bits).ToFloat32()
+		if math.IsNaN(float64(asFloat32)) {
+			return *big.NewFloat(0), errors.New("NaN encountered")
+		}
+		return E4M3.Bits(bits).ToBigFloat(), nil
+	case MXFP8E5M2:
+		asFloat32 := E5M2.Bits(bits).ToFloat32()
+		if math.IsNaN(float64(asFloat32)) || math.IsInf(float64(asFloat32), 0) {
+			return *big.NewFloat(0), errors.New("NaN or Inf encountered")
+		}
+		return E5M2.Bits(bits).ToBigFloat(), nil
+	case MXFP6E2M3:
+		return E2M3.Bits(bits).ToBigFloat(), nil
+	case MXFP6E3M2:
+		return E3M2.Bits(bits).ToBigFloat(), nil
+	case MXFP4E2M1:
+		return E2M1.Bits(bits).ToBigFloat(), nil
+	case MXINT8:
+		var result big.Float
+		result.SetMantExp(new(big.Float).SetInt64(int64(int8(bits))),
+			int8ScaleExponent)
+		return result, nil
+	default:
+		panic("Unsupported ElementFormat encountered")
+	}
+}
+
+// Convert the element bits of the format f into [floatBit.FloatBitFormat].
+// MXINT8 elements are two's complement, so their top bit is shown as the sign
+// and the remaining bits as the mantissa
+func (f ElementFormat) ToFloatFormat(bits uint8) floatBit.FloatBitFormat {
+	switch f {
+	case MXFP8E4M3:
+		asBits := E4M3.Bits(bits)
+		return asBits.ToFloatFormat()
+	case MXFP8E5M2:
+		asBits := E5M2.Bits(bits)
+		return asBits.ToFloatFormat()
+	case MXFP6E2M3:
+		asBits := E2M3.Bits(bits)
+		return asBits.ToFloatFormat()
+	case MXFP6E3M2:
+		asBits := E3M2.Bits(bits)
+		return asBits.ToFloatFormat()
+	case MXFP4E2M1:
+		asBits := E2M1.Bits(bits)
+		return asBits.ToFloatFormat()
+	case MXINT8:
+		return floatBit.FloatBitFormat{Sign: bitsToBytes(bits>>7, 1),
+			Exponent: []byte{}, Mantissa: bitsToBytes(bits, 7)}
+	default:
+		panic("Unsupported ElementFormat encountered")
+	}
+}
+
+// Quantize the given value to an MXINT8 element. Values outside of the
+// symmetric range are clamped and report overflow
+func encodeInt8(input *big.Float, rm floatBit.RoundingMode,
+	rb ...floatBit.RandomBits) (uint8, big.Accuracy, floatBit.Status) {
+	var scaled big.Float
+	scaled.SetMantExp(input, -int8ScaleExponent)
+	roundedInt, acc := roundToInteger(&scaled, rm, rb...)
+	if roundedInt.IsInt64() {
+		asInt64 := roundedInt.Int64()
+		if asInt64 >= -int8Max && asInt64 <= int8Max {
+			return uint8(int8(asInt64)), acc, floatBit.Fits
+		}
+	}
+	if input.Sign() > 0 {
+		return uint8(int8Max), big.Below, floatBit.Overflow
+	}
+	negativeMax := int8(-int8Max)
+	return uint8(negativeMax), big.Above, floatBit.Overflow
+}
+
+// Round the given number to an integer using the rounding mode rm. Returns the
+// rounded integer, and the accuracy of the result compared to the input.
+// [floatBit.RoundStochastic] needs rb
+func roundToInteger(input *big.Float, rm floatBit.RoundingMode,
+	rb ...floatBit.RandomBits) (*big.Int, big.Accuracy) {
+	// Int truncates towards zero
+	truncated, acc := input.Int(nil)
+	if acc == big.Exact {
+		return truncated, acc
+	}
+
+	// Compare the discarded fraction to half, to decide the nearest modes
+	var fraction big.Float
+	fraction.Sub(input, new(big.Float).SetInt(truncated))
+	fraction.Abs(&fraction)
+	halfCmp := fraction.Cmp(big.NewFloat(0.5))
+
+	isPositive := input.Sign() > 0
+	isOdd := truncated.Bit(0) == 1
+
+	// Whether to move away from zero (increase the magnitude)
+	var roundAway bool
+	switch rm {
+	case floatBit.RoundTowardsZero:
+		roundAway = false
+	case floatBit.RoundTowardsPositiveInf:
+		roundAway = isPositive
+	case floatBit.RoundTowardsNegativeInf:
+		roundAway = !isPositive
+	case floatBit.RoundHalfTowardsZero:
+		roundAway = halfCmp > 0
+	case floatBit.RoundHalfTowardsPositiveInf:
+		roundAway = halfCmp > 0 || (halfCmp == 0 && isPositive)
+	case floatBit.RoundHalfTowardsNegativeInf:
+		roundAway = halfCmp > 0 || (halfCmp == 0 && !isPositive)
+	case floatBit.RoundNearestEven:
+		roundAway = halfCmp > 0 || (halfCmp == 0 && isOdd)
+	case floatBit.RoundNearestOdd:
+		roundAway = halfCmp > 0 || (halfCmp == 0 && !isOdd)
+	case floatBit.RoundAwayFromZero:
+		roundAway = true
+	case floatBit.RoundHalfAwayFromZero:
+		roundAway = halfCmp >= 0
+	case floatBit.RoundStochastic:
+		roundAway = floatBit.StochasticRoundAway(&fraction, rb...)
+	default:
+		panic("Unsupported RoundingMode encountered")
+	}
+
+	if !roundAway {
+		return truncated, acc
+	}
+	if isPositive {
+		return truncated.Add(truncated, big.NewInt(1)), big.Above
+	}
+	return truncated.Sub(truncated, big.NewInt(1)), big.Below
+}
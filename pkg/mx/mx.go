@@ -0,0 +1,167 @@
+package MX
+
+import (
+	"errors"
+	"math/big"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+// Number of elements that share a single scale in an MX block
+const BlockSize int = 32
+
+// Block represents an OCP Microscaling (MX) block: up to [BlockSize] elements
+// of the same [ElementFormat] which share a single E8M0 scale. The value of
+// the i-th element is Scale * Elements[i]. The element bits are stored in the
+// lowest [ElementFormat.Width] bits of each entry of Elements
+type Block struct {
+	Format   ElementFormat
+	Scale    ScaleBits
+	Elements []uint8
+}
+
+// Report contains information about how accurately a [Block] represents the
+// values that were quantized into it
+type Report struct {
+	// Accuracy of each dequantized element, compared to its input
+	Accuracy []big.Accuracy
+	// Whether each element fit in the element format, or overflowed or
+	// underflowed
+	Status []floatBit.Status
+	// Difference between each dequantized element and its input
+	Error []big.Float
+	// Largest magnitude in Error. This is the block-level error
+	MaxAbsError big.Float
+}
+
+// Quantize the given values into an MX [Block] with the element format ef. The
+// shared scale is picked according to the OCP MX v1.0 rule:
+//
+//	X = 2^(floor(log2(max(|V_i|))) - emax_elem)
+//
+// where emax_elem is the exponent of the largest normal number of the element
+// format. Every element is then V_i / X rounded to the element format with the
+// rounding mode rm. Elements that overflow are clamped to the largest normal
+// (or integer, for MXINT8), and elements below the smallest subnormal are
+// rounded to zero or the smallest subnormal with rm.
+// [floatBit.RoundStochastic] needs the optional rb argument, which is used for
+// every element in order.
+//
+// If every value is zero, the scale is 1. If any of the values is an infinity
+// (NaN can't be stored in a [big.Float]), then the scale is NaN, which makes
+// every element of the block NaN, and the infinities are reported with the
+// [floatBit.NoEncoding] status. An error is returned if there are no values,
+// or more than [BlockSize].
+func Quantize(values []big.Float, ef ElementFormat,
+	rm floatBit.RoundingMode, rb ...floatBit.RandomBits) (Block, Report, error) {
+	if len(values) == 0 || len(values) > BlockSize {
+		return Block{}, Report{}, errors.New("MX blocks hold between 1 and 32 values")
+	}
+
+	block := Block{Format: ef, Elements: make([]uint8, len(values))}
+	report := Report{
+		Accuracy: make([]big.Accuracy, len(values)),
+		Status:   make([]floatBit.Status, len(values)),
+		Error:    make([]big.Float, len(values)),
+	}
+
+	// Find the largest magnitude, and check for infinities
+	var maxAbs big.Float
+	hasInf := false
+	for i := range values {
+		if values[i].IsInf() {
+			hasInf = true
+			report.Status[i] = floatBit.NoEncoding
+		}
+		var absValue big.Float
+		absValue.Abs(&values[i])
+		if absValue.Cmp(&maxAbs) > 0 {
+			maxAbs.Set(&absValue)
+		}
+	}
+
+	if hasInf {
+		block.Scale = ScaleNaN
+		return block, report, nil
+	}
+
+	block.Scale = ScaleOne
+	if maxAbs.Sign() != 0 {
+		// floor(log2(x)) is one less than the exponent returned by MantExp,
+		// since the mantissa it uses is in [0.5, 1)
+		sharedExponent := maxAbs.MantExp(nil) - 1 - ef.emax()
+		sharedExponent = max(sharedExponent, ScaleExponentMin)
+		sharedExponent = min(sharedExponent, ScaleExponentMax)
+		block.Scale = ScaleBits(sharedExponent + ScaleBias)
+	}
+
+	for i := range values {
+		// The scale is a power of two, so the division is exact
+		var scaled big.Float
+		scaled.SetMantExp(&values[i], -block.Scale.Exponent())
+		block.Elements[i], report.Accuracy[i], report.Status[i] =
+			ef.encode(&scaled, rm, rb...)
+	}
+
+	// Calculate the errors
+	dequantized, err := Dequantize(block)
+	if err != nil {
+		return block, report, err
+	}
+	for i := range values {
+		report.Error[i].Sub(&dequantized[i], &values[i])
+		var absError big.Float
+		absError.Abs(&report.Error[i])
+		if absError.Cmp(&report.MaxAbsError) > 0 {
+			report.MaxAbsError.Set(&absError)
+		}
+	}
+
+	return block, report, nil
+}
+
+// Quantize the given float32 values into an MX [Block]. Signature and usage is
+// identical to [Quantize] except the values are float32. NaN values also
+// result in a NaN scale
+func QuantizeFloat32(values []float32, ef ElementFormat,
+	rm floatBit.RoundingMode, rb ...floatBit.RandomBits) (Block, Report, error) {
+	asBigFloats := make([]big.Float, len(values))
+	hasNaN := false
+	for i, value := range values {
+		if value != value {
+			hasNaN = true
+			continue
+		}
+		asBigFloats[i].SetFloat64(float64(value))
+	}
+	block, report, err := Quantize(asBigFloats, ef, rm, rb...)
+	if err == nil && hasNaN {
+		block.Scale = ScaleNaN
+		for i := range block.Elements {
+			block.Elements[i] = 0
+			report.Accuracy[i] = big.Exact
+			report.Status[i] = floatBit.Fits
+			report.Error[i].SetFloat64(0)
+		}
+		report.MaxAbsError.SetFloat64(0)
+	}
+	return block, report, err
+}
+
+// Dequantize the given [Block], and return the value of every element.
+// Returns an error if the scale or any of the elements is NaN (or an infinity)
+// since these can't be stored in a [big.Float]
+func Dequantize(block Block) ([]big.Float, error) {
+	if block.Scale.IsNaN() {
+		return nil, errors.New("NaN encountered")
+	}
+	result := make([]big.Float, len(block.Elements))
+	for i, elementBits := range block.Elements {
+		elementValue, err := block.Format.decode(elementBits)
+		if err != nil {
+			return nil, err
+		}
+		result[i].SetMantExp(&elementValue, block.Scale.Exponent())
+	}
+	return result, nil
+}
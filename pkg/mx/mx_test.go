@@ -0,0 +1,287 @@
+package MX
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+func TestRoundToInteger(t *testing.T) {
+	testCases := []struct {
+		// Inputs
+		input float64
+		rm    floatBit.RoundingMode
+		// Outputs
+		golden    int64
+		goldenAcc big.Accuracy
+	}{
+		{2.0, floatBit.RoundNearestEven, 2, big.Exact},
+		{2.5, floatBit.RoundNearestEven, 2, big.Below},
+		{3.5, floatBit.RoundNearestEven, 4, big.Above},
+		{2.5, floatBit.RoundNearestOdd, 3, big.Above},
+		{-2.5, floatBit.RoundHalfTowardsZero, -2, big.Above},
+		{-2.5, floatBit.RoundHalfTowardsPositiveInf, -2, big.Above},
+		{-2.5, floatBit.RoundHalfTowardsNegativeInf, -3, big.Below},
+		{2.6, floatBit.RoundHalfTowardsZero, 3, big.Above},
+		{2.1, floatBit.RoundTowardsPositiveInf, 3, big.Above},
+		{-2.1, floatBit.RoundTowardsPositiveInf, -2, big.Above},
+		{-2.1, floatBit.RoundTowardsNegativeInf, -3, big.Below},
+		{-2.9, floatBit.RoundTowardsZero, -2, big.Above},
+		{-2.1, floatBit.RoundAwayFromZero, -3, big.Below},
+		{2.1, floatBit.RoundAwayFromZero, 3, big.Above},
+		{2.5, floatBit.RoundHalfAwayFromZero, 3, big.Above},
+		{-2.5, floatBit.RoundHalfAwayFromZero, -3, big.Below},
+		{-2.4, floatBit.RoundHalfAwayFromZero, -2, big.Above},
+	}
+
+	for _, tt := range testCases {
+		result, resultAcc := roundToInteger(big.NewFloat(tt.input), tt.rm)
+		if result.Int64() != tt.golden || resultAcc != tt.goldenAcc {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Input: %v Rounding Mode: %v", tt.input, tt.rm)
+			t.Errorf("Expected: %d (%v), Got: %d (%v)", tt.golden, tt.goldenAcc, result.Int64(), resultAcc)
+		}
+	}
+}
+
+func TestRoundToIntegerStochastic(t *testing.T) {
+	testCases := []struct {
+		// Inputs
+		input float64
+		rb    floatBit.ExplicitRandomBits
+		// Outputs
+		golden    int64
+		goldenAcc big.Accuracy
+	}{
+		{2.0, 0, 2, big.Exact},
+		{2.25, 1<<62 - 1, 3, big.Above},
+		{2.25, 1 << 62, 2, big.Below},
+		{-2.25, 0, -3, big.Below},
+		{-2.75, 3 << 62, -2, big.Above},
+	}
+
+	for _, tt := range testCases {
+		result, resultAcc := roundToInteger(big.NewFloat(tt.input), floatBit.RoundStochastic, tt.rb)
+		if result.Int64() != tt.golden || resultAcc != tt.goldenAcc {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Input: %v Random Bits: %#x", tt.input, uint64(tt.rb))
+			t.Errorf("Expected: %d (%v), Got: %d (%v)", tt.golden, tt.goldenAcc, result.Int64(), resultAcc)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		format ElementFormat
+		input  float64
+		rm     floatBit.RoundingMode
+		// Outputs
+		goldenVal    uint8
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{"E2M1Max", MXFP4E2M1, 6.0, floatBit.RoundNearestEven, 0b0_11_1, big.Exact, floatBit.Fits},
+		{"E2M1Tie", MXFP4E2M1, 2.5, floatBit.RoundNearestEven, 0b0_10_0, big.Below, floatBit.Fits},
+		{"E2M1Subnormal", MXFP4E2M1, -0.7, floatBit.RoundNearestEven, 0b1_00_1, big.Above, floatBit.Fits},
+		{"E2M1Overflow", MXFP4E2M1, 7.0, floatBit.RoundTowardsZero, 0b0_11_1, big.Below, floatBit.Overflow},
+		{"E2M1Underflow", MXFP4E2M1, -0.1, floatBit.RoundNearestEven, 0b1_00_0, big.Above, floatBit.Underflow},
+		// Just below the minimum subnormal (0.5), which is the closest element
+		{"E2M1BelowMinSubnormal", MXFP4E2M1, 0.4, floatBit.RoundNearestEven, 0b0_00_1, big.Above, floatBit.Underflow},
+		{"E4M3BelowMinSubnormal", MXFP8E4M3, -0x1.cp-10, floatBit.RoundNearestEven, 0b1_0000_001, big.Below,
+			floatBit.Underflow},
+		{"E2M3Carry", MXFP6E2M3, 1.96875, floatBit.RoundTowardsPositiveInf, 0b0_10_000, big.Above, floatBit.Fits},
+		{"E2M3Max", MXFP6E2M3, 7.5, floatBit.RoundNearestEven, 0b0_11_111, big.Exact, floatBit.Fits},
+		{"E3M2Normal", MXFP6E3M2, -0.3, floatBit.RoundTowardsZero, 0b1_001_00, big.Above, floatBit.Fits},
+		{"E3M2Max", MXFP6E3M2, 28, floatBit.RoundNearestEven, 0b0_111_11, big.Exact, floatBit.Fits},
+		{"E4M3Overflow", MXFP8E4M3, 500, floatBit.RoundNearestEven, 0b0_1111_110, big.Below, floatBit.Overflow},
+	}
+
+	for _, tt := range testCases {
+		resultVal, resultAcc, resultStatus := tt.format.encode(big.NewFloat(tt.input), tt.rm)
+		if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Name: %s", tt.name)
+			t.Errorf("Expected result: %0#8b, Got: %0#8b", tt.goldenVal, resultVal)
+			t.Errorf("Expect accuracy: %v, Got: %v", tt.goldenAcc, resultAcc)
+			t.Errorf("Expected status: %v, Got: %v", tt.goldenStatus, resultStatus)
+		}
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		values []float64
+		format ElementFormat
+		rm     floatBit.RoundingMode
+		// Outputs
+		goldenScale    ScaleBits
+		goldenElements []uint8
+		goldenAcc      []big.Accuracy
+		goldenStatus   []floatBit.Status
+		goldenMaxError float64
+	}{
+		{
+			name:           "MXFP8E4M3Exact",
+			values:         []float64{1.0, 0.5, -3.0},
+			format:         MXFP8E4M3,
+			rm:             floatBit.RoundNearestEven,
+			goldenScale:    ScaleBits(127 - 7),
+			goldenElements: []uint8{0b0_1110_000, 0b0_1101_000, 0b1_1111_100},
+			goldenAcc:      []big.Accuracy{big.Exact, big.Exact, big.Exact},
+			goldenStatus:   []floatBit.Status{floatBit.Fits, floatBit.Fits, floatBit.Fits},
+			goldenMaxError: 0,
+		},
+		{
+			name:           "MXFP8E4M3Clamp",
+			values:         []float64{480},
+			format:         MXFP8E4M3,
+			rm:             floatBit.RoundNearestEven,
+			goldenScale:    ScaleOne,
+			goldenElements: []uint8{0b0_1111_110},
+			goldenAcc:      []big.Accuracy{big.Below},
+			goldenStatus:   []floatBit.Status{floatBit.Overflow},
+			goldenMaxError: 32,
+		},
+		{
+			name:           "MXFP4",
+			values:         []float64{6, 1, 0.7},
+			format:         MXFP4E2M1,
+			rm:             floatBit.RoundNearestEven,
+			goldenScale:    ScaleOne,
+			goldenElements: []uint8{0b0_11_1, 0b0_01_0, 0b0_00_1},
+			goldenAcc:      []big.Accuracy{big.Exact, big.Exact, big.Below},
+			goldenStatus:   []floatBit.Status{floatBit.Fits, floatBit.Fits, floatBit.Fits},
+			goldenMaxError: 0.2,
+		},
+		{
+			name:           "MXINT8",
+			values:         []float64{0.1, -0.0625},
+			format:         MXINT8,
+			rm:             floatBit.RoundNearestEven,
+			goldenScale:    ScaleBits(127 - 4),
+			goldenElements: []uint8{102, 0xC0},
+			goldenAcc:      []big.Accuracy{big.Below, big.Exact},
+			goldenStatus:   []floatBit.Status{floatBit.Fits, floatBit.Fits},
+			goldenMaxError: 0.1 - 102.0/1024,
+		},
+		{
+			name:           "MXINT8Clamp",
+			values:         []float64{1.999},
+			format:         MXINT8,
+			rm:             floatBit.RoundNearestEven,
+			goldenScale:    ScaleOne,
+			goldenElements: []uint8{127},
+			goldenAcc:      []big.Accuracy{big.Below},
+			goldenStatus:   []floatBit.Status{floatBit.Overflow},
+			goldenMaxError: 1.999 - 127.0/64,
+		},
+		{
+			name:           "AllZeros",
+			values:         []float64{0, 0},
+			format:         MXFP6E3M2,
+			rm:             floatBit.RoundNearestEven,
+			goldenScale:    ScaleOne,
+			goldenElements: []uint8{0, 0},
+			goldenAcc:      []big.Accuracy{big.Exact, big.Exact},
+			goldenStatus:   []floatBit.Status{floatBit.Fits, floatBit.Fits},
+			goldenMaxError: 0,
+		},
+	}
+
+	for _, tt := range testCases {
+		values := make([]big.Float, len(tt.values))
+		for i, value := range tt.values {
+			values[i].SetFloat64(value)
+		}
+		block, report, err := Quantize(values, tt.format, tt.rm)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if block.Scale != tt.goldenScale {
+			t.Errorf("%s: Expected scale: %0#2x, Got: %0#2x", tt.name, tt.goldenScale, block.Scale)
+		}
+		for i := range tt.values {
+			if block.Elements[i] != tt.goldenElements[i] ||
+				report.Accuracy[i] != tt.goldenAcc[i] ||
+				report.Status[i] != tt.goldenStatus[i] {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("Name: %s Element: %d", tt.name, i)
+				t.Errorf("Expected result: %0#2x, Got: %0#2x", tt.goldenElements[i], block.Elements[i])
+				t.Errorf("Expect accuracy: %v, Got: %v", tt.goldenAcc[i], report.Accuracy[i])
+				t.Errorf("Expected status: %v, Got: %v", tt.goldenStatus[i], report.Status[i])
+			}
+		}
+		maxError, _ := report.MaxAbsError.Float64()
+		if math.Abs(maxError-tt.goldenMaxError) > 1e-12 {
+			t.Errorf("%s: Expected max error: %v, Got: %v", tt.name, tt.goldenMaxError, maxError)
+		}
+	}
+}
+
+func TestQuantizeSpecialCases(t *testing.T) {
+	// Too many values
+	values := make([]big.Float, BlockSize+1)
+	if _, _, err := Quantize(values, MXFP8E5M2, floatBit.RoundNearestEven); err == nil {
+		t.Errorf("Expected an error for %d values", len(values))
+	}
+
+	// Infinities make the scale NaN
+	block, report, err := QuantizeFloat32([]float32{1.0, float32(math.Inf(-1))},
+		MXFP8E5M2, floatBit.RoundNearestEven)
+	if err != nil || block.Scale != ScaleNaN || report.Status[1] != floatBit.NoEncoding {
+		t.Errorf("Expected NaN scale and NoEncoding status, Got: %0#2x, %v (%v)", block.Scale, report.Status, err)
+	}
+	if _, err := Dequantize(block); err == nil {
+		t.Errorf("Expected an error when dequantizing a NaN scale")
+	}
+
+	// So do NaNs
+	block, _, err = QuantizeFloat32([]float32{1.0, float32(math.NaN())},
+		MXFP6E2M3, floatBit.RoundNearestEven)
+	if err != nil || block.Scale != ScaleNaN {
+		t.Errorf("Expected NaN scale, Got: %0#2x (%v)", block.Scale, err)
+	}
+}
+
+func TestDequantize(t *testing.T) {
+	block := Block{Format: MXFP6E2M3, Scale: ScaleBits(127 + 3),
+		Elements: []uint8{0b0_01_100, 0b1_00_001, 0b0_11_111}}
+	golden := []float64{12, -1, 60}
+	result, err := Dequantize(block)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	for i := range golden {
+		if value, _ := result[i].Float64(); value != golden[i] {
+			t.Errorf("Element %d: Expected: %v, Got: %v", i, golden[i], value)
+		}
+	}
+}
+
+func TestToFloatFormat(t *testing.T) {
+	scale := ScaleBits(0x7A)
+	result := scale.ToFloatFormat()
+	if string(result.Sign) != "" || string(result.Exponent) != "01111010" ||
+		string(result.Mantissa) != "" {
+		t.Errorf("Expected Sign: , Exponent: 01111010, Mantissa: . Got: %v", result)
+	}
+
+	result = MXFP6E3M2.ToFloatFormat(0b1_011_01)
+	if string(result.Sign) != "1" || string(result.Exponent) != "011" ||
+		string(result.Mantissa) != "01" {
+		t.Errorf("Expected Sign: 1, Exponent: 011, Mantissa: 01. Got: %v", result)
+	}
+
+	result = MXINT8.ToFloatFormat(0xE0)
+	if string(result.Sign) != "1" || string(result.Exponent) != "" ||
+		string(result.Mantissa) != "1100000" {
+		t.Errorf("Expected Sign: 1, Exponent: , Mantissa: 1100000. Got: %v", result)
+	}
+}
@@ -0,0 +1,68 @@
+package MX
+
+import (
+	"math/big"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+)
+
+// Alias type for uint8. This is used to represent the bits of the E8M0 shared
+// scale of an MX block. E8M0 is an unsigned, exponent only format, where the
+// bits b encode the power of two 2^(b-127). The bits 0xFF are reserved for NaN
+type ScaleBits uint8
+
+const (
+	// Bias of the E8M0 exponent
+	ScaleBias int = 127
+	// Smallest and largest exponents that the E8M0 scale can encode
+	ScaleExponentMin int = -127
+	ScaleExponentMax int = 127
+	// The only NaN encoding in E8M0
+	ScaleNaN ScaleBits = 0xFF
+	// Encoding of the scale 2^0 = 1
+	ScaleOne ScaleBits = 0x7F
+)
+
+// Returns true if the scale is the E8M0 NaN
+func (s ScaleBits) IsNaN() bool {
+	return s == ScaleNaN
+}
+
+// Returns the power of two that the scale represents. The result is
+// meaningless if the scale is NaN
+func (s ScaleBits) Exponent() int {
+	return int(s) - ScaleBias
+}
+
+// Convert the given [ScaleBits] to a [big.Float]. Since [big.Float] cannot
+// represent NaNs, the second return value is false if the scale is NaN
+func (s ScaleBits) ToBigFloat() (big.Float, bool) {
+	if s.IsNaN() {
+		return *big.NewFloat(0), false
+	}
+	var result big.Float
+	result.SetMantExp(big.NewFloat(1), s.Exponent())
+	return result, true
+}
+
+// ToFloatFormat converts the E8M0 scale bits into [floatBit.FloatBitFormat].
+// E8M0 has neither a sign nor mantissa bits, so only the exponent is set
+// Implements the FloatBitFormatter Interface
+func (s *ScaleBits) ToFloatFormat() floatBit.FloatBitFormat {
+	return floatBit.FloatBitFormat{Sign: []byte{}, Exponent: bitsToBytes(uint8(*s), 8),
+		Mantissa: []byte{}}
+}
+
+// Utility function that returns the lowest width bits of value as a byte slice
+// of '0's and '1's, with the most significant bit first
+func bitsToBytes(value uint8, width int) []byte {
+	result := make([]byte, 0, width)
+	for i := width - 1; i >= 0; i-- {
+		if (value>>i)&0x1 == 0 {
+			result = append(result, '0')
+		} else {
+			result = append(result, '1')
+		}
+	}
+	return result
+}
@@ -0,0 +1,180 @@
+package floatBit
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// NaNPolicy decides how a NaN is converted to another format
+type NaNPolicy uint8
+
+// NaNPolicy
+//
+// NaNCanonicalize: Every NaN becomes the canonical NaN of the format, which
+// is positive, quiet and has a zero payload. This is what the FromBigFloat,
+// FromFloat32 and FromFloat64 conversions of the format packages produce
+//
+// NaNPreserve: The sign, the quiet bit and the payload are kept, as far as
+// the format can encode them. This is a bit-level copy of the NaN, like the
+// non-computational operations of IEEE-754 (copy, negate, abs), so signaling
+// NaNs stay signaling and don't raise [ExceptionInvalid]
+//
+// NaNQuiet: Like NaNPreserve, but signaling NaNs are quieted, and raise
+// [ExceptionInvalid]. This is how IEEE-754 (§6.2.3) converts NaNs between
+// formats, and what hardware conversions do
+const (
+	NaNCanonicalize NaNPolicy = 0
+	NaNPreserve     NaNPolicy = 1
+	NaNQuiet        NaNPolicy = 2
+)
+
+// Stringer interface for NaNPolicy
+func (p NaNPolicy) String() string {
+	switch p {
+	case NaNCanonicalize:
+		return "canonicalize"
+	case NaNPreserve:
+		return "preserve"
+	case NaNQuiet:
+		return "quiet"
+	default:
+		return ""
+	}
+}
+
+// NaN describes a NaN independently of its format, so it can be converted
+// between formats. The payload is the integer value of the trailing mantissa
+// bits below the quiet bit, like getPayload of IEEE-754 (§9.7). A wider
+// format keeps the payload as it is, and a narrower one keeps only its low
+// bits, so a payload that fits survives a round trip through any of the
+// formats. Note that hardware conversions keep the high bits of the mantissa
+// instead, which shifts the payload
+type NaN struct {
+	Negative  bool
+	Signaling bool
+	// A nil Payload is 0
+	Payload *big.Int
+}
+
+// Stringer interface for NaN. The result uses the syntax that [ParseNaN]
+// accepts, like -snan(0x2a)
+func (n NaN) String() string {
+	result := "nan"
+	if n.Signaling {
+		result = "snan"
+	}
+	if n.Negative {
+		result = "-" + result
+	}
+	if n.Payload != nil && n.Payload.Sign() != 0 {
+		result += fmt.Sprintf("(%#x)", n.Payload)
+	}
+	return result
+}
+
+// Returns the bit position of the quiet bit of [NaNIEEE] formats, which is
+// the MSB of the mantissa. The payload is made up of the bits below it
+func (f Format) quietBit() int {
+	return f.MantissaBits - 1
+}
+
+// Returns the [NaN] encoded by the given bits, and false if the bits aren't a
+// NaN in the format. NaNs of formats without a quiet bit are reported as
+// quiet, with a zero payload. The single NaN of [NaNNegativeZero] formats is
+// reported as positive
+func (f Format) DecodeNaN(bits *big.Int) (NaN, bool) {
+	if !f.IsNaN(bits) {
+		return NaN{}, false
+	}
+	switch f.NaN {
+	case NaNIEEE:
+		payload := new(big.Int).Lsh(big.NewInt(1), uint(f.quietBit()))
+		payload.Sub(payload, big.NewInt(1))
+		payload.And(payload, bits)
+		return NaN{
+			Negative:  bits.Bit(f.ExponentBits+f.MantissaBits) != 0,
+			Signaling: bits.Bit(f.quietBit()) == 0,
+			Payload:   payload,
+		}, true
+	case NaNAllOnes:
+		return NaN{Negative: bits.Bit(f.ExponentBits+f.MantissaBits) != 0}, true
+	default:
+		return NaN{}, true
+	}
+}
+
+// Converts the given [NaN] to the bits of the format, with the given
+// [NaNPolicy]. Payloads that are too wide for the format are truncated to the
+// low bits that fit. A signaling NaN whose payload truncates to zero gets the
+// payload 1, because a zero mantissa would be an infinity. Formats with a
+// single NaN per sign (or a single NaN) can't keep the quiet bit or the
+// payload.
+//
+// Returns the result bits, a [Status] and the [Exceptions] raised by the
+// conversion. Signaling NaNs raise [ExceptionInvalid], except with
+// [NaNPreserve]. Formats without NaNs return positive zero with the
+// [NoEncoding] status, and also raise [ExceptionInvalid]
+func (f Format) EncodeNaN(n NaN, p NaNPolicy) (*big.Int, Status, Exceptions) {
+	var exceptions Exceptions
+	if n.Signaling && p != NaNPreserve {
+		exceptions |= ExceptionInvalid
+	}
+
+	var signBit uint
+	if n.Negative && p != NaNCanonicalize {
+		signBit = 1
+	}
+
+	switch {
+	case f.NaN == NoNaN:
+		return f.zero(0), NoEncoding, exceptions | ExceptionInvalid
+	case f.NaN != NaNIEEE:
+		return f.nan(signBit), Fits, exceptions
+	case p == NaNCanonicalize:
+		return f.nan(0), Fits, exceptions
+	}
+
+	// Formats with a single mantissa bit only have the quiet NaN
+	signaling := n.Signaling && p == NaNPreserve && f.quietBit() > 0
+
+	result := new(big.Int)
+	if n.Payload != nil {
+		result.Abs(n.Payload)
+		mask := new(big.Int).Lsh(big.NewInt(1), uint(f.quietBit()))
+		result.And(result, mask.Sub(mask, big.NewInt(1)))
+	}
+	if signaling && result.Sign() == 0 {
+		result.SetInt64(1)
+	}
+	if !signaling {
+		result.SetBit(result, f.quietBit(), 1)
+	}
+	result.Or(result, f.infinity(signBit))
+	return result, Fits, exceptions
+}
+
+// Returns the result of an operation on the given operands, if any of them is
+// a NaN. Like IEEE-754 (§6.2.3), the result is the first NaN operand, quieted
+// and with its sign and payload. [ExceptionInvalid] is raised if any of the
+// operands is a signaling NaN. Returns false if none of the operands is a NaN
+func (f Format) PropagateNaN(operands ...*big.Int) (*big.Int, Exceptions, bool) {
+	var first *NaN
+	var exceptions Exceptions
+	for _, operand := range operands {
+		n, ok := f.DecodeNaN(operand)
+		if !ok {
+			continue
+		}
+		if n.Signaling {
+			exceptions |= ExceptionInvalid
+		}
+		if first == nil {
+			first = &n
+		}
+	}
+	if first == nil {
+		return nil, 0, false
+	}
+	result, _, _ := f.EncodeNaN(*first, NaNQuiet)
+	return result, exceptions, true
+}
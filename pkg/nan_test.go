@@ -0,0 +1,129 @@
+package floatBit_test
+
+import (
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	F128 "github.com/shantanu-gontia/float-conv/pkg/float128bits"
+	F16 "github.com/shantanu-gontia/float-conv/pkg/float16bits"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+	F64 "github.com/shantanu-gontia/float-conv/pkg/float64bits"
+	F80 "github.com/shantanu-gontia/float-conv/pkg/float80bits"
+)
+
+func TestEncodeNaN(t *testing.T) {
+	formatE2M1WithNaN := floatBit.Format{2, 1, 1, true, floatBit.NaNIEEE, true}
+	testCases := []struct {
+		name string
+		// In
+		input string
+		f     floatBit.Format
+		p     floatBit.NaNPolicy
+		// Out
+		golden           uint64
+		goldenStatus     floatBit.Status
+		goldenExceptions floatBit.Exceptions
+	}{
+		{"Canonicalize", "-snan(0x2a)", floatBit.FormatFloat32, floatBit.NaNCanonicalize, 0x7fc0_0000,
+			floatBit.Fits, floatBit.ExceptionInvalid},
+		{"Preserve", "-snan(0x2a)", floatBit.FormatFloat32, floatBit.NaNPreserve, 0xff80_002a,
+			floatBit.Fits, 0},
+		{"Quiet", "-snan(0x2a)", floatBit.FormatFloat32, floatBit.NaNQuiet, 0xffc0_002a,
+			floatBit.Fits, floatBit.ExceptionInvalid},
+		{"QuietNaNQuiet", "nan(0x2a)", floatBit.FormatFloat64, floatBit.NaNQuiet, 0x7ff8_0000_0000_002a,
+			floatBit.Fits, 0},
+		// Float16 has 9 payload bits
+		{"TruncatedPayload", "nan(0x12345)", floatBit.FormatFloat16, floatBit.NaNPreserve, 0x7f45,
+			floatBit.Fits, 0},
+		// A zero mantissa would be an infinity
+		{"TruncatedToZeroSignaling", "snan(0x200)", floatBit.FormatFloat16, floatBit.NaNPreserve, 0x7c01,
+			floatBit.Fits, 0},
+		{"SignalingE5M2", "snan", floatBit.FormatE5M2, floatBit.NaNPreserve, 0x7d, floatBit.Fits, 0},
+		{"NegativeE5M2", "-nan(0x3)", floatBit.FormatE5M2, floatBit.NaNQuiet, 0xff, floatBit.Fits, 0},
+		// With a single mantissa bit, there are no signaling NaNs
+		{"OnlyQuietBit", "snan(0x2a)", formatE2M1WithNaN, floatBit.NaNPreserve, 0x7, floatBit.Fits, 0},
+		{"AllOnesSign", "-snan(0x2a)", floatBit.FormatE4M3, floatBit.NaNPreserve, 0xff, floatBit.Fits, 0},
+		{"AllOnesCanonicalize", "-nan", floatBit.FormatE4M3, floatBit.NaNCanonicalize, 0x7f,
+			floatBit.Fits, 0},
+		{"NegativeZero", "-nan(0x2a)", floatBit.FormatE4M3FNUZ, floatBit.NaNPreserve, 0x80,
+			floatBit.Fits, 0},
+		{"NoNaN", "nan", floatBit.FormatE2M1, floatBit.NaNPreserve, 0, floatBit.NoEncoding,
+			floatBit.ExceptionInvalid},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			input, ok := floatBit.ParseNaN(tt.input)
+			if !ok {
+				t.Fatalf("Invalid input %s", tt.input)
+			}
+			result, status, exceptions := tt.f.EncodeNaN(input, tt.p)
+			if result.Uint64() != tt.golden || status != tt.goldenStatus || exceptions != tt.goldenExceptions {
+				t.Errorf("Input: %s Policy: %v Expected: %#x %v %v, Got: %#x %v %v", tt.input, tt.p, tt.golden,
+					tt.goldenStatus, tt.goldenExceptions, result, status, exceptions)
+			}
+		})
+	}
+}
+
+func TestDecodeNaN(t *testing.T) {
+	testCases := []struct {
+		f        floatBit.Format
+		bits     int64
+		golden   string
+		goldenOk bool
+	}{
+		{floatBit.FormatFloat32, 0xff80_002a, "-snan(0x2a)", true},
+		{floatBit.FormatFloat32, 0x7fc0_0000, "nan", true},
+		{floatBit.FormatFloat16, 0x7c01, "snan(0x1)", true},
+		{floatBit.FormatFloat16, 0x7c00, "", false},
+		{floatBit.FormatFloat16, 0x3c00, "", false},
+		{floatBit.FormatE4M3, 0xff, "-nan", true},
+		{floatBit.FormatE4M3FNUZ, 0x80, "nan", true},
+		{floatBit.FormatE2M1, 0x7, "", false},
+	}
+
+	for _, tt := range testCases {
+		result, ok := tt.f.DecodeNaN(big.NewInt(tt.bits))
+		if ok != tt.goldenOk || (ok && result.String() != tt.golden) {
+			t.Errorf("Format: %v Bits: %#x Expected: %s %v, Got: %s %v", tt.f, tt.bits, tt.golden,
+				tt.goldenOk, result, ok)
+		}
+	}
+}
+
+func TestPropagateNaN(t *testing.T) {
+	one := big.NewInt(0x3c00)
+	result, exceptions, ok := floatBit.FormatFloat16.PropagateNaN(one, big.NewInt(0xfc05),
+		big.NewInt(0x7e07))
+	if !ok || result.Int64() != 0xfe05 || exceptions != floatBit.ExceptionInvalid {
+		t.Errorf("Expected: 0xfe05 invalid, Got: %#x %v %v", result, exceptions, ok)
+	}
+	if _, _, ok := floatBit.FormatFloat16.PropagateNaN(one, one); ok {
+		t.Errorf("Expected no NaN")
+	}
+}
+
+// A payload that fits in the narrowest format survives a conversion to every
+// wider format and back
+func TestNaNRoundTrip(t *testing.T) {
+	for _, input := range []F16.Bits{0x7c01, 0xfd55, 0x7e00, 0xffff} {
+		asNaN, _ := input.DecodeNaN()
+
+		asF32, _, _ := F32.FromNaN(asNaN, floatBit.NaNPreserve)
+		asNaN, _ = asF32.DecodeNaN()
+		asF64, _, _ := F64.FromNaN(asNaN, floatBit.NaNPreserve)
+		asNaN, _ = asF64.DecodeNaN()
+		asF80, _, _ := F80.FromNaN(asNaN, floatBit.NaNPreserve)
+		asNaN, _ = asF80.DecodeNaN()
+		asF128, _, _ := F128.FromNaN(asNaN, floatBit.NaNPreserve)
+		asNaN, _ = asF128.DecodeNaN()
+
+		result, _, _ := F16.FromNaN(asNaN, floatBit.NaNPreserve)
+		if result != input {
+			t.Errorf("Input: %#04x Expected: %#04x, Got: %#04x (via %#08x %#016x %#x %#x)", input, input,
+				result, asF32, asF64, asF80, asF128)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package NVFP4
+
+import (
+	"errors"
+	"math"
+	"math/big"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	E2M1 "github.com/shantanu-gontia/float-conv/pkg/fp4e2m1bits"
+	E4M3 "github.com/shantanu-gontia/float-conv/pkg/fp8e4m3bits"
+)
+
+// Number of E2M1 elements that share a single E4M3 scale
+const BlockSize int = 16
+
+const (
+	// Largest magnitude of an E2M1 element
+	elementMax float32 = 6.0
+	// Largest magnitude of an E4M3 scale
+	scaleMax float32 = 448.0
+	// Quotients are clamped to [2^-quotientExponentLimit,
+	// 2^quotientExponentLimit] in magnitude before they are rounded. This is
+	// far outside of the range of both E2M1 and E4M3, so clamping never
+	// changes the result
+	quotientExponentLimit int = 20
+)
+
+// Block represents values quantized to NVFP4. NVFP4 uses two levels of
+// scaling: every [BlockSize] consecutive E2M1 elements share an E4M3 scale,
+// and all of the blocks share a single float32 tensor scale. The value of the
+// i-th element is
+//
+//	Elements[i] * Scales[i/BlockSize] * TensorScale
+//
+// The last block is shorter if the number of elements isn't a multiple of
+// [BlockSize]
+type Block struct {
+	// Per-tensor scale. This is 1 when no tensor scale is used
+	TensorScale float32
+	// One E4M3 scale for every [BlockSize] elements
+	Scales   []E4M3.Bits
+	Elements []E2M1.Bits
+}
+
+// Report contains information about how accurately a [Block] represents the
+// values that were quantized into it
+type Report struct {
+	// Accuracy of each element compared to its input divided by the scales
+	Accuracy []big.Accuracy
+	// Whether each element fit in E2M1, or overflowed or underflowed.
+	// Infinities and NaNs are reported with [floatBit.NoEncoding]
+	Status []floatBit.Status
+	// Difference between each dequantized element and its input. Zero for
+	// infinities and NaNs
+	Error []big.Float
+	// Largest magnitude in Error
+	MaxAbsError big.Float
+	// Number of elements with the [floatBit.Overflow] status
+	Overflows int
+	// Number of elements with the [floatBit.Underflow] status
+	Underflows int
+}
+
+// Quantize the given values to NVFP4 without a tensor scale (the tensor scale
+// is 1). See [QuantizeWithTensorScale] for how the values are quantized
+func Quantize(values []float32, rm floatBit.RoundingMode,
+	rb ...floatBit.RandomBits) (Block, Report) {
+	// A tensor scale of 1 is always valid, so there is no error to return
+	block, report, _ := QuantizeWithTensorScale(values, 1, rm, rb...)
+	return block, report
+}
+
+// Quantize the given values to NVFP4 with the given tensor scale. The values
+// are split into blocks of [BlockSize], and for each block:
+//
+//  1. The block scale is amax / (6 * tensorScale), where amax is the largest
+//     finite magnitude in the block, and 6 is the largest E2M1 magnitude. It is
+//     rounded to E4M3 by rounding to nearest even. Scales that overflow are
+//     clamped to 448, and non-zero scales that underflow are saturated to the
+//     minimum E4M3 subnormal, so that a block is only zeroed out when all of
+//     its values are zero
+//  2. Every element is value / (scale * tensorScale), rounded to E2M1 with the
+//     rounding mode rm. Elements that overflow are clamped to +/-6, and
+//     elements below the smallest subnormal (0.5) are rounded to zero or
+//     +/-0.5 with rm. [floatBit.RoundStochastic] needs the optional rb
+//     argument, which is used for every element in order.
+//
+// All the arithmetic is exact, and every number is rounded only once, so the
+// result doesn't depend on the order of the operations. Infinities and NaNs
+// don't contribute to amax. Infinities are clamped to +/-6, NaNs become +0, and
+// both are reported with the [floatBit.NoEncoding] status.
+//
+// Returns an error if tensorScale is not a positive finite number
+func QuantizeWithTensorScale(values []float32, tensorScale float32,
+	rm floatBit.RoundingMode, rb ...floatBit.RandomBits) (Block, Report, error) {
+	if !(tensorScale > 0) || math.IsInf(float64(tensorScale), 1) {
+		return Block{}, Report{}, errors.New("tensor scale must be a positive finite number")
+	}
+
+	numBlocks := (len(values) + BlockSize - 1) / BlockSize
+	block := Block{
+		TensorScale: tensorScale,
+		Scales:      make([]E4M3.Bits, numBlocks),
+		Elements:    make([]E2M1.Bits, len(values)),
+	}
+	report := Report{
+		Accuracy: make([]big.Accuracy, len(values)),
+		Status:   make([]floatBit.Status, len(values)),
+		Error:    make([]big.Float, len(values)),
+	}
+
+	bigTensorScale := big.NewFloat(float64(tensorScale))
+	for b := range numBlocks {
+		start := b * BlockSize
+		end := min(start+BlockSize, len(values))
+		blockValues := values[start:end]
+
+		// Pick the block scale
+		var amax float32
+		for _, value := range blockValues {
+			if isFinite(value) {
+				amax = max(amax, float32(math.Abs(float64(value))))
+			}
+		}
+		if amax == 0 {
+			block.Scales[b] = E4M3.Bits(E4M3.PositiveZero)
+		} else {
+			var scaleDenominator big.Float
+			scaleDenominator.Mul(big.NewFloat(float64(elementMax)), bigTensorScale)
+			block.Scales[b], _, _ = E4M3.FromFloat32(
+				roundedQuotient(big.NewFloat(float64(amax)), &scaleDenominator),
+				floatBit.RoundNearestEven, floatBit.SaturateMax, floatBit.SaturateMin,
+				floatBit.TininessBeforeRounding)
+		}
+
+		// The product of the two scales is exact, since E4M3 has 4 bits of
+		// precision and float32 has 24
+		var decodeScale big.Float
+		scaleValue := block.Scales[b].ToBigFloat()
+		decodeScale.Mul(&scaleValue, bigTensorScale)
+
+		for i, value := range blockValues {
+			element := &block.Elements[start+i]
+			acc, status := &report.Accuracy[start+i], &report.Status[start+i]
+			if !isFinite(value) || value == 0 || decodeScale.Sign() == 0 {
+				// Infinities and NaNs are handled by the E2M1 conversion, and
+				// zeros keep their sign. The scale is only zero if every value
+				// in the block is zero (or not finite)
+				*element, *acc, *status = E2M1.FromFloat32(value, rm,
+					floatBit.SaturateMax, floatBit.RoundToSubnormal,
+					floatBit.TininessBeforeRounding, rb...)
+			} else if rm == floatBit.RoundStochastic {
+				// Stochastic rounding uses 64 bits of the discarded fraction,
+				// which is more than the float32 quotient keeps
+				quotient := stochasticQuotient(big.NewFloat(float64(value)), &decodeScale)
+				*element, *acc, *status = E2M1.FromBigFloat(quotient, rm,
+					floatBit.SaturateMax, floatBit.RoundToSubnormal,
+					floatBit.TininessBeforeRounding, rb...)
+			} else {
+				*element, *acc, *status = E2M1.FromFloat32(
+					roundedQuotient(big.NewFloat(float64(value)), &decodeScale), rm,
+					floatBit.SaturateMax, floatBit.RoundToSubnormal,
+					floatBit.TininessBeforeRounding)
+			}
+
+			switch *status {
+			case floatBit.Overflow:
+				report.Overflows++
+			case floatBit.Underflow:
+				report.Underflows++
+			}
+		}
+	}
+
+	// Calculate the errors
+	dequantized := Dequantize(block)
+	for i, value := range values {
+		if !isFinite(value) {
+			continue
+		}
+		report.Error[i].Sub(big.NewFloat(float64(dequantized[i])),
+			big.NewFloat(float64(value)))
+		var absError big.Float
+		absError.Abs(&report.Error[i])
+		if absError.Cmp(&report.MaxAbsError) > 0 {
+			report.MaxAbsError.Set(&absError)
+		}
+	}
+
+	return block, report, nil
+}
+
+// Dequantize the given [Block], and return the value of every element as a
+// float32. The element is first multiplied by its block scale, which is
+// always exact, and then by the tensor scale, which rounds to nearest even
+func Dequantize(block Block) []float32 {
+	result := make([]float32, len(block.Elements))
+	for i, element := range block.Elements {
+		scaled := element.ToFloat32() * block.Scales[i/BlockSize].ToFloat32()
+		result[i] = scaled * block.TensorScale
+	}
+	return result
+}
+
+// Returns the tensor scale that maps the largest finite magnitude in values to
+// the largest magnitude NVFP4 can represent with a tensor scale of 1 (6 * 448).
+// Returns 1 if all the values are zero (or not finite)
+func ComputeTensorScale(values []float32) float32 {
+	var amax float32
+	for _, value := range values {
+		if isFinite(value) {
+			amax = max(amax, float32(math.Abs(float64(value))))
+		}
+	}
+	if amax == 0 {
+		return 1
+	}
+	tensorScale := amax / (elementMax * scaleMax)
+	// Very small inputs can make the tensor scale underflow
+	if tensorScale == 0 {
+		return math.SmallestNonzeroFloat32
+	}
+	return tensorScale
+}
+
+// Returns numerator / denominator rounded to a float32 by rounding to odd.
+// Rounding to odd keeps track of whether the quotient was inexact, so
+// rounding the result again to a format with at least 2 fewer bits of
+// precision is the same as rounding the exact quotient directly. The
+// magnitude of the result is clamped to [2^-quotientExponentLimit,
+// 2^quotientExponentLimit]. Both numbers must be non-zero and finite
+func roundedQuotient(numerator, denominator *big.Float) float32 {
+	var quotient big.Float
+	acc := quotient.SetPrec(24).SetMode(big.ToZero).
+		Quo(numerator, denominator).Acc()
+
+	var limit big.Float
+	limit.SetMantExp(big.NewFloat(1), quotientExponentLimit)
+	var absQuotient big.Float
+	absQuotient.Abs(&quotient)
+	if absQuotient.Cmp(&limit) > 0 {
+		quotient.SetMantExp(big.NewFloat(float64(quotient.Sign())), quotientExponentLimit)
+		acc = big.Exact
+	}
+	limit.SetMantExp(big.NewFloat(1), -quotientExponentLimit)
+	if absQuotient.Cmp(&limit) < 0 {
+		quotient.SetMantExp(big.NewFloat(float64(quotient.Sign())), -quotientExponentLimit)
+		acc = big.Exact
+	}
+
+	// The quotient has 24 bits of precision and is within the float32 normal
+	// range, so the conversion is exact
+	result, _ := quotient.Float32()
+	if acc != big.Exact {
+		result = math.Float32frombits(math.Float32bits(result) | 0x1)
+	}
+	return result
+}
+
+// Returns true if the value is neither an infinity nor a NaN
+func isFinite(value float32) bool {
+	return !math.IsInf(float64(value), 0) && !math.IsNaN(float64(value))
+}
+
+// Returns numerator / denominator rounded to 128 bits of precision by rounding
+// to odd. Like for [roundedQuotient], rounding the result again is the same as
+// rounding the exact quotient, and the 128 bits keep more of the discarded
+// fraction than stochastic rounding uses. Both numbers must be non-zero and
+// finite
+func stochasticQuotient(numerator, denominator *big.Float) big.Float {
+	var quotient big.Float
+	acc := quotient.SetPrec(127).SetMode(big.ToZero).
+		Quo(numerator, denominator).Acc()
+	quotient.SetPrec(128)
+	if acc != big.Exact {
+		// Set the LSB of the 128 bits, which is 2^-127 times the leading bit
+		var lsb big.Float
+		lsb.SetMantExp(big.NewFloat(float64(quotient.Sign())), quotient.MantExp(nil)-128)
+		quotient.Add(&quotient, &lsb)
+	}
+	return quotient
+}
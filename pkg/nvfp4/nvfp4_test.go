@@ -0,0 +1,290 @@
+package NVFP4
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	E2M1 "github.com/shantanu-gontia/float-conv/pkg/fp4e2m1bits"
+	E4M3 "github.com/shantanu-gontia/float-conv/pkg/fp8e4m3bits"
+)
+
+func TestQuantize(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		values []float32
+		rm     floatBit.RoundingMode
+		// Outputs
+		goldenScales     []E4M3.Bits
+		goldenElements   []E2M1.Bits
+		goldenAcc        []big.Accuracy
+		goldenStatus     []floatBit.Status
+		goldenMaxError   float64
+		goldenOverflows  int
+		goldenUnderflows int
+	}{
+		{
+			name:             "Exact",
+			values:           []float32{6, 1, 0.5, -3},
+			rm:               floatBit.RoundNearestEven,
+			goldenScales:     []E4M3.Bits{0b0_0111_000},
+			goldenElements:   []E2M1.Bits{0b0_11_1, 0b0_01_0, 0b0_00_1, 0b1_10_1},
+			goldenAcc:        []big.Accuracy{big.Exact, big.Exact, big.Exact, big.Exact},
+			goldenStatus:     []floatBit.Status{floatBit.Fits, floatBit.Fits, floatBit.Fits, floatBit.Fits},
+			goldenMaxError:   0,
+			goldenOverflows:  0,
+			goldenUnderflows: 0,
+		},
+		{
+			// 7/6 rounds down to the scale 1.125, so 7 no longer fits
+			name:             "ScaleRoundsDown",
+			values:           []float32{7, 1},
+			rm:               floatBit.RoundNearestEven,
+			goldenScales:     []E4M3.Bits{0b0_0111_001},
+			goldenElements:   []E2M1.Bits{0b0_11_1, 0b0_01_0},
+			goldenAcc:        []big.Accuracy{big.Below, big.Above},
+			goldenStatus:     []floatBit.Status{floatBit.Overflow, floatBit.Fits},
+			goldenMaxError:   0.25,
+			goldenOverflows:  1,
+			goldenUnderflows: 0,
+		},
+		{
+			name:             "RTZ",
+			values:           []float32{5, 1.3},
+			rm:               floatBit.RoundTowardsZero,
+			goldenScales:     []E4M3.Bits{0b0_0110_101},
+			goldenElements:   []E2M1.Bits{0b0_11_1, 0b0_01_1},
+			goldenAcc:        []big.Accuracy{big.Below, big.Below},
+			goldenStatus:     []floatBit.Status{floatBit.Overflow, floatBit.Fits},
+			goldenMaxError:   0.125,
+			goldenOverflows:  1,
+			goldenUnderflows: 0,
+		},
+		{
+			name:             "Underflow",
+			values:           []float32{6, -0.1},
+			rm:               floatBit.RoundNearestEven,
+			goldenScales:     []E4M3.Bits{0b0_0111_000},
+			goldenElements:   []E2M1.Bits{0b0_11_1, 0b1_00_0},
+			goldenAcc:        []big.Accuracy{big.Exact, big.Above},
+			goldenStatus:     []floatBit.Status{floatBit.Fits, floatBit.Underflow},
+			goldenMaxError:   float64(float32(0.1)),
+			goldenOverflows:  0,
+			goldenUnderflows: 1,
+		},
+		{
+			// Below the smallest subnormal, but closer to 0.5 than to 0
+			name:             "RoundsToSubnormal",
+			values:           []float32{-6, 0.3},
+			rm:               floatBit.RoundNearestEven,
+			goldenScales:     []E4M3.Bits{0b0_0111_000},
+			goldenElements:   []E2M1.Bits{0b1_11_1, 0b0_00_1},
+			goldenAcc:        []big.Accuracy{big.Exact, big.Above},
+			goldenStatus:     []floatBit.Status{floatBit.Fits, floatBit.Underflow},
+			goldenMaxError:   0.5 - float64(float32(0.3)),
+			goldenOverflows:  0,
+			goldenUnderflows: 1,
+		},
+		{
+			// The scale clamps to 448, so 5376 / 448 = 12 overflows
+			name:             "ScaleOverflow",
+			values:           []float32{5376, -448},
+			rm:               floatBit.RoundNearestEven,
+			goldenScales:     []E4M3.Bits{0b0_1111_110},
+			goldenElements:   []E2M1.Bits{0b0_11_1, 0b1_01_0},
+			goldenAcc:        []big.Accuracy{big.Below, big.Exact},
+			goldenStatus:     []floatBit.Status{floatBit.Overflow, floatBit.Fits},
+			goldenMaxError:   2688,
+			goldenOverflows:  1,
+			goldenUnderflows: 0,
+		},
+		{
+			name:             "InfAndNaN",
+			values:           []float32{float32(math.Inf(1)), float32(math.NaN()), 3},
+			rm:               floatBit.RoundNearestEven,
+			goldenScales:     []E4M3.Bits{0b0_0110_000},
+			goldenElements:   []E2M1.Bits{0b0_11_1, 0b0_00_0, 0b0_11_1},
+			goldenAcc:        []big.Accuracy{big.Below, big.Exact, big.Exact},
+			goldenStatus:     []floatBit.Status{floatBit.NoEncoding, floatBit.NoEncoding, floatBit.Fits},
+			goldenMaxError:   0,
+			goldenOverflows:  0,
+			goldenUnderflows: 0,
+		},
+		{
+			name:             "AllZeros",
+			values:           []float32{0, float32(math.Copysign(0, -1))},
+			rm:               floatBit.RoundNearestEven,
+			goldenScales:     []E4M3.Bits{0},
+			goldenElements:   []E2M1.Bits{0b0_00_0, 0b1_00_0},
+			goldenAcc:        []big.Accuracy{big.Exact, big.Exact},
+			goldenStatus:     []floatBit.Status{floatBit.Fits, floatBit.Fits},
+			goldenMaxError:   0,
+			goldenOverflows:  0,
+			goldenUnderflows: 0,
+		},
+	}
+
+	for _, tt := range testCases {
+		block, report := Quantize(tt.values, tt.rm)
+		if block.TensorScale != 1 {
+			t.Errorf("%s: Expected tensor scale: 1, Got: %v", tt.name, block.TensorScale)
+		}
+		for i := range tt.goldenScales {
+			if block.Scales[i] != tt.goldenScales[i] {
+				t.Errorf("%s: Expected scale %d: %0#2x, Got: %0#2x", tt.name, i, tt.goldenScales[i], block.Scales[i])
+			}
+		}
+		for i := range tt.values {
+			if block.Elements[i] != tt.goldenElements[i] ||
+				report.Accuracy[i] != tt.goldenAcc[i] ||
+				report.Status[i] != tt.goldenStatus[i] {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("Name: %s Element: %d", tt.name, i)
+				t.Errorf("Expected result: %0#2x, Got: %0#2x", tt.goldenElements[i], block.Elements[i])
+				t.Errorf("Expect accuracy: %v, Got: %v", tt.goldenAcc[i], report.Accuracy[i])
+				t.Errorf("Expected status: %v, Got: %v", tt.goldenStatus[i], report.Status[i])
+			}
+		}
+		maxError, _ := report.MaxAbsError.Float64()
+		if math.Abs(maxError-tt.goldenMaxError) > 1e-6 {
+			t.Errorf("%s: Expected max error: %v, Got: %v", tt.name, tt.goldenMaxError, maxError)
+		}
+		if report.Overflows != tt.goldenOverflows || report.Underflows != tt.goldenUnderflows {
+			t.Errorf("%s: Expected %d overflows and %d underflows, Got: %d and %d", tt.name,
+				tt.goldenOverflows, tt.goldenUnderflows, report.Overflows, report.Underflows)
+		}
+	}
+}
+
+func TestQuantizeMultipleBlocks(t *testing.T) {
+	values := make([]float32, BlockSize+1)
+	for i := range BlockSize {
+		values[i] = 3
+	}
+	values[BlockSize] = -12
+
+	block, report := Quantize(values, floatBit.RoundNearestEven)
+	if len(block.Scales) != 2 || block.Scales[0] != 0b0_0110_000 || block.Scales[1] != 0b0_1000_000 {
+		t.Errorf("Expected scales: [0x30 0x40], Got: %#x", block.Scales)
+	}
+	if block.Elements[0] != 0b0_11_1 || block.Elements[BlockSize] != 0b1_11_1 {
+		t.Errorf("Expected elements 0x7 and 0xf, Got: %0#2x and %0#2x", block.Elements[0], block.Elements[BlockSize])
+	}
+	if report.MaxAbsError.Sign() != 0 {
+		t.Errorf("Expected no error, Got: %v", report.MaxAbsError.String())
+	}
+}
+
+func TestQuantizeWithTensorScale(t *testing.T) {
+	// With the tensor scale, the values from the ScaleOverflow case fit
+	values := []float32{5376, -448}
+	tensorScale := ComputeTensorScale(values)
+	if tensorScale != 2 {
+		t.Fatalf("Expected tensor scale: 2, Got: %v", tensorScale)
+	}
+	block, report, err := QuantizeWithTensorScale(values, tensorScale, floatBit.RoundNearestEven)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if block.TensorScale != 2 || block.Scales[0] != 0b0_1111_110 ||
+		block.Elements[0] != 0b0_11_1 || block.Elements[1] != 0b1_00_1 {
+		t.Errorf("Expected tensor scale 2, scale 0x7e, elements [0x7 0x9], Got: %v, %0#2x, %#x",
+			block.TensorScale, block.Scales[0], block.Elements)
+	}
+	if report.MaxAbsError.Sign() != 0 || report.Overflows != 0 {
+		t.Errorf("Expected no error and no overflows, Got: %v, %d", report.MaxAbsError.String(), report.Overflows)
+	}
+
+	// Invalid tensor scales
+	for _, tensorScale := range []float32{0, -1, float32(math.NaN()), float32(math.Inf(1))} {
+		if _, _, err := QuantizeWithTensorScale(values, tensorScale, floatBit.RoundNearestEven); err == nil {
+			t.Errorf("Expected an error for tensor scale %v", tensorScale)
+		}
+	}
+}
+
+func TestQuantizeStochastic(t *testing.T) {
+	// 2.5 is halfway between the E2M1 values 2 and 3
+	values := []float32{6, 2.5, -2.5}
+	testCases := []struct {
+		rb             floatBit.ExplicitRandomBits
+		goldenElements []E2M1.Bits
+	}{
+		{0, []E2M1.Bits{0b0_11_1, 0b0_10_1, 0b1_10_1}},
+		{1<<63 - 1, []E2M1.Bits{0b0_11_1, 0b0_10_1, 0b1_10_1}},
+		{1 << 63, []E2M1.Bits{0b0_11_1, 0b0_10_0, 0b1_10_0}},
+	}
+
+	for _, tt := range testCases {
+		block, _ := Quantize(values, floatBit.RoundStochastic, tt.rb)
+		for i, golden := range tt.goldenElements {
+			if block.Elements[i] != golden {
+				t.Errorf("Random Bits: %#x Element %d: Expected: %0#2x, Got: %0#2x",
+					uint64(tt.rb), i, golden, block.Elements[i])
+			}
+		}
+	}
+}
+
+func TestComputeTensorScale(t *testing.T) {
+	testCases := []struct {
+		values []float32
+		golden float32
+	}{
+		{[]float32{0, 0}, 1},
+		{[]float32{2688, -5376}, 2},
+		{[]float32{float32(math.NaN()), float32(math.Inf(-1)), 1344}, 0.5},
+		{[]float32{math.SmallestNonzeroFloat32}, math.SmallestNonzeroFloat32},
+	}
+
+	for _, tt := range testCases {
+		if result := ComputeTensorScale(tt.values); result != tt.golden {
+			t.Errorf("Values: %v, Expected: %v, Got: %v", tt.values, tt.golden, result)
+		}
+	}
+}
+
+func TestDequantize(t *testing.T) {
+	block := Block{TensorScale: 0.5, Scales: []E4M3.Bits{0b0_0111_000, 0b0_1000_000},
+		Elements: make([]E2M1.Bits, BlockSize+1)}
+	block.Elements[0] = 0b0_11_1
+	block.Elements[1] = 0b1_00_1
+	block.Elements[BlockSize] = 0b1_10_1
+
+	result := Dequantize(block)
+	golden := map[int]float32{0: 3, 1: -0.25, 2: 0, BlockSize: -3}
+	for i, value := range golden {
+		if result[i] != value {
+			t.Errorf("Element %d: Expected: %v, Got: %v", i, value, result[i])
+		}
+	}
+}
+
+func TestRoundedQuotient(t *testing.T) {
+	testCases := []struct {
+		// Inputs
+		numerator   float64
+		denominator float64
+		// Outputs
+		golden uint32
+	}{
+		// Exact
+		{1, 2, 0x3f000000},
+		// Inexact quotients have the last bit set
+		{1, 3, 0x3eaaaaab},
+		{-1, 10, 0xbdcccccd},
+		// Clamped
+		{1, math.Ldexp(1, -30), 0x49800000},
+		{-1, math.Ldexp(1, 30), 0xb5800000},
+	}
+
+	for _, tt := range testCases {
+		result := roundedQuotient(big.NewFloat(tt.numerator), big.NewFloat(tt.denominator))
+		if math.Float32bits(result) != tt.golden {
+			t.Errorf("%v / %v: Expected: %0#8x, Got: %0#8x", tt.numerator, tt.denominator,
+				tt.golden, math.Float32bits(result))
+		}
+	}
+}
@@ -7,19 +7,55 @@ type OverflowMode uint8
 
 // OverflowMode
 //
-// MakeNaN: If the result overflows, then the result is NaN
+// MakeNaN: If the result overflows, then the result is NaN. If the
+// destination format has signed NaNs then the sign is retained. Formats with a
+// single unsigned NaN (like the FNUZ FP8 formats) return that NaN instead
 //
 // SaturateMax: If the result overflows, then the result is Max Normal value
 // in the result format (sign is retained)
 //
 // SaturateInf: If the result overflows, then the result is Inf. If the destination
-// format supports signed infinities then the sign is retained
+// format supports signed infinities then the sign is retained. If the
+// destination format has no infinities (like OCP FP8 E4M3 or E4M3FNUZ), then
+// the result is NaN instead and the status is [NoEncoding]
+//
+// OverflowIEEE: The default of IEEE-754, where the rounding mode decides the
+// result (see [OverflowMode.Resolve]). The result is Inf for the rounding
+// modes that round to nearest, and the max normal value for rounding towards
+// zero. Rounding towards positive infinity gives Inf for positive numbers,
+// and the (negative) max normal for negative numbers, and rounding towards
+// negative infinity the other way around. Like IEEE-754, the result only
+// overflows if it exceeds the max normal value after rounding, so numbers
+// just above the max normal value can still round down to it
 const (
-	MakeNaN     OverflowMode = 0
-	SaturateMax OverflowMode = 1
-	SaturateInf OverflowMode = 2
+	MakeNaN      OverflowMode = 0
+	SaturateMax  OverflowMode = 1
+	SaturateInf  OverflowMode = 2
+	OverflowIEEE OverflowMode = 3
 )
 
+// Returns the response to overflow for a number with the given sign, rounded
+// with the given rounding mode. [OverflowIEEE] is either [SaturateInf] or
+// [SaturateMax], depending on the direction the rounding mode rounds in, and
+// the other modes don't depend on it. [RoundStochastic] and
+// [RoundAwayFromZero] round away from the max normal value, just like the
+// rounding modes that round to nearest
+func (o OverflowMode) Resolve(rm RoundingMode, negative bool) OverflowMode {
+	if o != OverflowIEEE {
+		return o
+	}
+	switch {
+	case rm == RoundTowardsZero:
+		return SaturateMax
+	case rm == RoundTowardsPositiveInf && negative:
+		return SaturateMax
+	case rm == RoundTowardsNegativeInf && !negative:
+		return SaturateMax
+	default:
+		return SaturateInf
+	}
+}
+
 // Stringer interface for OverflowMode
 func (o OverflowMode) String() string {
 	switch o {
@@ -29,6 +65,8 @@ func (o OverflowMode) String() string {
 		return "SaturateMax"
 	case SaturateInf:
 		return "SaturateInf"
+	case OverflowIEEE:
+		return "OverflowIEEE"
 	default:
 		return ""
 	}
@@ -46,11 +84,25 @@ type UnderflowMode uint8
 //
 // Underflow: If the result underflows, then flushes the result to 0
 // If the format supports signed zeros, then the sign is retained.
+//
+// RoundToSubnormal: Gradual underflow, like IEEE-754. The number is rounded
+// to either 0 or the minimum subnormal (sign is retained) with the rounding
+// mode, just like any other number between two neighbours in the format. The
+// status is still [Underflow]
 const (
-	SaturateMin UnderflowMode = 0
-	FlushToZero UnderflowMode = 1
+	SaturateMin      UnderflowMode = 0
+	FlushToZero      UnderflowMode = 1
+	RoundToSubnormal UnderflowMode = 2
 )
 
+// Returns true if numbers smaller than the minimum subnormal in magnitude are
+// rounded with the rounding mode, instead of being replaced with the response
+// to underflow right away. This is the case for [RoundToSubnormal], and when
+// tininess is detected after rounding
+func (u UnderflowMode) RoundsBelowMinSubnormal(t Tininess) bool {
+	return u == RoundToSubnormal || t == TininessAfterRounding
+}
+
 // Stringer interface for UnderflowMode
 func (u UnderflowMode) String() string {
 	switch u {
@@ -58,6 +110,44 @@ func (u UnderflowMode) String() string {
 		return "SaturateMin"
 	case FlushToZero:
 		return "FlushToZero"
+	case RoundToSubnormal:
+		return "RoundToSubnormal"
+	default:
+		return ""
+	}
+}
+
+// Tininess decides when a result is tiny, which is when it underflows. The
+// [UnderflowMode] then decides the result. IEEE-754 allows both (x86 detects
+// tininess after rounding, and ARM before)
+type Tininess uint8
+
+// Tininess
+//
+// TininessBeforeRounding: Every number smaller than the minimum subnormal in
+// magnitude underflows, and the [UnderflowMode] decides the result. Numbers
+// smaller than the minimum normal raise [ExceptionUnderflow] if they are
+// inexact
+//
+// TininessAfterRounding: The number is rounded first. Only the numbers that
+// round to zero (with the subnormals of the destination format) underflow, so
+// a number that rounds up to the minimum subnormal fits. Numbers just below
+// the minimum normal that would round up to it with an unbounded exponent
+// range don't raise [ExceptionUnderflow]. [RoundStochastic] always detects
+// tininess before rounding for [ExceptionUnderflow], since the rounded result
+// depends on the random bits
+const (
+	TininessBeforeRounding Tininess = 0
+	TininessAfterRounding  Tininess = 1
+)
+
+// Stringer interface for Tininess
+func (t Tininess) String() string {
+	switch t {
+	case TininessBeforeRounding:
+		return "TininessBeforeRounding"
+	case TininessAfterRounding:
+		return "TininessAfterRounding"
 	default:
 		return ""
 	}
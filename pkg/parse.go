@@ -0,0 +1,108 @@
+package floatBit
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Parses the number in s, which is then converted to a format with the
+// rounding mode rm. Rounding the input to prec bits first, and then to the
+// format, can give a different result than rounding the input to the format
+// directly, even if both use rm. For example, a number just above the midpoint
+// of two float32 numbers can round down to the midpoint at 53 bits, which then
+// rounds to even. So the exact value of s is rounded towards zero to prec bits,
+// and the last bit is set if any of the discarded bits were set (rounding to
+// odd). The last bit is a sticky bit, which keeps the result strictly between
+// the same two numbers of any format with at most prec-2 bits of precision as
+// s, so the conversion rounds it just like it would round s, for every
+// rounding mode. For [RoundStochastic], the bits below prec are only kept as
+// the sticky bit.
+//
+// s accepts the same syntax as [big.ParseFloat] with base 0. Infinities and
+// exponents that are too large to parse exactly are parsed with
+// [big.ParseFloat] instead, using the equivalent big rounding mode of rm, or
+// big.ToZero if it has none
+func ParseFloat(s string, prec uint, rm RoundingMode) (*big.Float, error) {
+	var exact big.Rat
+	if _, ok := exact.SetString(s); !ok {
+		bigMode, ok := rm.ToBigRoundingModeOk()
+		if !ok {
+			bigMode = big.ToZero
+		}
+		result, _, err := big.ParseFloat(s, 0, prec, bigMode)
+		return result, err
+	}
+
+	result := new(big.Float).SetPrec(prec).SetMode(big.ToZero).SetRat(&exact)
+	roundToOdd(result)
+
+	// big.Rat doesn't have a negative zero
+	if exact.Sign() == 0 && strings.HasPrefix(strings.TrimSpace(s), "-") {
+		result.Neg(result)
+	}
+	return result.SetMode(big.ToNearestEven), nil
+}
+
+// Parses the number in s like [ParseFloat], but rounds it to nearest even
+// with prec bits. With enough precision, this is the number s itself, which is
+// what the input is displayed as, and what the error of a conversion is
+// measured against. The result of [ParseFloat] is only meant to be converted,
+// since its last bit is a sticky bit
+func ParseFloatNearest(s string, prec uint) (*big.Float, error) {
+	var exact big.Rat
+	if _, ok := exact.SetString(s); !ok {
+		result, _, err := big.ParseFloat(s, 0, prec, big.ToNearestEven)
+		return result, err
+	}
+
+	result := new(big.Float).SetPrec(prec).SetRat(&exact)
+
+	// big.Rat doesn't have a negative zero
+	if exact.Sign() == 0 && strings.HasPrefix(strings.TrimSpace(s), "-") {
+		result.Neg(result)
+	}
+	return result, nil
+}
+
+// Parses the NaN in s, like nan, -snan or nan(0x2a), which is the syntax
+// [NaN.String] produces. The case doesn't matter, and qnan is the same as nan.
+// The optional payload in parentheses accepts the same syntax as
+// [big.Int.SetString] with base 0, and must not be negative. Returns false if
+// s isn't a NaN
+func ParseNaN(s string) (NaN, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	var result NaN
+	if unsigned, ok := strings.CutPrefix(s, "-"); ok {
+		result.Negative = true
+		s = unsigned
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+
+	switch {
+	case strings.HasPrefix(s, "snan"):
+		result.Signaling = true
+		s = s[len("snan"):]
+	case strings.HasPrefix(s, "qnan"):
+		s = s[len("qnan"):]
+	case strings.HasPrefix(s, "nan"):
+		s = s[len("nan"):]
+	default:
+		return NaN{}, false
+	}
+	if s == "" {
+		return result, true
+	}
+
+	payload, hasOpen := strings.CutPrefix(s, "(")
+	payload, hasClose := strings.CutSuffix(payload, ")")
+	if !hasOpen || !hasClose {
+		return NaN{}, false
+	}
+	var ok bool
+	result.Payload, ok = new(big.Int).SetString(payload, 0)
+	if !ok || result.Payload.Sign() < 0 {
+		return NaN{}, false
+	}
+	return result, true
+}
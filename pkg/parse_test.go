@@ -0,0 +1,196 @@
+package floatBit_test
+
+import (
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+)
+
+func TestToBigRoundingMode(t *testing.T) {
+	testCases := []struct {
+		rm       floatBit.RoundingMode
+		golden   big.RoundingMode
+		goldenOk bool
+	}{
+		{floatBit.RoundTowardsZero, big.ToZero, true},
+		{floatBit.RoundTowardsNegativeInf, big.ToNegativeInf, true},
+		{floatBit.RoundTowardsPositiveInf, big.ToPositiveInf, true},
+		{floatBit.RoundNearestEven, big.ToNearestEven, true},
+		{floatBit.RoundAwayFromZero, big.AwayFromZero, true},
+		{floatBit.RoundHalfAwayFromZero, big.ToNearestAway, true},
+		{floatBit.RoundHalfTowardsZero, big.ToNearestEven, false},
+		{floatBit.RoundHalfTowardsNegativeInf, big.ToNearestEven, false},
+		{floatBit.RoundHalfTowardsPositiveInf, big.ToNearestEven, false},
+		{floatBit.RoundNearestOdd, big.ToNearestEven, false},
+		{floatBit.RoundStochastic, big.ToNearestEven, false},
+	}
+
+	for _, tt := range testCases {
+		result, ok := tt.rm.ToBigRoundingModeOk()
+		if result != tt.golden || ok != tt.goldenOk {
+			t.Errorf("RoundingMode: %v Expected: %v %v, Got: %v %v", tt.rm, tt.golden,
+				tt.goldenOk, result, ok)
+		}
+		if result := tt.rm.ToBigRoundingMode(); result != tt.golden {
+			t.Errorf("RoundingMode: %v Expected: %v, Got: %v", tt.rm, tt.golden, result)
+		}
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input string
+		prec  uint
+		// Outputs
+		golden string
+	}{
+		{"Exact", "0.375", 53, "0x1.8p-02"},
+		{"ExactHex", "-0x1.000001p0", 25, "-0x1.000001p+00"},
+		{"NegativeZero", "-0", 53, "-0x0p+00"},
+		{"Infinity", "-Inf", 53, "-Inf"},
+		// 0.1 is 0x1.99999...p-4, and the LSB of the truncated value is even
+		{"TenthEvenLSB", "0.1", 8, "0x1.9ap-04"},
+		{"Negative", "-0.1", 8, "-0x1.9ap-04"},
+		// 0.3 is 0x1.33333...p-2, and the truncated LSB is already odd
+		{"ThreeTenthsOddLSB", "0.3", 8, "0x1.32p-02"},
+		// Bits far below the precision only set the LSB
+		{"Sticky", "0x1.0000000000000000000001p0", 8, "0x1.02p+00"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := floatBit.ParseFloat(tt.input, tt.prec, floatBit.RoundNearestEven)
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if result.Text('p', 0) != parseHex(t, tt.golden).Text('p', 0) ||
+				result.Signbit() != parseHex(t, tt.golden).Signbit() {
+				t.Errorf("Input: %s Expected: %s, Got: %s", tt.input, tt.golden, result.Text('x', -1))
+			}
+		})
+	}
+
+	if _, err := floatBit.ParseFloat("1.5x", 53, floatBit.RoundNearestEven); err == nil {
+		t.Errorf("Expected an error for an invalid number")
+	}
+}
+
+func TestParseFloatNearest(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input string
+		prec  uint
+		// Outputs
+		golden string
+	}{
+		{"Exact", "0.375", 53, "0x1.8p-02"},
+		{"NegativeZero", "-0", 53, "-0x0p+00"},
+		{"Infinity", "-Inf", 53, "-Inf"},
+		// Rounds up, where rounding to odd truncates
+		{"Tenth", "0.1", 53, "0x1.999999999999ap-04"},
+		{"Fraction", "-1/3", 8, "-0x1.56p-02"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := floatBit.ParseFloatNearest(tt.input, tt.prec)
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if result.Text('p', 0) != parseHex(t, tt.golden).Text('p', 0) ||
+				result.Signbit() != parseHex(t, tt.golden).Signbit() {
+				t.Errorf("Input: %s Expected: %s, Got: %s", tt.input, tt.golden, result.Text('x', -1))
+			}
+		})
+	}
+
+	if _, err := floatBit.ParseFloatNearest("1.5x", 53); err == nil {
+		t.Errorf("Expected an error for an invalid number")
+	}
+}
+
+func parseHex(t *testing.T, input string) *big.Float {
+	result, _, err := big.ParseFloat(input, 0, 200, big.ToNearestEven)
+	if err != nil {
+		t.Fatalf("Invalid golden value %s: %v", input, err)
+	}
+	return result
+}
+
+// Parsing with 53 bits and then converting to float32 must give the same result
+// as converting the exact input, for every rounding mode
+func TestParseFloatNoDoubleRounding(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input string
+		rm    floatBit.RoundingMode
+		// Outputs
+		golden F32.Bits
+	}{
+		// Just above the midpoint of 1 and the next float32 number. This
+		// rounds down to the midpoint at 53 bits, which then rounds to even
+		{"AboveTieRNE", "0x1.000001000000001p0", floatBit.RoundNearestEven, 0x3f80_0001},
+		{"AboveTieRoundHalfTowardsZero", "0x1.000001000000001p0",
+			floatBit.RoundHalfTowardsZero, 0x3f80_0001},
+		{"BelowTieRoundHalfAwayFromZero", "-0x1.000000fffffffffffp0",
+			floatBit.RoundHalfAwayFromZero, 0xbf80_0000},
+		// Just above 1. This rounds down to 1 at 53 bits, which is exact
+		{"AboveOneRTPosInf", "0x1.0000000000000000001p0", floatBit.RoundTowardsPositiveInf, 0x3f80_0001},
+		// Just above the midpoint of an odd and an even number
+		{"AboveTieRNO", "0x1.000003000000001p0", floatBit.RoundNearestOdd, 0x3f80_0002},
+		{"BelowNegativeOneRTNegInf", "-0x1.0000000000000000001p0",
+			floatBit.RoundTowardsNegativeInf, 0xbf80_0001},
+		{"DecimalRTZ", "1.00000005960464477539062499999999", floatBit.RoundTowardsZero, 0x3f80_0000},
+		{"DecimalRNE", "1.00000005960464477539062500000001", floatBit.RoundNearestEven, 0x3f80_0001},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := floatBit.ParseFloat(tt.input, 53, tt.rm)
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			result, _, _ := F32.FromBigFloat(*parsed, tt.rm, floatBit.SaturateInf,
+				floatBit.SaturateMin, floatBit.TininessBeforeRounding)
+			if result != tt.golden {
+				t.Errorf("Input: %s Expected: %#08x, Got: %#08x", tt.input, tt.golden, result)
+			}
+		})
+	}
+}
+
+func TestParseNaN(t *testing.T) {
+	testCases := []struct {
+		input    string
+		golden   string
+		goldenOk bool
+	}{
+		{"nan", "nan", true},
+		{"-NaN", "-nan", true},
+		{"+qnan", "nan", true},
+		{"sNaN", "snan", true},
+		{"-snan(0x2a)", "-snan(0x2a)", true},
+		{"nan(42)", "nan(0x2a)", true},
+		{"nan(0b1_0000)", "nan(0x10)", true},
+		{"nan(0)", "nan", true},
+		{"nan(-1)", "", false},
+		{"nan(0x2a", "", false},
+		{"nanx", "", false},
+		{"inf", "", false},
+		{"1.5", "", false},
+	}
+
+	for _, tt := range testCases {
+		result, ok := floatBit.ParseNaN(tt.input)
+		if ok != tt.goldenOk || (ok && result.String() != tt.golden) {
+			t.Errorf("Input: %s Expected: %s %v, Got: %s %v", tt.input, tt.golden, tt.goldenOk,
+				result, ok)
+		}
+	}
+}
@@ -24,16 +24,48 @@ const (
 	RoundNearestEven RoundingMode = 6
 
 	RoundNearestOdd RoundingMode = 7
+
+	// Rounds away from zero with probability equal to the discarded fraction
+	// of an ULP, and towards zero otherwise. Needs [RandomBits]
+	RoundStochastic RoundingMode = 8
+
+	RoundAwayFromZero RoundingMode = 9
+
+	// Ties are broken by rounding away from zero. This is roundTiesToAway in
+	// IEEE 754-2008
+	RoundHalfAwayFromZero RoundingMode = 10
 )
 
 // Returns the equivalent rounding mode constant defined in the big stdlib
-// package
+// package. The rounding modes that big doesn't have are returned as
+// big.ToNearestEven. Use [RoundingMode.ToBigRoundingModeOk] to tell them apart
 func (r RoundingMode) ToBigRoundingMode() big.RoundingMode {
+	result, _ := r.ToBigRoundingModeOk()
+	return result
+}
+
+// Returns the equivalent rounding mode constant defined in the big stdlib
+// package, like [RoundingMode.ToBigRoundingMode]. The second return value is
+// false for the rounding modes that big doesn't have (RoundHalfTowardsZero,
+// RoundHalfTowardsNegativeInf, RoundHalfTowardsPositiveInf, RoundNearestOdd
+// and RoundStochastic), in which case the first return value is
+// big.ToNearestEven. Use [ParseFloat] to parse inputs for these
+func (r RoundingMode) ToBigRoundingModeOk() (big.RoundingMode, bool) {
 	switch r {
-	case 0:
-		return big.ToZero
+	case RoundTowardsZero:
+		return big.ToZero, true
+	case RoundTowardsNegativeInf:
+		return big.ToNegativeInf, true
+	case RoundTowardsPositiveInf:
+		return big.ToPositiveInf, true
+	case RoundNearestEven:
+		return big.ToNearestEven, true
+	case RoundAwayFromZero:
+		return big.AwayFromZero, true
+	case RoundHalfAwayFromZero:
+		return big.ToNearestAway, true
 	default:
-		return big.ToNearestEven
+		return big.ToNearestEven, false
 	}
 }
 
@@ -56,6 +88,12 @@ func (r RoundingMode) String() string {
 		return "RoundNearestEven"
 	case RoundNearestOdd:
 		return "RoundNearestOdd"
+	case RoundStochastic:
+		return "RoundStochastic"
+	case RoundAwayFromZero:
+		return "RoundAwayFromZero"
+	case RoundHalfAwayFromZero:
+		return "RoundHalfAwayFromZero"
 	default:
 		return ""
 	}
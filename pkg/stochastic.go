@@ -0,0 +1,48 @@
+package floatBit
+
+import "math/big"
+
+// RandomBits is a source of the random bits used by [RoundStochastic]. The
+// sources of math/rand/v2 (like rand.PCG and rand.ChaCha8), and the *rand.Rand
+// of both math/rand and math/rand/v2 implement it, so a seeded source gives
+// reproducible results. The conversion functions take it as an optional last
+// argument, which is only used for [RoundStochastic]
+type RandomBits interface {
+	Uint64() uint64
+}
+
+// ExplicitRandomBits implements [RandomBits] by returning the same bits for
+// every rounding. This reproduces a single stochastic rounding exactly, like
+// when comparing with hardware that exposes the random bits it used
+type ExplicitRandomBits uint64
+
+// Returns the explicit bits
+func (b ExplicitRandomBits) Uint64() uint64 {
+	return uint64(b)
+}
+
+// Decides whether [RoundStochastic] rounds a number away from zero. fraction
+// is the part of the magnitude that is discarded, in ULPs, so it must be in
+// [0, 1). 64 random bits are drawn from rb and compared with the fraction,
+// which rounds away from zero with probability equal to the fraction, rounded
+// up to a multiple of 2^-64. Exact results (a fraction of 0) never round away,
+// and don't draw any bits. Panics if rb is empty
+func StochasticRoundAway(fraction *big.Float, rb ...RandomBits) bool {
+	if len(rb) == 0 || rb[0] == nil {
+		panic("RoundStochastic requires RandomBits")
+	}
+	if fraction.Sign() == 0 {
+		return false
+	}
+	random := rb[0].Uint64()
+
+	// The first 64 bits of the fraction, and whether any of the bits below
+	// them are set
+	var scaled big.Float
+	scaled.SetMantExp(fraction, 64)
+	threshold, acc := scaled.Int(nil)
+
+	// random < fraction * 2^64
+	return random < threshold.Uint64() ||
+		(random == threshold.Uint64() && acc != big.Exact)
+}
@@ -0,0 +1,207 @@
+package floatBit_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	randv2 "math/rand/v2"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	BF16 "github.com/shantanu-gontia/float-conv/pkg/bfloat16bits"
+	F128 "github.com/shantanu-gontia/float-conv/pkg/float128bits"
+	F16 "github.com/shantanu-gontia/float-conv/pkg/float16bits"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+	F64 "github.com/shantanu-gontia/float-conv/pkg/float64bits"
+	F80 "github.com/shantanu-gontia/float-conv/pkg/float80bits"
+	E2M1 "github.com/shantanu-gontia/float-conv/pkg/fp4e2m1bits"
+	E2M3 "github.com/shantanu-gontia/float-conv/pkg/fp6e2m3bits"
+	E3M2 "github.com/shantanu-gontia/float-conv/pkg/fp6e3m2bits"
+	E4M3 "github.com/shantanu-gontia/float-conv/pkg/fp8e4m3bits"
+	E4M3FNUZ "github.com/shantanu-gontia/float-conv/pkg/fp8e4m3fnuzbits"
+	E5M2 "github.com/shantanu-gontia/float-conv/pkg/fp8e5m2bits"
+	E5M2FNUZ "github.com/shantanu-gontia/float-conv/pkg/fp8e5m2fnuzbits"
+	TF32 "github.com/shantanu-gontia/float-conv/pkg/tf32bits"
+)
+
+func TestStochasticRoundAway(t *testing.T) {
+	quarter := big.NewFloat(0.25)
+	quarterAndSticky := new(big.Float).SetMantExp(big.NewFloat(1), -100)
+	quarterAndSticky.SetPrec(200).Add(quarterAndSticky, quarter)
+
+	testCases := []struct {
+		name     string
+		fraction *big.Float
+		rb       floatBit.ExplicitRandomBits
+		golden   bool
+	}{
+		{"ZeroFraction", big.NewFloat(0), 0, false},
+		{"BelowThreshold", quarter, 1<<62 - 1, true},
+		{"AtThreshold", quarter, 1 << 62, false},
+		{"AtThresholdWithSticky", quarterAndSticky, 1 << 62, true},
+		{"AboveThresholdWithSticky", quarterAndSticky, 1<<62 + 1, false},
+		{"LargestRandomBits", big.NewFloat(0.75), math.MaxUint64, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := floatBit.StochasticRoundAway(tt.fraction, tt.rb)
+			if result != tt.golden {
+				t.Errorf("Fraction: %v Random Bits: %#x Expected: %v, Got: %v",
+					tt.fraction.Text('p', 0), uint64(tt.rb), tt.golden, result)
+			}
+		})
+	}
+
+	t.Run("MissingRandomBits", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected a panic without RandomBits")
+			}
+		}()
+		floatBit.StochasticRoundAway(quarter)
+	})
+}
+
+// Converts a [big.Float] with a format specific package
+type stochasticConverter[T comparable] func(big.Float, floatBit.RoundingMode,
+	floatBit.OverflowMode, floatBit.UnderflowMode, floatBit.Tininess,
+	...floatBit.RandomBits) (T, big.Accuracy, floatBit.Status)
+
+// All zero random bits round every inexact result away from zero, and all one
+// random bits (almost) never do, so stochastic rounding with these must match
+// the directed rounding modes
+func runStochasticExtremesTest[T comparable](t *testing.T,
+	from stochasticConverter[T], inputs []*big.Float) {
+	for _, input := range inputs {
+		away, towards := floatBit.RoundTowardsPositiveInf, floatBit.RoundTowardsZero
+		if input.Signbit() {
+			away = floatBit.RoundTowardsNegativeInf
+		}
+		for _, om := range overflowModes {
+			for _, um := range underflowModes {
+				for _, tininess := range tininesses {
+					for _, tc := range []struct {
+						rb floatBit.ExplicitRandomBits
+						rm floatBit.RoundingMode
+					}{{0, away}, {math.MaxUint64, towards}} {
+						resultVal, resultAcc, resultStatus := from(*input,
+							floatBit.RoundStochastic, om, um, tininess, tc.rb)
+						goldenVal, goldenAcc, goldenStatus := from(*input, tc.rm, om, um, tininess)
+						if resultVal != goldenVal || resultAcc != goldenAcc ||
+							resultStatus != goldenStatus {
+							t.Errorf("Input: %v Random Bits: %#x OverflowMode: %v UnderflowMode: %v Tininess: %v",
+								input.Text('p', 0), uint64(tc.rb), om, um, tininess)
+							t.Errorf("Expected: %v (%v, %v), Got: %v (%v, %v)",
+								goldenVal, goldenAcc, goldenStatus, resultVal, resultAcc, resultStatus)
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// Returns 200-bit inputs with exponents in [minExponent, maxExponent]
+func stochasticInputs(rng *rand.Rand, minExponent, maxExponent int) []*big.Float {
+	inputs := []*big.Float{big.NewFloat(0), big.NewFloat(1), big.NewFloat(-1.5)}
+	for range 300 {
+		// A random 200-bit mantissa in [1, 2)
+		mantissa := new(big.Int).Rand(rng, new(big.Int).Lsh(big.NewInt(1), 199))
+		mantissa.SetBit(mantissa, 199, 1)
+		input := new(big.Float).SetPrec(200).SetInt(mantissa)
+		input.SetMantExp(input, minExponent+rng.Intn(maxExponent-minExponent+1)-199)
+		if rng.Intn(2) == 0 {
+			input.Neg(input)
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs
+}
+
+func TestStochasticExtremes(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+	small := stochasticInputs(rng, -160, 140)
+	wide := stochasticInputs(rng, -17000, 17000)
+
+	t.Run("F32", func(t *testing.T) { runStochasticExtremesTest(t, F32.FromBigFloat, small) })
+	t.Run("BF16", func(t *testing.T) { runStochasticExtremesTest(t, BF16.FromBigFloat, small) })
+	t.Run("F16", func(t *testing.T) { runStochasticExtremesTest(t, F16.FromBigFloat, small) })
+	t.Run("TF32", func(t *testing.T) { runStochasticExtremesTest(t, TF32.FromBigFloat, small) })
+	t.Run("E5M2", func(t *testing.T) { runStochasticExtremesTest(t, E5M2.FromBigFloat, small) })
+	t.Run("E4M3", func(t *testing.T) { runStochasticExtremesTest(t, E4M3.FromBigFloat, small) })
+	t.Run("E5M2FNUZ", func(t *testing.T) { runStochasticExtremesTest(t, E5M2FNUZ.FromBigFloat, small) })
+	t.Run("E4M3FNUZ", func(t *testing.T) { runStochasticExtremesTest(t, E4M3FNUZ.FromBigFloat, small) })
+	t.Run("E3M2", func(t *testing.T) { runStochasticExtremesTest(t, E3M2.FromBigFloat, small) })
+	t.Run("E2M3", func(t *testing.T) { runStochasticExtremesTest(t, E2M3.FromBigFloat, small) })
+	t.Run("E2M1", func(t *testing.T) { runStochasticExtremesTest(t, E2M1.FromBigFloat, small) })
+	t.Run("F64", func(t *testing.T) { runStochasticExtremesTest(t, F64.FromBigFloat, wide) })
+	t.Run("F128", func(t *testing.T) { runStochasticExtremesTest(t, F128.FromBigFloat, wide) })
+	t.Run("F80", func(t *testing.T) { runStochasticExtremesTest(t, F80.FromBigFloat, wide) })
+}
+
+// FromFloat32 hands stochastic rounding over to FromBigFloat, and must not
+// lose any of the discarded bits while doing so
+func TestStochasticFromFloat32(t *testing.T) {
+	// 1 + 3/8 ULP of bfloat16, exact in float32
+	input := float32(1 + 0.375/128)
+	testCases := []struct {
+		rb     floatBit.ExplicitRandomBits
+		golden BF16.Bits
+	}{
+		{3<<61 - 1, 0x3f81},
+		{3 << 61, 0x3f80},
+	}
+	for _, tt := range testCases {
+		result, _, _ := BF16.FromFloat32(input, floatBit.RoundStochastic,
+			floatBit.SaturateInf, floatBit.FlushToZero, floatBit.TininessBeforeRounding, tt.rb)
+		if result != tt.golden {
+			t.Errorf("Random Bits: %#x Expected: %#04x, Got: %#04x",
+				uint64(tt.rb), tt.golden, result)
+		}
+	}
+}
+
+// The probability of rounding up must be the discarded fraction, and the same
+// seed must give the same results
+func TestStochasticDistribution(t *testing.T) {
+	// 1 + 0.3 ULP of bfloat16
+	input := new(big.Float).SetPrec(200).SetFloat64(0.3)
+	input.SetMantExp(input, -7)
+	input.Add(input, big.NewFloat(1))
+
+	const samples = 20000
+	run := func(seed uint64) []uint64 {
+		rb := randv2.New(randv2.NewPCG(seed, 0))
+		results := make([]uint64, samples)
+		for i := range results {
+			resultBits, _, _ := floatBit.Encode(*input, floatBit.FormatBFloat16,
+				floatBit.RoundStochastic, floatBit.SaturateInf, floatBit.FlushToZero,
+				floatBit.TininessBeforeRounding, rb)
+			results[i] = resultBits.Uint64()
+		}
+		return results
+	}
+
+	results := run(1)
+	roundedUp := 0
+	for _, result := range results {
+		switch result {
+		case 0x3f81:
+			roundedUp++
+		case 0x3f80:
+		default:
+			t.Fatalf("Unexpected result: %#04x", result)
+		}
+	}
+	if fraction := float64(roundedUp) / samples; math.Abs(fraction-0.3) > 0.02 {
+		t.Errorf("Expected about 30%% of the results to round up, Got: %.2f%%", 100*fraction)
+	}
+
+	for i, result := range run(1) {
+		if result != results[i] {
+			t.Fatalf("Sample %d differs between runs with the same seed", i)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package TF32
+
+import "math/big"
+
+// Utility function that returns the number rounded to a number that is
+// representable in TF32. If y is the input number and x < |y| < x + 1ULP
+// where x is a TF32 number. Then this rounding mode picks up x + 1ULP
+// (with the sign of y).
+// The parameters are the same as for [roundTowardsPositiveInf].
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundAwayFromZero(signBit, exponentBits,
+	mantissaBits uint32) (Bits, big.Accuracy) {
+	// Rounding away from zero is rounding up for positive numbers, and
+	// rounding down for negative numbers
+	if signBit == 0 {
+		return roundTowardsPositiveInf(signBit, exponentBits, mantissaBits)
+	}
+	return roundTowardsNegativeInf(signBit, exponentBits, mantissaBits)
+}
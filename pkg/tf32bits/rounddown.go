@@ -0,0 +1,49 @@
+package TF32
+
+import "math/big"
+
+// Utility function that returns the number rounded to a number that is
+// representable in TF32. If y is the input number and x < y < x + 1ULP
+// where x is a TF32 number. Then this rounding mode picks up x
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the TF32 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundTowardsNegativeInf(signBit, exponentBits,
+	mantissaBits uint32) (Bits, big.Accuracy) {
+	return roundDown(signBit, exponentBits, mantissaBits)
+}
+
+func roundDown(signBit, exponentBits,
+	mantissaBits uint32) (Bits, big.Accuracy) {
+
+	mantissaTF32Precision := mantissaBits & 0x007f_e000
+	mantissaExtraPrecision := mantissaBits & 0x0000_1fff
+
+	tf32Sign := signBit << 18
+	tf32Exponent := exponentBits << 10
+	tf32Mantissa := mantissaTF32Precision >> 13
+
+	// For this rounding mode, we only need to add 1 to the Least-precision
+	// mantissa, if the input was positive, to bring it closer to +inf.
+	// For negative numbers, this is achieved by simply truncating.
+
+	exponentMantissaComposite := (tf32Exponent | tf32Mantissa)
+
+	// If positive and there is extra precision, then add 1
+	if (tf32Sign != 0) && (mantissaExtraPrecision != 0) {
+		exponentMantissaComposite += 1
+	}
+	// Since, we don't handle overflow, all we need to do now is attach the sign
+	resultVal := Bits((tf32Sign | exponentMantissaComposite) << 13)
+
+	resultAcc := big.Exact
+	// If there was extra precision bits set, then we need to
+	if mantissaExtraPrecision != 0 {
+		// We always round to a smaller value
+		resultAcc = big.Below
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,47 @@
+package TF32
+
+import "math/big"
+
+// Utility function that returns the number rounded to the closest float32
+// value. Ties are broken by rounding to the value closest to zero (truncation)
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the float32 bias applied
+// mantissaBits must be passed in their float64 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundHalfTowardsZero(signBit, exponentBits,
+	mantissaBits uint32) (Bits, big.Accuracy) {
+
+	mantissaTF32Precision := mantissaBits & 0x007f_e000
+	mantissaExtraPrecision := mantissaBits & 0x0000_1fff
+
+	tf32Sign := signBit << 18
+	tf32Exponent := exponentBits << 10
+	tf32Mantissa := mantissaTF32Precision >> 13
+
+	exponentMantissaComposite := tf32Exponent | tf32Mantissa
+
+	// If the extra precision bits exceed 1 0 0 0 0....
+	// we need to add 1 to LSB of F32 mantissa, otherwise truncate
+	// For all other cases we truncate
+	addedOne := false
+	if mantissaExtraPrecision > f32TF32HalfSubnormalLSB {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// All we need to do now is attach the sign
+	resultVal := Bits((tf32Sign | exponentMantissaComposite) << 13)
+	resultAcc := big.Exact
+
+	// Result is larger if the input was positive and we added 1, or
+	// if the input was negative and we truncated.
+	if mantissaExtraPrecision != 0 {
+		resultAcc = big.Below
+		if (tf32Sign == 0) == addedOne {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,56 @@
+package TF32
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number rounded to the closest float32
+// value. Ties are broken by rounding towards the value closer to +Infinity.
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the TF32 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundHalfTowardsPositiveInf(signBit, exponentBits,
+	mantissaBits uint32) (Bits, big.Accuracy) {
+
+	mantissaTF32Precision := mantissaBits & 0x007f_e000
+	mantissaExtraPrecision := mantissaBits & 0x0000_1fff
+
+	tf32Sign := signBit << 18
+	tf32Exponent := exponentBits << 10
+	tf32Mantissa := mantissaTF32Precision >> 13
+
+	exponentMantissaComposite := tf32Exponent | tf32Mantissa
+
+	addedOne := false
+	// We definitely add 1, if we're greater than the mid-point
+	if mantissaExtraPrecision > f32TF32HalfSubnormalLSB {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// In the case that we're halfway through,
+	// We add 1, only if the sign was positive, otherwise we truncate
+	if (mantissaExtraPrecision ==
+		f32TF32HalfSubnormalLSB) && (tf32Sign == 0) {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// For all other case we truncate, so now we can construct the result
+	// by attaching the sign
+	resultVal := Bits((tf32Sign | exponentMantissaComposite) << 13)
+	resultAcc := big.Exact
+
+	// Result is larger if the input was positive and we added 1, or
+	// if the input was negative and we truncated.
+	if mantissaExtraPrecision != 0 {
+		resultAcc = big.Below
+		if (tf32Sign == 0) == addedOne {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
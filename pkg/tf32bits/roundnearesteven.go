@@ -0,0 +1,72 @@
+package TF32
+
+import (
+	"math/big"
+)
+
+// Utility function that returns the number rounded to the closest TF32
+// value. Ties are broken by rounding to the even value (the LSB mantissa
+// bit is 0)
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the TF32 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundNearestEven(signBit, exponentBits, mantissaBits uint32) (Bits,
+	big.Accuracy) {
+
+	// For rounding to nearest even, we round to the number that is closest and
+	// break ties by rounding towards the number that is even (LSB is 0)
+
+	// LSB  |  Extra Precision Bits
+	//  m13    m12 m11 m10
+	// 1. if m12 m11 m10 ... > 1 0 0 0 ... (more than half) we round up
+	// 2. if m12 m11 m10 ... < 1 0 0 0 ... (less than half) we truncate
+	// 3. if m12 m11 m10 ... == 1 0 0 0 ... (exactly half), then
+	// 	  3.1 m13 == 0, we truncate
+	//    3.2 m13 == 1, we round up
+
+	mantissaTF32Precision := mantissaBits & 0x007f_e000
+	mantissaExtraPrecision := mantissaBits & 0x0000_1fff
+
+	tf32Sign := signBit << 18
+	tf32Exponent := exponentBits << 10
+	tf32Mantissa := mantissaTF32Precision >> 13
+
+	exponentMantissaComposite := tf32Exponent | tf32Mantissa
+
+	addedOne := false
+	// We definitely add 1, if we're greater than the mid-point
+	if mantissaExtraPrecision > f32TF32HalfSubnormalLSB {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	mantissaTF32LSB := mantissaBits & 0x0000_2000
+	// In the case we're at the mid-point, we only add 1, if the LSB of the
+	// TF32 retained mantissa is 1
+	if (mantissaTF32LSB != 0) && (mantissaExtraPrecision ==
+		f32TF32HalfSubnormalLSB) {
+		exponentMantissaComposite += 1
+		addedOne = true
+	}
+
+	// For all other case we truncate, so now we can construct the result
+	// by attaching the sign
+	resultVal := Bits((tf32Sign | exponentMantissaComposite) << 13)
+	resultAcc := big.Exact
+
+	// Result is larger if the input was positive and we added 1, or
+	// if the input was negative and we truncated.
+	if mantissaExtraPrecision != 0 {
+		resultAcc = big.Below
+		if (tf32Sign == 0) && addedOne {
+			resultAcc = big.Above
+		}
+		if (tf32Sign != 0) && !addedOne {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,42 @@
+package TF32
+
+import "math/big"
+
+// Utility function that returns the number truncated to a number that can
+// be represented as a TF32 number.
+// signBit, and exponentBits must be passed with their values shifted all the
+// way to the right.
+// exponentBits must be passed with the TF32 bias applied
+// mantissaBits must be passed in their float32 locations.
+// NOTE: This doesn't handle the underflow and overflow cases.
+func roundTowardsZero(signBit, exponentBits, mantissaBits uint32) (Bits,
+	big.Accuracy) {
+	return truncate(signBit, exponentBits, mantissaBits)
+}
+
+// truncation is the same as rounding towards zero
+func truncate(signBit, exponentBits, mantissaBits uint32) (Bits, big.Accuracy) {
+	mantissaTF32Precision := mantissaBits & 0x007f_e000
+	mantissaExtraPrecision := mantissaBits & 0x0000_1fff
+
+	tf32Sign := signBit << 18
+	tf32Exponent := exponentBits << 10
+	// we need to move mantissa bits to the right, so they align with the
+	// mantissa bits in the TF32 format
+	tf32Mantissa := mantissaTF32Precision >> 13
+
+	resultVal := Bits((tf32Sign | tf32Exponent | tf32Mantissa) << 13)
+	resultAcc := big.Exact
+
+	// If there was extra precision, then the number did not fit in the
+	// TF32 format, so we need to report the status appropriately
+	if mantissaExtraPrecision != 0 {
+		if signBit == 0 {
+			resultAcc = big.Below
+		} else {
+			resultAcc = big.Above
+		}
+	}
+
+	return resultVal, resultAcc
+}
@@ -0,0 +1,328 @@
+package TF32
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+)
+
+// Some constants that will help with bit manipulation we'll need to perform
+const (
+	f32TF32ExtraPrecisionMask uint32 = 0x0000_1fff
+)
+
+// Alias type for uint32. This is used to represent the bits that make up a
+// TensorFloat-32 (TF32) number. TF32 has the same 8 exponent bits as float32,
+// but only 10 mantissa bits, which is the precision the matrix units of
+// NVIDIA Ampere (and later) GPUs use for float32 inputs. Just like on the
+// GPU, the 19 bits are stored in the upper bits of a 32-bit container, so
+// the lower 13 bits are always 0. This type also comes with utility methods
+// to support Floating point conversions with different Rounding Modes and Out
+// of Bounds responses.
+type Bits uint32
+
+// Convert the given [Bits] type to the floating point number it represents,
+// inside a [float32] value. TF32 is stored in the upper 19 bits of a float32,
+// so this is a bit_cast to [float32], and NaNs keep their sign, payload and
+// whether they are quiet or signaling
+func (input Bits) ToFloat32() float32 {
+	return math.Float32frombits(uint32(input))
+}
+
+// Convert the given [Bits] type to a [big.Float] arbitrary precision
+// floating-point number
+func (input Bits) ToBigFloat() big.Float {
+	asFloat32 := input.ToFloat32()
+	asBigFloat := *big.NewFloat(float64(asFloat32))
+	return asBigFloat
+}
+
+// Convert the given [big.Float] arbitrary precision floating-point number
+// to a [Bits] type representing the bits of a TF32 number. If the number
+// cannot be represented in TF32 format exactly, then the rounding mode,
+// overflow mode and underflow mode decide the result. Returns the result
+// [Bits], a [big.Accuracy] which encodes whether the result value was the same,
+// larger or smaller than the input, and a [floatBit.Status] which encodes,
+// whether the result fit in the [Bits], caused overflow or underflow.
+// The [floatBit.Tininess] decides when the result underflows.
+// [floatBit.RoundStochastic] needs the optional rb argument.
+func FromBigFloat(input big.Float, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+
+	// Converting to an intermediate float32 first would round the input
+	// twice, and lose the bits below float32 precision. The generic encoder
+	// extracts the mantissa and the sticky bit directly from the [big.Float]
+	// instead, so the result is correctly rounded at any input precision
+	resultBits, resultAcc, resultStatus := floatBit.Encode(input,
+		floatBit.FormatTF32, rm, om, um, t, rb...)
+	// TF32 is stored in the upper 19 bits of the 32-bit container
+	return Bits(resultBits.Uint64() << 13), resultAcc, resultStatus
+}
+
+// Returns the [floatBit.NaN] the given [Bits] encode, and false if they
+// aren't a NaN. See [floatBit.Format.DecodeNaN]
+func (input Bits) DecodeNaN() (floatBit.NaN, bool) {
+	// TF32 is stored in the upper 19 bits of the 32-bit container
+	return floatBit.FormatTF32.DecodeNaN(new(big.Int).SetUint64(uint64(input) >> 13))
+}
+
+// Convert the given [floatBit.NaN] to a [Bits] type representing the bits of
+// a TF32 NaN, with the given [floatBit.NaNPolicy]. Returns the result
+// [Bits], a [floatBit.Status] and the [floatBit.Exceptions] raised by the
+// conversion. See [floatBit.Format.EncodeNaN]
+func FromNaN(input floatBit.NaN, p floatBit.NaNPolicy) (Bits, floatBit.Status,
+	floatBit.Exceptions) {
+	resultBits, resultStatus, resultExceptions := floatBit.FormatTF32.EncodeNaN(input, p)
+	return Bits(resultBits.Uint64() << 13), resultStatus, resultExceptions
+}
+
+// Convert the given [float32] number to a [Bits] type which represents the bits
+// of a TF32 number. Signature and usage is identical to [FromBigFloat],
+// except the parameter input for this function is [float32]. NaNs are always
+// canonicalized to [NaN], which drops the sign and the payload, like
+// [floatBit.NaNCanonicalize]. Use [FromNaN] to choose the [floatBit.NaNPolicy]
+func FromFloat32(input float32, rm floatBit.RoundingMode,
+	om floatBit.OverflowMode, um floatBit.UnderflowMode, t floatBit.Tininess,
+	rb ...floatBit.RandomBits) (Bits, big.Accuracy, floatBit.Status) {
+
+	// There are some special cases we need to handle, particularly, those of
+	// values like Inf, NaN which have special encodings in the target formats
+
+	// Since TF32 is essentially float32 with 13 less mantissa bits,
+	// conversion is simpler
+	inputAsBits := math.Float32bits(input)
+
+	// Special Case #1: Infinities
+	// Both float32 and TF32 have representations for positive and negative
+	// infinites.
+	if inputAsBits == F32.PositiveInfinity {
+		return Bits(PositiveInfinity), big.Exact, floatBit.Fits
+	}
+	if inputAsBits == F32.NegativeInfinity {
+		return Bits(NegativeInfinity), big.Exact, floatBit.Fits
+	}
+
+	// Special Case #2: NaNs
+	// NaNs always convert to NaNs. For our case, we consider the conversion
+	// to be exact
+	if math.IsNaN(float64(input)) {
+		return Bits(NaN), big.Exact, floatBit.Fits
+	}
+
+	// Stochastic rounding needs all of the discarded bits, not just the
+	// round and sticky bits, which [FromBigFloat] extracts. [FromBigFloat]
+	// also takes care of rounding the numbers below the minimum subnormal
+	// (see [floatBit.UnderflowMode.RoundsBelowMinSubnormal]), and of detecting
+	// overflow after rounding for [floatBit.OverflowIEEE]
+	if rm == floatBit.RoundStochastic || um.RoundsBelowMinSubnormal(t) ||
+		om == floatBit.OverflowIEEE {
+		return FromBigFloat(*big.NewFloat(float64(input)), rm, om, um, t, rb...)
+	}
+
+	// Special Case #3: Zeros
+	// Both Negative and Positive Zeros convert to their counterparts exactly
+	if inputAsBits == F32.PositiveZero {
+		return Bits(PositiveZero), big.Exact, floatBit.Fits
+	}
+	if inputAsBits == F32.NegativeZero {
+		return Bits(NegativeZero), big.Exact, floatBit.Fits
+	}
+
+	// With the number interpreted as uint32, we can extract the underlying
+	// sign, exponent and mantissa bits.
+	signBit := (inputAsBits & F32.SignMask) >> 31
+	exponentBits := (inputAsBits & F32.ExponentMask) >> 23
+	mantissaBits := (inputAsBits & F32.MantissaMask)
+
+	// Special Case #4: Input is subnormal (exponent bits == 0 && mantissa != 0)
+	// If the input is subnormal in the source format, which has more precision
+	// bits, it is possible to underflow in the destination format with lower
+	// precision bits. For float32 to TF32 conversion, this is only valid
+	// for the cases where the exponent bits are 0, and the only mantissa bits
+	// set are the extra precision bits.
+	// The exponent range for TF32 and float32 is exactly the same, so,
+	// we do not any explicit exponent and mantissa alignment to perform and
+	// can handle the rounding and this is the only case for underflow.
+	if exponentBits == 0 &&
+		(mantissaBits&F32.MantissaMask) <= f32TF32ExtraPrecisionMask {
+		return handleUnderflow(signBit, um)
+	}
+
+	// Special Case #5: Input exceeds the maximum normal number that is
+	// representable (in magnitude) in TF32. This constitutes overflow.
+	// In this case, the result is determined by the
+	// input om [floatBit.OverflowMode]
+	if (inputAsBits &^ F32.SignMask) > PositiveMaxNormal {
+		return handleOverflow(signBit, om)
+	}
+
+	var resultVal Bits
+	var resultAcc big.Accuracy
+
+	switch rm {
+	case floatBit.RoundTowardsZero:
+		resultVal, resultAcc =
+			roundTowardsZero(signBit, exponentBits, mantissaBits)
+	case floatBit.RoundTowardsNegativeInf:
+		resultVal, resultAcc =
+			roundDown(signBit, exponentBits, mantissaBits)
+	case floatBit.RoundTowardsPositiveInf:
+		resultVal, resultAcc =
+			roundUp(signBit, exponentBits, mantissaBits)
+	case floatBit.RoundHalfTowardsZero:
+		resultVal, resultAcc =
+			roundHalfTowardsZero(signBit, exponentBits, mantissaBits)
+	case floatBit.RoundHalfTowardsNegativeInf:
+		resultVal, resultAcc =
+			roundHalfTowardsNegativeInf(signBit, exponentBits, mantissaBits)
+	case floatBit.RoundHalfTowardsPositiveInf:
+		resultVal, resultAcc =
+			roundHalfTowardsPositiveInf(signBit, exponentBits, mantissaBits)
+	case floatBit.RoundNearestEven:
+		resultVal, resultAcc =
+			roundNearestEven(signBit, exponentBits, mantissaBits)
+	case floatBit.RoundNearestOdd:
+		resultVal, resultAcc =
+			roundNearestOdd(signBit, exponentBits, mantissaBits)
+	case floatBit.RoundAwayFromZero:
+		resultVal, resultAcc =
+			roundAwayFromZero(signBit, exponentBits, mantissaBits)
+	case floatBit.RoundHalfAwayFromZero:
+		resultVal, resultAcc =
+			roundHalfAwayFromZero(signBit, exponentBits, mantissaBits)
+	}
+
+	return resultVal, resultAcc, floatBit.Fits
+}
+
+func handleUnderflow(signBit uint32, um floatBit.UnderflowMode) (Bits,
+	big.Accuracy, floatBit.Status) {
+	switch um {
+	case floatBit.FlushToZero:
+		if signBit == 0 {
+			// Zero is less than any positive subnormal
+			return Bits(PositiveZero), big.Below, floatBit.Underflow
+		}
+		return Bits(NegativeZero), big.Above, floatBit.Underflow
+	case floatBit.SaturateMin:
+		if signBit == 0 {
+			// Min subnormal is larger than any float32 subnormal that results
+			// in underflow
+			return Bits(PositiveMinSubnormal), big.Above, floatBit.Underflow
+		}
+		return Bits(NegativeMinSubnormal), big.Below, floatBit.Underflow
+	default:
+		panic("Unsupported UnderflowMode encountered")
+	}
+}
+
+func handleOverflow(signBit uint32, om floatBit.OverflowMode) (Bits,
+	big.Accuracy, floatBit.Status) {
+	switch om {
+	case floatBit.SaturateInf:
+		if signBit == 0 {
+			// +Inf is greater than any other normal number in float32
+			return Bits(PositiveInfinity), big.Above, floatBit.Overflow
+		}
+		return Bits(NegativeInfinity), big.Below, floatBit.Overflow
+	case floatBit.MakeNaN:
+		if signBit == 0 {
+			// The accuracy doesn't matter for this case
+			return Bits(PositiveNaN), big.Above, floatBit.Overflow
+		}
+		return Bits(NegativeNaN), big.Below, floatBit.Overflow
+	case floatBit.SaturateMax:
+		if signBit == 0 {
+			// The maximum normal in TF32 is smaller than any number this
+			// function will be invoked for
+			return Bits(PositiveMaxNormal), big.Below, floatBit.Overflow
+		}
+		return Bits(NegativeMaxNormal), big.Above, floatBit.Overflow
+	default:
+		panic("Unsupported OverflowMode encountered")
+	}
+}
+
+// ToFloatFormat converts the given Bits type representing the bits that make
+// up a TF32 number into [floatBit.FloatBitFormat]
+// Implements the FloatBitFormatter Interface
+func (b *Bits) ToFloatFormat() floatBit.FloatBitFormat {
+	// Iterate over the bits and construct the return Values
+
+	asUint := uint32(*b)
+	signBits := (asUint & SignMask) >> 31
+	exponentBits := (asUint & ExponentMask) >> 23
+	mantissaBits := (asUint & MantissaMask) >> 13
+
+	// 1 Sign Bit
+	signRetVal := make([]byte, 0, 1)
+	if signBits == 0 {
+		signRetVal = append(signRetVal, byte('0'))
+	} else {
+		signRetVal = append(signRetVal, byte('1'))
+	}
+
+	// 8 Exponent Bits
+	exponentRetVal := make([]byte, 0, 8)
+	for i := 0; i < 8; i++ {
+		currentExponentBit := exponentBits & 0x1
+		var valueToAppend byte
+		if currentExponentBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		exponentRetVal = append(exponentRetVal, valueToAppend)
+		exponentBits >>= 1
+	}
+	slices.Reverse(exponentRetVal)
+
+	// 10 Mantissa Bits
+	mantissaRetVal := make([]byte, 0, 10)
+	for i := 0; i < 10; i++ {
+		currentMantissaBit := mantissaBits & 0x1
+		var valueToAppend byte
+		if currentMantissaBit == 0 {
+			valueToAppend = '0'
+		} else {
+			valueToAppend = '1'
+		}
+		mantissaRetVal = append(mantissaRetVal, valueToAppend)
+		mantissaBits >>= 1
+	}
+	slices.Reverse(mantissaRetVal)
+
+	return floatBit.FloatBitFormat{Sign: signRetVal,
+		Exponent: exponentRetVal, Mantissa: mantissaRetVal}
+}
+
+// Conversion error returns the difference between the input [big.Float]
+// number and the TF32 number represented by the bits in the [Bits] receiver
+func (b *Bits) ConversionError(input *big.Float) (big.Float, error) {
+	// If the receiver is a NaN then we return an error
+	asFloat32 := b.ToFloat32()
+	if math.IsNaN(float64(asFloat32)) {
+		return *big.NewFloat(0.0), errors.New("NaN encountered")
+	}
+
+	// Positive Infinity == Positive Infinity
+	if math.IsInf(float64(asFloat32), 1) &&
+		(input.IsInf() && (input.Sign() > 0)) {
+		return *big.NewFloat(0), nil
+	}
+
+	// Negative Infinity == Negative Infinity
+	if math.IsInf(float64(asFloat32), -1) &&
+		(input.IsInf() && (input.Sign() < 0)) {
+		return *big.NewFloat(0), nil
+	}
+
+	asBigFloat := b.ToBigFloat()
+	convDiff := asBigFloat.Sub(&asBigFloat, input)
+	return *convDiff, nil
+}
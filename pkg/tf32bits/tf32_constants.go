@@ -0,0 +1,38 @@
+package TF32
+
+// TF32 numbers are stored in the upper 19 bits of a 32-bit container, which
+// is laid out exactly like a float32. The lower 13 bits are always 0
+const (
+	SignMask     uint32 = 0x8000_0000
+	ExponentMask uint32 = 0x7f80_0000
+	MantissaMask uint32 = 0x007f_e000
+
+	PositiveInfinity uint32 = 0x7f80_0000
+	NegativeInfinity uint32 = 0xff80_0000
+
+	PositiveZero uint32 = 0x0000_0000
+	NegativeZero uint32 = 0x8000_0000
+
+	PositiveMaxNormal uint32 = 0x7f7f_e000
+	NegativeMaxNormal uint32 = 0xff7f_e000
+
+	PositiveMinSubnormal uint32 = 0x0000_2000
+	NegativeMinSubnormal uint32 = 0x8000_2000
+
+	// In TF32 format, all numbers with the exponent bits = 11111111
+	// and, mantissa bits not all zero, constitute the special NaN value
+	// Just like for bfloat16, the mantissa MSB is the quiet bit, and
+	// whenever the result of an operation is a NaN, we encode it as a quiet
+	// NaN with the payload bits=0
+	NaN         uint32 = 0x7fc0_0000
+	PositiveNaN uint32 = 0x7fc0_0000
+	NegativeNaN uint32 = 0xffc0_0000
+
+	ExponentBias int = 127
+	ExponentMin  int = -126
+	ExponentMax  int = 127
+)
+
+const (
+	f32TF32HalfSubnormalLSB uint32 = 0x0000_1000
+)
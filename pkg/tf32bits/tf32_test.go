@@ -0,0 +1,443 @@
+package TF32
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	floatBit "github.com/shantanu-gontia/float-conv/pkg"
+	F32 "github.com/shantanu-gontia/float-conv/pkg/float32bits"
+)
+
+func TestToFloat32(t *testing.T) {
+	testCases := []struct {
+		// Input
+		input Bits
+		// Output
+		golden float32
+	}{
+		{0x3f80_0000, 1.0},
+		{0xbf80_2000, -1.0009765625},
+		{Bits(PositiveMaxNormal), math.Float32frombits(0x7f7f_e000)},
+		{Bits(PositiveMinSubnormal), math.Float32frombits(0x0000_2000)},
+		{Bits(NegativeZero), math.Float32frombits(F32.NegativeZero)},
+		{Bits(PositiveInfinity), float32(math.Inf(1))},
+	}
+	for _, tt := range testCases {
+		result := tt.input.ToFloat32()
+		if math.Float32bits(result) != math.Float32bits(tt.golden) {
+			t.Errorf("Input: %0#8x, Expected Output: %0#8x. Got: %0#8x", tt.input, math.Float32bits(tt.golden), math.Float32bits(result))
+		}
+	}
+}
+
+func TestHandleOverflow(t *testing.T) {
+	testCases := []struct {
+		// In
+		signBit uint32
+		om      floatBit.OverflowMode
+		// Out
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{0, floatBit.SaturateInf, Bits(PositiveInfinity), big.Above, floatBit.Overflow},
+		{1, floatBit.SaturateInf, Bits(NegativeInfinity), big.Below, floatBit.Overflow},
+		{0, floatBit.MakeNaN, Bits(PositiveNaN), big.Above, floatBit.Overflow},
+		{1, floatBit.MakeNaN, Bits(NegativeNaN), big.Below, floatBit.Overflow},
+		{0, floatBit.SaturateMax, Bits(PositiveMaxNormal), big.Below, floatBit.Overflow},
+		{1, floatBit.SaturateMax, Bits(NegativeMaxNormal), big.Above, floatBit.Overflow},
+		{200, floatBit.SaturateMax, Bits(NegativeMaxNormal), big.Above, floatBit.Overflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run("HandleOverflow", func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := handleOverflow(tt.signBit, tt.om)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("SignBit: %v\tOverflowMode: %v\n", tt.signBit, tt.om)
+				t.Errorf("Expected Result: %0#8x, Got: %0#8x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+func TestHandleUnderflow(t *testing.T) {
+	testCases := []struct {
+		// In
+		signBit uint32
+		um      floatBit.UnderflowMode
+		// Out
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{0, floatBit.FlushToZero, Bits(PositiveZero), big.Below, floatBit.Underflow},
+		{1, floatBit.FlushToZero, Bits(NegativeZero), big.Above, floatBit.Underflow},
+		{200, floatBit.FlushToZero, Bits(NegativeZero), big.Above, floatBit.Underflow},
+		{0, floatBit.SaturateMin, Bits(PositiveMinSubnormal), big.Above, floatBit.Underflow},
+		{1, floatBit.SaturateMin, Bits(NegativeMinSubnormal), big.Below, floatBit.Underflow},
+		{200, floatBit.SaturateMin, Bits(NegativeMinSubnormal), big.Below, floatBit.Underflow},
+	}
+
+	for _, tt := range testCases {
+		t.Run("HandleUnderflow", func(t *testing.T) {
+			resultVal, resultAcc, resultStatus := handleUnderflow(tt.signBit, tt.um)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("SignBit: %v\tUnderflowMode: %v\n", tt.signBit, tt.um)
+				t.Errorf("Expected Result: %0#8x, Got: %0#8x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+				t.Errorf("Expected Status: %v, Got: %v\n", tt.goldenStatus, resultStatus)
+			}
+		})
+	}
+}
+
+// Test case shared by all the rounding function tests
+type roundTestCase struct {
+	// Inputs
+	signBit      uint32
+	exponentBits uint32
+	mantissaBits uint32
+	// Outputs
+	goldenVal Bits
+	goldenAcc big.Accuracy
+}
+
+func runRoundTests(t *testing.T, name string,
+	roundFunc func(uint32, uint32, uint32) (Bits, big.Accuracy),
+	testCases []roundTestCase) {
+	for _, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resultVal, resultAcc := roundFunc(tt.signBit, tt.exponentBits, tt.mantissaBits)
+			if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) {
+				t.Logf("Failed Input Set:\n")
+				t.Logf("signBit: %#08x, exponentBits: %#08x, mantissaBits: %#08x", tt.signBit, tt.exponentBits, tt.mantissaBits)
+				t.Errorf("Expected Result: %0#8x, Got: %0#8x\n", tt.goldenVal, resultVal)
+				t.Errorf("Expected Accuracy: %v, Got: %v\n", tt.goldenAcc, resultAcc)
+			}
+		})
+	}
+}
+
+func TestRoundTowardsZero(t *testing.T) {
+	runRoundTests(t, "RoundTowardsZero", roundTowardsZero, []roundTestCase{
+		// +Zero -> +Zero
+		{0, 0, 0, Bits(0x0), big.Exact},
+		// Exact
+		{0, 127, 0b0_00000000_0000000001_0000000000000, Bits(0x3f80_2000), big.Exact},
+		// Positive RTZ to below
+		{0, 127, 0b0_00000000_0000000001_1100000000000, Bits(0x3f80_2000), big.Below},
+		// Negative RTZ to above
+		{1, 1, 0b0_00000000_0000000101_1000000000001, Bits(0x8080_a000), big.Above},
+	})
+}
+
+func TestRoundTowardsPositiveInf(t *testing.T) {
+	runRoundTests(t, "RoundTowardsPositiveInf", roundTowardsPositiveInf, []roundTestCase{
+		{0, 127, 0b0_00000000_0000000001_0000000000000, Bits(0x3f80_2000), big.Exact},
+		// Positive rounds up
+		{0, 127, 0b0_00000000_0000000001_0000000000001, Bits(0x3f80_4000), big.Above},
+		// Negative truncates
+		{1, 127, 0b0_00000000_0000000001_1111111111111, Bits(0xbf80_2000), big.Above},
+		// Carry into the exponent
+		{0, 127, 0b0_00000000_1111111111_1000000000000, Bits(0x4000_0000), big.Above},
+	})
+}
+
+func TestRoundTowardsNegativeInf(t *testing.T) {
+	runRoundTests(t, "RoundTowardsNegativeInf", roundTowardsNegativeInf, []roundTestCase{
+		{1, 127, 0b0_00000000_0000000001_0000000000000, Bits(0xbf80_2000), big.Exact},
+		// Positive truncates
+		{0, 127, 0b0_00000000_0000000001_1111111111111, Bits(0x3f80_2000), big.Below},
+		// Negative rounds up in magnitude
+		{1, 127, 0b0_00000000_0000000001_0000000000001, Bits(0xbf80_4000), big.Below},
+		// Subnormal rounding up to the smallest normal
+		{1, 0, 0b0_00000000_1111111111_0000000000001, Bits(0x8080_0000), big.Below},
+	})
+}
+
+func TestRoundHalfTowardsZero(t *testing.T) {
+	runRoundTests(t, "RoundHalfTowardsZero", roundHalfTowardsZero, []roundTestCase{
+		// Below half truncates
+		{0, 127, 0b0_00000000_0000000001_0111111111111, Bits(0x3f80_2000), big.Below},
+		// Above half rounds up
+		{0, 127, 0b0_00000000_0000000001_1000000000001, Bits(0x3f80_4000), big.Above},
+		// Ties truncate
+		{0, 127, 0b0_00000000_0000000001_1000000000000, Bits(0x3f80_2000), big.Below},
+		{1, 127, 0b0_00000000_0000000001_1000000000000, Bits(0xbf80_2000), big.Above},
+	})
+}
+
+func TestRoundHalfTowardsPositiveInf(t *testing.T) {
+	runRoundTests(t, "RoundHalfTowardsPositiveInf", roundHalfTowardsPositiveInf, []roundTestCase{
+		{0, 127, 0b0_00000000_0000000001_0111111111111, Bits(0x3f80_2000), big.Below},
+		{1, 127, 0b0_00000000_0000000001_1000000000001, Bits(0xbf80_4000), big.Below},
+		// Ties round towards +inf
+		{0, 127, 0b0_00000000_0000000001_1000000000000, Bits(0x3f80_4000), big.Above},
+		{1, 127, 0b0_00000000_0000000001_1000000000000, Bits(0xbf80_2000), big.Above},
+	})
+}
+
+func TestRoundHalfTowardsNegativeInf(t *testing.T) {
+	runRoundTests(t, "RoundHalfTowardsNegativeInf", roundHalfTowardsNegativeInf, []roundTestCase{
+		{0, 127, 0b0_00000000_0000000001_0111111111111, Bits(0x3f80_2000), big.Below},
+		{0, 127, 0b0_00000000_0000000001_1000000000001, Bits(0x3f80_4000), big.Above},
+		// Ties round towards -inf
+		{0, 127, 0b0_00000000_0000000001_1000000000000, Bits(0x3f80_2000), big.Below},
+		{1, 127, 0b0_00000000_0000000001_1000000000000, Bits(0xbf80_4000), big.Below},
+	})
+}
+
+func TestRoundNearestEven(t *testing.T) {
+	runRoundTests(t, "RoundNearestEven", roundNearestEven, []roundTestCase{
+		{0, 127, 0b0_00000000_0000000001_0111111111111, Bits(0x3f80_2000), big.Below},
+		{1, 127, 0b0_00000000_0000000000_1000000000001, Bits(0xbf80_2000), big.Below},
+		// Ties round to the even value
+		{0, 127, 0b0_00000000_0000000001_1000000000000, Bits(0x3f80_4000), big.Above},
+		{0, 127, 0b0_00000000_0000000000_1000000000000, Bits(0x3f80_0000), big.Below},
+		{1, 127, 0b0_00000000_0000000010_1000000000000, Bits(0xbf80_4000), big.Above},
+		// Carry into the exponent
+		{0, 127, 0b0_00000000_1111111111_1000000000000, Bits(0x4000_0000), big.Above},
+	})
+}
+
+func TestRoundNearestOdd(t *testing.T) {
+	runRoundTests(t, "RoundNearestOdd", roundNearestOdd, []roundTestCase{
+		{0, 127, 0b0_00000000_0000000001_0111111111111, Bits(0x3f80_2000), big.Below},
+		{1, 127, 0b0_00000000_0000000000_1000000000001, Bits(0xbf80_2000), big.Below},
+		// Ties round to the odd value
+		{0, 127, 0b0_00000000_0000000001_1000000000000, Bits(0x3f80_2000), big.Below},
+		{0, 127, 0b0_00000000_0000000000_1000000000000, Bits(0x3f80_2000), big.Above},
+		{1, 127, 0b0_00000000_0000000010_1000000000000, Bits(0xbf80_6000), big.Below},
+	})
+}
+
+func TestRoundAwayFromZero(t *testing.T) {
+	runRoundTests(t, "RoundAwayFromZero", roundAwayFromZero, []roundTestCase{
+		{1, 127, 0b0_00000000_0000000001_0000000000000, Bits(0xbf80_2000), big.Exact},
+		// Both signs round up in magnitude
+		{0, 127, 0b0_00000000_0000000001_0000000000001, Bits(0x3f80_4000), big.Above},
+		{1, 127, 0b0_00000000_0000000001_0000000000001, Bits(0xbf80_4000), big.Below},
+		// Carry into the exponent
+		{0, 127, 0b0_00000000_1111111111_0000000000001, Bits(0x4000_0000), big.Above},
+	})
+}
+
+func TestRoundHalfAwayFromZero(t *testing.T) {
+	runRoundTests(t, "RoundHalfAwayFromZero", roundHalfAwayFromZero, []roundTestCase{
+		{0, 127, 0b0_00000000_0000000001_0111111111111, Bits(0x3f80_2000), big.Below},
+		{1, 127, 0b0_00000000_0000000001_1000000000001, Bits(0xbf80_4000), big.Below},
+		// Ties round away from zero
+		{0, 127, 0b0_00000000_0000000001_1000000000000, Bits(0x3f80_4000), big.Above},
+		{1, 127, 0b0_00000000_0000000001_1000000000000, Bits(0xbf80_4000), big.Below},
+	})
+}
+
+func TestFromBigFloat(t *testing.T) {
+	testCases := []struct {
+		name string
+		// Inputs
+		input big.Float
+		rm    floatBit.RoundingMode
+		um    floatBit.UnderflowMode
+		om    floatBit.OverflowMode
+		// Outputs
+		goldenVal    Bits
+		goldenAcc    big.Accuracy
+		goldenStatus floatBit.Status
+	}{
+		{
+			name:         "PosInfInput",
+			input:        *big.NewFloat(math.Inf(1)),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveInfinity),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegZeroInput",
+			input:        *big.NewFloat(float64(math.Float32frombits(F32.NegativeZero))),
+			rm:           floatBit.RoundHalfTowardsNegativeInf,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateInf,
+			goldenVal:    Bits(NegativeZero),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "OneThirdRNE",
+			input:        *new(big.Float).Quo(big.NewFloat(1), big.NewFloat(3)),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0x3eaa_a000),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "OneThirdRTPosInf",
+			input:        *new(big.Float).Quo(big.NewFloat(1), big.NewFloat(3)),
+			rm:           floatBit.RoundTowardsPositiveInf,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0x3eaa_c000),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegativeRTZ",
+			input:        *big.NewFloat(-0.1),
+			rm:           floatBit.RoundTowardsZero,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0xbdcc_c000),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "NegativeRTNegInf",
+			input:        *big.NewFloat(-0.1),
+			rm:           floatBit.RoundTowardsNegativeInf,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0xbdcc_e000),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "MaxNormalExact",
+			input:        *big.NewFloat(float64(math.Float32frombits(PositiveMaxNormal))),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.MakeNaN,
+			goldenVal:    Bits(PositiveMaxNormal),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			// The float32 maximum normal doesn't fit in TF32
+			name:         "Float32MaxSatMax",
+			input:        *big.NewFloat(math.MaxFloat32),
+			rm:           floatBit.RoundTowardsZero,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMaxNormal),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Overflow,
+		},
+		{
+			name:         "Float32MaxSatInf",
+			input:        *big.NewFloat(math.MaxFloat32),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateInf,
+			goldenVal:    Bits(PositiveInfinity),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Overflow,
+		},
+		{
+			name:         "NegativeOverflowToNaN",
+			input:        *big.NewFloat(-1e39),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.MakeNaN,
+			goldenVal:    Bits(NegativeNaN),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Overflow,
+		},
+		{
+			name:         "SubnormalExact",
+			input:        *big.NewFloat(math.Ldexp(1, -130)),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0x0008_0000),
+			goldenAcc:    big.Exact,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "SubnormalRNE",
+			input:        *big.NewFloat(math.Ldexp(1, -130) + math.Ldexp(1, -145)),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(0x0008_0000),
+			goldenAcc:    big.Below,
+			goldenStatus: floatBit.Fits,
+		},
+		{
+			name:         "PositiveUnderflowSatMin",
+			input:        *big.NewFloat(math.Ldexp(1, -140)),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.SaturateMin,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(PositiveMinSubnormal),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
+		{
+			name:         "NegativeUnderflowFlushZero",
+			input:        *big.NewFloat(-1e-46),
+			rm:           floatBit.RoundNearestEven,
+			um:           floatBit.FlushToZero,
+			om:           floatBit.SaturateMax,
+			goldenVal:    Bits(NegativeZero),
+			goldenAcc:    big.Above,
+			goldenStatus: floatBit.Underflow,
+		},
+	}
+
+	for _, tt := range testCases {
+		resultVal, resultAcc, resultStatus := FromBigFloat(tt.input, tt.rm, tt.om, tt.um, floatBit.TininessBeforeRounding)
+		if (resultVal != tt.goldenVal) || (resultAcc != tt.goldenAcc) || (resultStatus != tt.goldenStatus) {
+			t.Logf("Failed Input Set:\n")
+			t.Logf("Name: %s", tt.name)
+			t.Logf("Value: %s", tt.input.String())
+			t.Logf("Rounding Mode: %v", tt.rm)
+			t.Logf("Overflow Mode: %v", tt.om)
+			t.Logf("Underflow Mode: %v", tt.um)
+			t.Errorf("Expected result: %.10e (%0#8x), Got: %.10e (%0#8x)", tt.goldenVal.ToFloat32(), tt.goldenVal, resultVal.ToFloat32(), resultVal)
+			t.Errorf("Expect accuracy: %v, Got: %v", tt.goldenAcc, resultAcc)
+			t.Errorf("Expected status: %v, Got: %v", tt.goldenStatus, resultStatus)
+		}
+	}
+}
+
+func TestToFloatFormat(t *testing.T) {
+	input := Bits(0xbf80_6000)
+	result := input.ToFloatFormat()
+	if string(result.Sign) != "1" || string(result.Exponent) != "01111111" ||
+		string(result.Mantissa) != "0000000011" {
+		t.Errorf("Expected Sign: 1, Exponent: 01111111, Mantissa: 0000000011. Got: %v", result)
+	}
+}
+
+func TestNaN(t *testing.T) {
+	for _, input := range []Bits{Bits(NaN), Bits(NegativeNaN), 0x7f80_2000, 0xffa0_6000} {
+		asNaN, ok := input.DecodeNaN()
+		if !ok {
+			t.Errorf("Input: %0#8x Expected a NaN", input)
+			continue
+		}
+		result, status, _ := FromNaN(asNaN, floatBit.NaNPreserve)
+		if result != input || status != floatBit.Fits {
+			t.Errorf("Input: %0#8x Expected: %0#8x %v, Got: %0#8x %v", input, input, floatBit.Fits, result, status)
+		}
+	}
+
+	for _, input := range []Bits{Bits(PositiveInfinity), Bits(NegativeInfinity), 0x3f80_0000} {
+		if asNaN, ok := input.DecodeNaN(); ok {
+			t.Errorf("Input: %0#8x Expected no NaN, Got: %v", input, asNaN)
+		}
+	}
+
+	if result, _, _ := FromNaN(floatBit.NaN{}, floatBit.NaNQuiet); result != Bits(NaN) {
+		t.Errorf("Expected: %0#8x, Got: %0#8x", NaN, result)
+	}
+}